@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/handlers"
+)
+
+// modelsPkg holds the types the client re-exports as aliases rather than copies
+const modelsPkg = "github.com/yishak-cs/Neo4j_DB/internal/models"
+
+func main() {
+	out := flag.String("out", "operations.go", "file to write the generated client methods to")
+	flag.Parse()
+
+	source, err := generate(handlers.Operations())
+	if err != nil {
+		log.Fatalf("Failed to generate client: %v", err)
+	}
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		log.Fatalf("Failed to write client: %v", err)
+	}
+}
+
+// generator accumulates the declarations of the generated file
+type generator struct {
+	methods bytes.Buffer
+	// types holds the generated declaration of each named type, by name
+	types   map[string]string
+	aliases map[string]bool
+	seen    map[string]reflect.Type
+	// imports holds the packages the generated code uses
+	imports map[string]bool
+}
+
+// generate writes one client method per operation, with the types they need
+func generate(operations []handlers.Operation) ([]byte, error) {
+	g := &generator{
+		types:   map[string]string{},
+		aliases: map[string]bool{},
+		seen:    map[string]reflect.Type{},
+		imports: map[string]bool{"context": true, "net/http": true},
+	}
+	for _, op := range operations {
+		g.method(op)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cmd/clientgen from handlers.Operations. DO NOT EDIT.\n\n")
+	buf.WriteString("package client\n\nimport (\n")
+	for _, path := range sortedKeys(g.imports) {
+		fmt.Fprintf(&buf, "%q\n", path)
+	}
+	if len(g.aliases) > 0 {
+		fmt.Fprintf(&buf, "\n%q\n", modelsPkg)
+	}
+	buf.WriteString(")\n\n")
+
+	if len(g.aliases) > 0 {
+		buf.WriteString("// Types shared with the server's models\ntype (\n")
+		for _, name := range sortedKeys(g.aliases) {
+			fmt.Fprintf(&buf, "%s = models.%s\n", name, name)
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, name := range sortedKeys(g.types) {
+		buf.WriteString(g.types[name])
+	}
+	buf.Write(g.methods.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// method writes the client method of one operation, and its request and params types
+func (g *generator) method(op handlers.Operation) {
+	var args []string
+	pathArgs := map[string]reflect.Type{}
+	query, body := "nil", "nil"
+
+	if op.Request != nil {
+		fields := reflect.VisibleFields(op.Request)
+
+		// Path arguments, in the order they appear in the path
+		for _, segment := range strings.Split(strings.TrimPrefix(op.Path, "/"), "/") {
+			name, ok := strings.CutPrefix(segment, ":")
+			if !ok {
+				continue
+			}
+			for _, field := range fields {
+				if field.Tag.Get("uri") == name && !field.Anonymous {
+					args = append(args, fmt.Sprintf("%s %s", argName(name), g.goType(field.Type)))
+					pathArgs[name] = field.Type
+				}
+			}
+		}
+
+		var params bytes.Buffer
+		var setters bytes.Buffer
+		for _, field := range fields {
+			tag := field.Tag.Get("form")
+			if field.Anonymous || !field.IsExported() || tag == "" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			fieldType := field.Type
+			if fieldType.Kind() != reflect.Pointer {
+				fieldType = reflect.PointerTo(fieldType)
+			}
+			fmt.Fprintf(&params, "%s %s\n", field.Name, g.goType(fieldType))
+			fmt.Fprintf(&setters, "if p.%s != nil {\nq.Set(%q, fmt.Sprint(*p.%s))\n}\n", field.Name, name, field.Name)
+		}
+		if params.Len() > 0 {
+			paramsType := op.ID + "Params"
+			g.types[paramsType] = fmt.Sprintf("// %s holds the optional query parameters of %s; nil fields are left to the server's defaults\n"+
+				"type %s struct {\n%s}\n\n"+
+				"func (p *%s) query() url.Values {\nq := url.Values{}\nif p == nil {\nreturn q\n}\n%sreturn q\n}\n\n",
+				paramsType, op.ID, paramsType, params.String(), paramsType, setters.String())
+			args = append(args, "params *"+paramsType)
+			query = "params.query()"
+			g.imports["fmt"], g.imports["net/url"] = true, true
+		}
+
+		var bodyFields []reflect.StructField
+		for _, field := range handlers.JSONFields(op.Request) {
+			if field.Tag.Get("uri") == "" && field.Tag.Get("form") == "" {
+				bodyFields = append(bodyFields, field)
+			}
+		}
+		if len(bodyFields) > 0 {
+			requestType := op.ID + "Request"
+			g.types[requestType] = fmt.Sprintf("// %s is the body of %s\ntype %s struct {\n%s}\n\n",
+				requestType, op.ID, requestType, g.fields(bodyFields))
+			args = append(args, "body "+requestType)
+			body = "body"
+		}
+	}
+
+	m := &g.methods
+	fmt.Fprintf(m, "// %s calls %s %s: %s\n", op.ID, op.Method, op.Path, op.Summary)
+	signature := strings.Join(append([]string{"ctx context.Context"}, append(args, "opts ...RequestOption")...), ", ")
+	call := fmt.Sprintf("c.do(ctx, %s, %s, %s, %s, %%s, opts)", methodConst(op.Method), g.pathExpr(op.Path, pathArgs), query, body)
+
+	if op.Response == nil {
+		fmt.Fprintf(m, "func (c *Client) %s(%s) error {\nreturn %s\n}\n\n", op.ID, signature, fmt.Sprintf(call, "nil"))
+		return
+	}
+	response := g.goType(op.Response)
+	fmt.Fprintf(m, "func (c *Client) %s(%s) (*%s, error) {\nvar out %s\nif err := %s; err != nil {\nreturn nil, err\n}\nreturn &out, nil\n}\n\n",
+		op.ID, signature, response, response, fmt.Sprintf(call, "&out"))
+}
+
+// fields declares struct fields with their JSON names; optional ones are omitted when unset
+func (g *generator) fields(fields []reflect.StructField) string {
+	var buf bytes.Buffer
+	for _, field := range fields {
+		tag := field.Tag.Get("json")
+		if field.Type.Kind() == reflect.Pointer && !strings.Contains(tag, "omitempty") {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&buf, "%s %s `json:%q`\n", field.Name, g.goType(field.Type), tag)
+	}
+	return buf.String()
+}
+
+// goType names a type as the generated package sees it, declaring it first if needed
+func (g *generator) goType(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		g.imports["time"] = true
+		return "time.Time"
+	case t.Kind() == reflect.Pointer:
+		return "*" + g.goType(t.Elem())
+	case t.Kind() == reflect.Slice:
+		return "[]" + g.goType(t.Elem())
+	case t.Kind() == reflect.Map:
+		return fmt.Sprintf("map[%s]%s", g.goType(t.Key()), g.goType(t.Elem()))
+	case t.Kind() == reflect.Interface:
+		return "interface{}"
+	case t.PkgPath() == modelsPkg:
+		g.aliases[t.Name()] = true
+		return t.Name()
+	case t.Kind() != reflect.Struct:
+		// Named strings and numbers outside models travel as their underlying type
+		return t.Kind().String()
+	}
+
+	name := handlers.SchemaName(t)
+	if known, ok := g.seen[name]; ok {
+		if known != t {
+			log.Fatalf("%s and %s both map to client type %s", known, t, name)
+		}
+		return name
+	}
+	g.seen[name] = t
+	g.types[name] = fmt.Sprintf("// %s mirrors %s\ntype %s struct {\n%s}\n\n", name, t, name, g.fields(handlers.JSONFields(t)))
+	return name
+}
+
+// argName turns a path parameter into a Go argument name, spelling ID the Go way
+func argName(param string) string {
+	if name, ok := strings.CutSuffix(param, "Id"); ok {
+		return name + "ID"
+	}
+	return param
+}
+
+// methodConst names the net/http constant for an HTTP method
+func methodConst(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "http.MethodGet"
+	case http.MethodPost:
+		return "http.MethodPost"
+	case http.MethodPut:
+		return "http.MethodPut"
+	case http.MethodPatch:
+		return "http.MethodPatch"
+	case http.MethodDelete:
+		return "http.MethodDelete"
+	default:
+		return fmt.Sprintf("%q", method)
+	}
+}
+
+// pathExpr builds the expression for a path, escaping the arguments of its :param segments
+func (g *generator) pathExpr(path string, args map[string]reflect.Type) string {
+	var parts []string
+	literal := ""
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			literal += "/" + segment
+			continue
+		}
+		arg := argName(name)
+		if args[name].Kind() != reflect.String {
+			arg = fmt.Sprintf("fmt.Sprint(%s)", arg)
+			g.imports["fmt"] = true
+		}
+		parts = append(parts, fmt.Sprintf("%q", literal+"/"), fmt.Sprintf("url.PathEscape(%s)", arg))
+		literal = ""
+		g.imports["net/url"] = true
+	}
+	if literal != "" {
+		parts = append(parts, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// sortedKeys lists the keys of a map in order, so the output is stable
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
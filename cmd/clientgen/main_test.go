@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/handlers"
+)
+
+// TestClientUpToDate fails when pkg/client no longer matches the operations; run
+// go generate ./pkg/client to fix it
+func TestClientUpToDate(t *testing.T) {
+	want, err := generate(handlers.Operations())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../pkg/client/operations.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("pkg/client/operations.go is stale; run go generate ./pkg/client")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/evaluation"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	"github.com/yishak-cs/Neo4j_DB/pkg/helper"
+)
+
+// dataDir is where the CSV importer reads its files from
+const dataDir = "data"
+
+func main() {
+	k := flag.Int("k", 10, "number of recommendations to score per case")
+	trainRatio := flag.Float64("train-ratio", 0.8, "share of orders (by time) used for training")
+	cutoffParam := flag.String("cutoff", "", "explicit split date (YYYY-MM-DD), overrides -train-ratio")
+	trendDays := flag.Int("trend-days", 7, "window for the time-based trend strategy")
+	format := flag.String("format", "table", "output format: table, json or both")
+	output := flag.String("out", "", "write the JSON report to this file as well")
+	skipImport := flag.Bool("skip-import", false, "reuse a database already loaded with the training split")
+	flag.Parse()
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Error loading .env file: %v\n", err)
+	}
+
+	orders, err := evaluation.LoadOrders(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load orders: %v", err)
+	}
+
+	var cutoff time.Time
+	if *cutoffParam != "" {
+		cutoff, err = time.Parse("2006-01-02", *cutoffParam)
+	} else {
+		cutoff, err = evaluation.CutoffForRatio(orders, *trainRatio)
+	}
+	if err != nil {
+		log.Fatalf("Failed to determine split: %v", err)
+	}
+
+	dataset := evaluation.SplitByTime(orders, cutoff)
+	log.Printf("Split at %s: %d training orders, %d held-out orders", cutoff.Format(time.RFC3339), len(dataset.Train), len(dataset.Test))
+
+	config := helper.LoadConfigFromEnv()
+	neo4jClient, err := database.NewNeo4jClient(config)
+	if err != nil {
+		log.Fatalf("Failed to connect to Neo4j: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := neo4jClient.Close(ctx); err != nil {
+			log.Printf("Error closing Neo4j connection: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	// Rebuild the graph, and with it the derived relationships, from training orders only
+	if !*skipImport {
+		log.Println("Reloading the database with the training split (this wipes existing data)")
+		importer := database.NewCSVImporter(neo4jClient)
+		importer.SetOrderCutoff(cutoff)
+		if err := importer.ImportAllData(ctx, dataDir); err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+	}
+
+	recommendationService := services.NewRecommendationService(neo4jClient)
+	recommendationService.SetClock(func() time.Time { return cutoff })
+
+	items, err := recommendationService.GetAllItems(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load catalogue: %v", err)
+	}
+
+	cases := evaluation.BuildCases(dataset.Test)
+	evaluator := evaluation.NewEvaluator(*k, len(items), dataset.Train)
+
+	report := evaluation.Report{
+		Cutoff:      cutoff,
+		TrainOrders: len(dataset.Train),
+		TestOrders:  len(dataset.Test),
+		Cases:       len(cases),
+		K:           *k,
+		Results:     evaluator.Evaluate(ctx, evaluation.DefaultStrategies(recommendationService, *trendDays), cases),
+	}
+
+	switch *format {
+	case "json":
+		err = evaluation.WriteJSON(os.Stdout, report)
+	case "both":
+		if err = evaluation.WriteTable(os.Stdout, report); err == nil {
+			fmt.Println()
+			err = evaluation.WriteJSON(os.Stdout, report)
+		}
+	default:
+		err = evaluation.WriteTable(os.Stdout, report)
+	}
+	if err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *output, err)
+		}
+		defer file.Close()
+		if err := evaluation.WriteJSON(file, report); err != nil {
+			log.Fatalf("Failed to write %s: %v", *output, err)
+		}
+	}
+}
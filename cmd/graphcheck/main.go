@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/pkg/helper"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "rewrite the relationships that differ from the recomputed ones")
+	batchSize := flag.Int("batch-size", database.DefaultRepairBatchSize, "relationships rewritten per repair transaction")
+	limit := flag.Int("limit", 20, "discrepancies to list (0 lists none, -1 lists all)")
+	jsonOutput := flag.Bool("json", false, "print the full report as JSON")
+	flag.Parse()
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Error loading .env file: %v\n", err)
+	}
+
+	config := helper.LoadConfigFromEnv()
+	neo4jClient, err := database.NewNeo4jClient(config)
+	if err != nil {
+		log.Fatalf("Failed to connect to Neo4j: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := neo4jClient.Close(ctx); err != nil {
+			log.Printf("Error closing Neo4j connection: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	report, err := database.CheckDerivedGraph(ctx, neo4jClient)
+	if err != nil {
+		log.Fatalf("Check failed: %v", err)
+	}
+
+	if *repair && !report.Consistent {
+		if err := database.RepairDerivedGraph(ctx, neo4jClient, &report, *batchSize); err != nil {
+			log.Fatalf("Repair failed: %v", err)
+		}
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report, *limit)
+	}
+
+	// A non-zero exit lets scheduled runs alert on drift that was not repaired
+	if !report.Consistent && !*repair {
+		os.Exit(1)
+	}
+}
+
+// printReport writes a readable summary of the report
+func printReport(report database.GraphReport, limit int) {
+	fmt.Printf("Checked at %s, %d orders not yet applied\n", report.CheckedAt.Format(time.RFC3339), report.PendingOrders)
+	for _, check := range report.Checks {
+		fmt.Printf("%-20s expected %6d  stored %6d  missing %5d  extra %5d  mismatched %5d\n",
+			check.Relationship, check.Expected, check.Stored, check.Missing, check.Extra, check.Mismatched)
+	}
+
+	if report.Consistent {
+		fmt.Println("Derived graph is consistent")
+		return
+	}
+
+	discrepancies := report.Discrepancies
+	if limit >= 0 && len(discrepancies) > limit {
+		discrepancies = discrepancies[:limit]
+	}
+	for _, d := range discrepancies {
+		fmt.Printf("  %-20s %-10s %d -> %d  expected %d, stored %d\n", d.Relationship, d.Kind, d.From, d.To, d.Expected, d.Stored)
+	}
+	if len(discrepancies) < len(report.Discrepancies) {
+		fmt.Printf("  ... and %d more\n", len(report.Discrepancies)-len(discrepancies))
+	}
+
+	if report.Repaired > 0 {
+		fmt.Printf("Repaired %d relationships\n", report.Repaired)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/grpcserver"
 	"github.com/yishak-cs/Neo4j_DB/internal/handlers"
 	"github.com/yishak-cs/Neo4j_DB/internal/services"
 	"github.com/yishak-cs/Neo4j_DB/pkg/helper"
@@ -45,8 +48,65 @@ func main() {
 	// Initialize services
 	recommendationService := services.NewRecommendationService(neo4jClient)
 
+	// Load tuned hybrid weights if a profile exists, otherwise keep the built-in defaults
+	weightsPath := os.Getenv("WEIGHTS_FILE")
+	if weightsPath == "" {
+		weightsPath = "config/weights.json"
+	}
+	if weightsFile, err := services.LoadWeightsFile(weightsPath); err != nil {
+		log.Printf("Using default hybrid weights: %v", err)
+	} else if err := recommendationService.SetSegmentWeights(weightsFile.Segments); err != nil {
+		log.Printf("Ignoring weights file %s: %v", weightsPath, err)
+	} else {
+		log.Printf("Loaded hybrid weights from %s (tuned for %s)", weightsPath, weightsFile.Metric)
+	}
+
+	// "Not interested" marks fade out over SUPPRESSION_DAYS (default 30)
+	if days, err := strconv.Atoi(os.Getenv("SUPPRESSION_DAYS")); err == nil && days > 0 {
+		recommendationService.SetSuppressionPeriod(time.Duration(days) * 24 * time.Hour)
+	}
+
+	// Right-to-be-forgotten requests follow ERASURE_POLICY: anonymise (default), delete or purge
+	if policy := os.Getenv("ERASURE_POLICY"); policy != "" {
+		if err := recommendationService.SetErasurePolicy(policy); err != nil {
+			log.Fatalf("Invalid ERASURE_POLICY: %v", err)
+		}
+	}
+
+	// Apply derived-relationship updates for new orders in the background
+	updateQueue := database.NewUpdateQueue(neo4jClient, database.DefaultQueueConfig())
+	updateQueue.Start()
+	recommendationService.SetOrderUpdater(updateQueue)
+
+	// Count experiment exposures in memory and add them to the graph every few seconds
+	exposureBuffer := database.NewExposureBuffer(neo4jClient, 5*time.Second)
+	exposureBuffer.Start()
+	recommendationService.SetExposureRecorder(exposureBuffer)
+
+	// Choose where feedback events go: the graph (default) or a JSON lines file
+	var eventSink services.EventSink = services.NewGraphEventSink(neo4jClient)
+	if os.Getenv("EVENT_SINK") == "file" {
+		eventLogPath := os.Getenv("EVENT_LOG_FILE")
+		if eventLogPath == "" {
+			eventLogPath = "events.jsonl"
+		}
+		fileSink, err := services.NewFileEventSink(eventLogPath)
+		if err != nil {
+			log.Fatalf("Failed to open event log: %v", err)
+		}
+		defer fileSink.Close()
+		eventSink = fileSink
+		log.Printf("Writing feedback events to %s", eventLogPath)
+	}
+	eventService := services.NewEventService(eventSink)
+	eventService.SetDismissalSuppressor(recommendationService)
+
 	// Initialize API handlers
-	apiHandler := handlers.NewAPIHandler(recommendationService)
+	apiHandler := handlers.NewAPIHandler(recommendationService, eventService)
+	apiHandler.SetAdminToken(os.Getenv("ADMIN_API_TOKEN"))
+	if os.Getenv("ADMIN_API_TOKEN") == "" {
+		log.Println("Warning: ADMIN_API_TOKEN is not set; admin endpoints are disabled")
+	}
 
 	// Setup Gin router
 	router := gin.Default()
@@ -54,8 +114,9 @@ func main() {
 	// Add CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Session-ID, X-Request-ID")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Experiment, X-Request-ID")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -68,26 +129,48 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	if err := importer.ImportAllData(ctx, "https://github.com/yishak-cs/Neo4j_DB/data"); err != nil {
-		log.Printf("Import failed: %v", err)
+	if err := importer.EnsureConstraints(ctx); err != nil {
+		log.Printf("Failed to create constraints: %v", err)
 		os.Exit(1)
 	}
 
-	// Get import status
-	_, err = importer.GetImportStatus(ctx)
+	// Import the CSV data only into an empty database, or when RELOAD_DATA=true asks to replace
+	// what is there: a reload wipes everything created through the API since the last import
+	hasData, err := importer.HasData(ctx)
 	if err != nil {
 		log.Printf("Failed to get import status: %v", err)
 		os.Exit(1)
 	}
+	if !hasData || os.Getenv("RELOAD_DATA") == "true" {
+		if err := importer.ImportAllData(ctx, "https://github.com/yishak-cs/Neo4j_DB/data"); err != nil {
+			log.Printf("Import failed: %v", err)
+			os.Exit(1)
+		}
+	} else {
+		log.Println("Keeping existing data; set RELOAD_DATA=true to wipe it and reimport")
+	}
+
+	// Bring data kept from an earlier version up to date before anything reads it
+	if _, err := importer.UpgradeData(ctx); err != nil {
+		log.Printf("Failed to upgrade existing data: %v", err)
+		os.Exit(1)
+	}
+
+	// Queue orders left without derived relationships when the server last stopped
+	if pending, err := updateQueue.EnqueuePending(ctx); err != nil {
+		log.Printf("Warning: Failed to queue pending order updates: %v", err)
+	} else if pending > 0 {
+		log.Printf("Queued %d pending order updates", pending)
+	}
 
 	// Setup API routes
 	apiHandler.SetupRoutes(router)
 
 	// Handle React Router (SPA) - catch all other routes and serve index.html
-	router.NoRoute(func(c *gin.Context) {
-		// If the request is for API, return 404
+	router.NoRoute(handlers.RequestID(), func(c *gin.Context) {
+		// If the request is for API, return the usual 404 error envelope
 		if strings.HasPrefix(c.Request.URL.Path, "/api/") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "API endpoint not found"})
+			handlers.RouteNotFound(c)
 			return
 		}
 		// Otherwise serve the React app
@@ -114,17 +197,52 @@ func main() {
 		}
 	}()
 
+	// Serve the same recommendations over gRPC on GRPC_PORT (default 9090)
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+	grpcSrv := grpcserver.NewServer(recommendationService)
+	go func() {
+		log.Printf("gRPC server starting on port %s", grpcPort)
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
 
-	// Gracefully shutdown with a timeout
+	// Gracefully shutdown both servers with a shared timeout
 	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	grpcStopped := make(chan error, 1)
+	go func() {
+		grpcStopped <- grpcSrv.Shutdown(ctx)
+	}()
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+	if err := <-grpcStopped; err != nil {
+		log.Printf("gRPC server forced to shutdown: %v", err)
+	}
+
+	// Drain queued order updates before the Neo4j connection closes, with a timeout of its
+	// own so a slow HTTP shutdown does not leave the queue undrained
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer drainCancel()
+	if err := updateQueue.Stop(drainCtx); err != nil {
+		log.Printf("Error stopping update queue: %v", err)
+	}
+	if err := exposureBuffer.Stop(drainCtx); err != nil {
+		log.Printf("Error flushing experiment exposures: %v", err)
 	}
 
 	log.Println("Server exited properly")
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/evaluation"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	"github.com/yishak-cs/Neo4j_DB/pkg/helper"
+)
+
+// dataDir is where the CSV importer reads its files from
+const dataDir = "data"
+
+func main() {
+	method := flag.String("method", evaluation.MethodRandom, "search method: grid, random or coordinate")
+	metric := flag.String("metric", "ndcg", "metric to maximise: precision, recall, hit_rate, ndcg, map, coverage or novelty")
+	k := flag.Int("k", 10, "number of recommendations to score per case")
+	trainRatio := flag.Float64("train-ratio", 0.8, "share of orders (by time) before the test split cmd/evaluate reports on; tuning never sees the rest")
+	validationRatio := flag.Float64("validation-ratio", 0.2, "share of the training orders (the latest) held out to score candidate weights on")
+	budget := flag.Int("budget", 0, "grid steps per weight, random iterations or coordinate rounds (default 5, 200 or 20)")
+	seed := flag.Int64("seed", 1, "random seed for random search")
+	output := flag.String("out", "config/weights.json", "where to write the tuned weights profile")
+	skipImport := flag.Bool("skip-import", false, "reuse a database already loaded with the orders before the validation split")
+	flag.Parse()
+
+	if *budget == 0 {
+		switch *method {
+		case evaluation.MethodGrid:
+			*budget = 5
+		case evaluation.MethodCoordinate:
+			*budget = 20
+		default:
+			*budget = 200
+		}
+	}
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Error loading .env file: %v\n", err)
+	}
+
+	orders, err := evaluation.LoadOrders(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load orders: %v", err)
+	}
+	testCutoff, err := evaluation.CutoffForRatio(orders, *trainRatio)
+	if err != nil {
+		log.Fatalf("Failed to determine split: %v", err)
+	}
+	// Weights are scored on a validation split from the training period, so the
+	// test split stays unseen and cmd/evaluate reports honest numbers for them
+	dataset, err := evaluation.ValidationSplit(orders, testCutoff, *validationRatio)
+	if err != nil {
+		log.Fatalf("Failed to determine split: %v", err)
+	}
+	cutoff := dataset.Cutoff
+	log.Printf("Split at %s: %d training orders, %d validation orders; %d test orders from %s left out",
+		cutoff.Format(time.RFC3339), len(dataset.Train), len(dataset.Test), len(orders)-len(dataset.Train)-len(dataset.Test), testCutoff.Format(time.RFC3339))
+
+	config := helper.LoadConfigFromEnv()
+	neo4jClient, err := database.NewNeo4jClient(config)
+	if err != nil {
+		log.Fatalf("Failed to connect to Neo4j: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := neo4jClient.Close(ctx); err != nil {
+			log.Printf("Error closing Neo4j connection: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+	defer cancel()
+
+	if !*skipImport {
+		log.Println("Reloading the database with the orders before the validation split (this wipes existing data)")
+		importer := database.NewCSVImporter(neo4jClient)
+		importer.SetOrderCutoff(cutoff)
+		if err := importer.ImportAllData(ctx, dataDir); err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+	}
+
+	recommendationService := services.NewRecommendationService(neo4jClient)
+	recommendationService.SetClock(func() time.Time { return cutoff })
+
+	items, err := recommendationService.GetAllItems(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load catalogue: %v", err)
+	}
+
+	evaluator := evaluation.NewEvaluator(*k, len(items), dataset.Train)
+	tuner, err := evaluation.NewTuner(evaluator, recommendationService, *metric, *seed)
+	if err != nil {
+		log.Fatalf("Failed to create tuner: %v", err)
+	}
+
+	cases := evaluation.BuildCases(dataset.Test)
+	newUserCases, experiencedCases, err := evaluation.SplitCasesBySegment(ctx, recommendationService, cases)
+	if err != nil {
+		log.Fatalf("Failed to segment users: %v", err)
+	}
+
+	weightsFile := services.WeightsFile{
+		Segments:    recommendationService.GetSegmentWeights(),
+		Metric:      *metric,
+		Method:      *method,
+		K:           *k,
+		Scores:      make(map[string]float64),
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	newUserResult, err := tuner.Tune(ctx, "new_user", *method, newUserCases, weightsFile.Segments.NewUser, *budget)
+	if err != nil {
+		log.Fatalf("Tuning new users failed: %v", err)
+	}
+	experiencedResult, err := tuner.Tune(ctx, "experienced_user", *method, experiencedCases, weightsFile.Segments.ExperiencedUser, *budget)
+	if err != nil {
+		log.Fatalf("Tuning experienced users failed: %v", err)
+	}
+	defaultResult, err := tuner.Tune(ctx, "default", *method, cases, weightsFile.Segments.Default, *budget)
+	if err != nil {
+		log.Fatalf("Tuning default weights failed: %v", err)
+	}
+
+	for _, result := range []evaluation.TuningResult{newUserResult, experiencedResult, defaultResult} {
+		log.Printf("%s: %s=%.4f over %d cases after %d evaluations, weights %+v",
+			result.Segment, *metric, result.Score, result.Cases, result.Evaluations, result.Weights)
+		weightsFile.Scores[result.Segment] = result.Score
+	}
+
+	weightsFile.Segments.NewUser = newUserResult.Weights
+	weightsFile.Segments.ExperiencedUser = experiencedResult.Weights
+	weightsFile.Segments.Default = defaultResult.Weights
+
+	if err := services.SaveWeightsFile(*output, weightsFile); err != nil {
+		log.Fatalf("Failed to save weights: %v", err)
+	}
+	log.Printf("Wrote tuned weights to %s; run cmd/evaluate with -train-ratio %v for their scores on the test split", *output, *trainRatio)
+}
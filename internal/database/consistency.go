@@ -0,0 +1,294 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// Derived relationship types the checker verifies
+const (
+	RelHasOrdered       = "HAS_ORDERED"
+	RelOrderedAlongWith = "ORDERED_ALONG_WITH"
+)
+
+// Kinds of discrepancy between recomputed and stored derived relationships
+const (
+	DiscrepancyMissing    = "missing"
+	DiscrepancyExtra      = "extra"
+	DiscrepancyMismatched = "mismatched"
+)
+
+// DefaultRepairBatchSize is how many relationships one repair transaction rewrites
+const DefaultRepairBatchSize = 500
+
+// Discrepancy is one derived relationship whose stored count differs from the recomputed one
+type Discrepancy struct {
+	Relationship string `json:"relationship"`
+	Kind         string `json:"kind"`
+	From         int    `json:"from"`
+	To           int    `json:"to"`
+	Expected     int64  `json:"expected"`
+	Stored       int64  `json:"stored"`
+}
+
+// RelationshipCheck summarises the comparison for one relationship type
+type RelationshipCheck struct {
+	Relationship string `json:"relationship"`
+	Expected     int    `json:"expected"`
+	Stored       int    `json:"stored"`
+	Missing      int    `json:"missing"`
+	Extra        int    `json:"extra"`
+	Mismatched   int    `json:"mismatched"`
+}
+
+// GraphReport is the result of checking (and possibly repairing) the derived graph.
+// PrunedSuppressions counts the expired "not interested" marks the server's repair deleted.
+type GraphReport struct {
+	CheckedAt          time.Time           `json:"checked_at"`
+	Consistent         bool                `json:"consistent"`
+	PendingOrders      int                 `json:"pending_orders"`
+	Checks             []RelationshipCheck `json:"checks"`
+	Discrepancies      []Discrepancy       `json:"discrepancies"`
+	Repaired           int                 `json:"repaired"`
+	PrunedSuppressions int                 `json:"pruned_suppressions"`
+}
+
+// CheckDerivedGraph recomputes HAS_ORDERED and ORDERED_ALONG_WITH from HAS_MADE/HAS_ITEM
+// and diffs them against what is stored. Only orders already counted (derived_at set) are
+// recomputed, so orders still waiting in the update queue are not reported as drift;
+// they are counted in PendingOrders instead.
+func CheckDerivedGraph(ctx context.Context, client *Neo4jClient) (GraphReport, error) {
+	report := GraphReport{CheckedAt: time.Now().UTC()}
+
+	pending, err := client.ExecuteRead(ctx, `
+		MATCH (o:Order)
+		WHERE o.derived_at IS NULL
+		RETURN count(o) AS pending
+	`, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to count pending orders: %w", err)
+	}
+	if len(pending) > 0 {
+		report.PendingOrders, err = Value[int](pending[0], "pending")
+		if err != nil {
+			return report, fmt.Errorf("failed to decode pending orders: %w", err)
+		}
+	}
+
+	hasOrdered, err := checkRelationship(ctx, client, RelHasOrdered, `
+		MATCH (u:User)-[:HAS_MADE]->(o:Order)-[hi:HAS_ITEM]->(i:Item)
+		WHERE o.derived_at IS NOT NULL
+		RETURN u.db_id AS from, i.db_id AS to, sum(hi.quantity) AS times
+	`, `
+		MATCH (u:User)-[ho:HAS_ORDERED]->(i:Item)
+		RETURN u.db_id AS from, i.db_id AS to, ho.times AS times
+	`)
+	if err != nil {
+		return report, err
+	}
+
+	// Co-occurrence is stored in both directions, so the expected count is emitted for both
+	alongWith, err := checkRelationship(ctx, client, RelOrderedAlongWith, `
+		MATCH (o:Order)-[:HAS_ITEM]->(i1:Item)
+		MATCH (o)-[:HAS_ITEM]->(i2:Item)
+		WHERE o.derived_at IS NOT NULL AND i1.db_id <> i2.db_id
+		RETURN i1.db_id AS from, i2.db_id AS to, count(DISTINCT o) AS times
+	`, `
+		MATCH (i1:Item)-[oaw:ORDERED_ALONG_WITH]->(i2:Item)
+		RETURN i1.db_id AS from, i2.db_id AS to, oaw.times AS times
+	`)
+	if err != nil {
+		return report, err
+	}
+
+	for _, result := range []relationshipDiff{hasOrdered, alongWith} {
+		report.Checks = append(report.Checks, result.check)
+		report.Discrepancies = append(report.Discrepancies, result.discrepancies...)
+	}
+	report.Consistent = len(report.Discrepancies) == 0
+
+	return report, nil
+}
+
+// RepairDerivedGraph rewrites every relationship in the report's discrepancies, batchSize at
+// a time, each batch in its own short transaction so reads and new orders carry on meanwhile.
+// Counts are recomputed inside each write rather than taken from the report, so orders
+// counted since the check are not lost.
+func RepairDerivedGraph(ctx context.Context, client *Neo4jClient, report *GraphReport, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = DefaultRepairBatchSize
+	}
+
+	userItems, pairs := repairRows(report.Discrepancies)
+
+	repairHasOrdered := `
+		UNWIND $rows AS row
+		MATCH (u:User {db_id: row.from})
+		MATCH (i:Item {db_id: row.to})
+		OPTIONAL MATCH (u)-[:HAS_MADE]->(o:Order)-[hi:HAS_ITEM]->(i)
+		WHERE o.derived_at IS NOT NULL
+		WITH u, i, coalesce(sum(hi.quantity), 0) AS expected
+		MERGE (u)-[ho:HAS_ORDERED]->(i)
+		SET ho.times = expected
+		WITH ho
+		WHERE ho.times <= 0
+		DELETE ho
+	`
+
+	repairOrderedAlongWith := `
+		UNWIND $rows AS row
+		MATCH (i1:Item {db_id: row.from})
+		MATCH (i2:Item {db_id: row.to})
+		OPTIONAL MATCH (i1)<-[:HAS_ITEM]-(o:Order)-[:HAS_ITEM]->(i2)
+		WHERE o.derived_at IS NOT NULL
+		WITH i1, i2, count(DISTINCT o) AS expected
+		MERGE (i1)-[oaw1:ORDERED_ALONG_WITH]->(i2)
+		SET oaw1.times = expected
+		MERGE (i2)-[oaw2:ORDERED_ALONG_WITH]->(i1)
+		SET oaw2.times = expected
+		WITH oaw1, oaw2, expected
+		WHERE expected <= 0
+		DELETE oaw1, oaw2
+	`
+
+	for _, job := range []struct {
+		relationship string
+		query        string
+		rows         []map[string]interface{}
+	}{
+		{RelHasOrdered, repairHasOrdered, userItems},
+		{RelOrderedAlongWith, repairOrderedAlongWith, pairs},
+	} {
+		for start := 0; start < len(job.rows); start += batchSize {
+			end := start + batchSize
+			if end > len(job.rows) {
+				end = len(job.rows)
+			}
+
+			params := map[string]interface{}{
+				"rows": job.rows[start:end],
+			}
+			if err := client.ExecuteWrite(ctx, job.query, params); err != nil {
+				return fmt.Errorf("failed to repair %s batch at %d: %w", job.relationship, start, err)
+			}
+			report.Repaired += end - start
+			log.Printf("Repaired %s relationships %d-%d of %d", job.relationship, start+1, end, len(job.rows))
+		}
+	}
+
+	return nil
+}
+
+// repairRows lists the user-item and item-pair relationships to rewrite. Both directions
+// of a co-occurrence are rewritten together, so each pair appears once, smaller ID first.
+func repairRows(discrepancies []Discrepancy) ([]map[string]interface{}, []map[string]interface{}) {
+	var userItems []map[string]interface{}
+	itemPairs := make(map[[2]int]bool)
+	for _, d := range discrepancies {
+		switch d.Relationship {
+		case RelHasOrdered:
+			userItems = append(userItems, map[string]interface{}{"from": d.From, "to": d.To})
+		case RelOrderedAlongWith:
+			a, b := d.From, d.To
+			if a > b {
+				a, b = b, a
+			}
+			itemPairs[[2]int{a, b}] = true
+		}
+	}
+
+	var pairs []map[string]interface{}
+	for pair := range itemPairs {
+		pairs = append(pairs, map[string]interface{}{"from": pair[0], "to": pair[1]})
+	}
+	return userItems, pairs
+}
+
+// relationshipDiff is the comparison of one relationship type
+type relationshipDiff struct {
+	check         RelationshipCheck
+	discrepancies []Discrepancy
+}
+
+// checkRelationship runs the recompute and stored queries, which both return from, to and times
+func checkRelationship(ctx context.Context, client *Neo4jClient, relationship, expectedQuery, storedQuery string) (relationshipDiff, error) {
+	expected, err := readCounts(ctx, client, expectedQuery)
+	if err != nil {
+		return relationshipDiff{}, fmt.Errorf("failed to recompute %s: %w", relationship, err)
+	}
+	stored, err := readCounts(ctx, client, storedQuery)
+	if err != nil {
+		return relationshipDiff{}, fmt.Errorf("failed to read stored %s: %w", relationship, err)
+	}
+
+	return diffCounts(relationship, expected, stored), nil
+}
+
+// diffCounts compares recomputed and stored counts, listing discrepancies by from and to
+func diffCounts(relationship string, expected, stored map[[2]int]int64) relationshipDiff {
+	diff := relationshipDiff{
+		check: RelationshipCheck{
+			Relationship: relationship,
+			Expected:     len(expected),
+			Stored:       len(stored),
+		},
+	}
+
+	for key, want := range expected {
+		have, ok := stored[key]
+		switch {
+		case !ok:
+			diff.check.Missing++
+			diff.discrepancies = append(diff.discrepancies, Discrepancy{relationship, DiscrepancyMissing, key[0], key[1], want, 0})
+		case have != want:
+			diff.check.Mismatched++
+			diff.discrepancies = append(diff.discrepancies, Discrepancy{relationship, DiscrepancyMismatched, key[0], key[1], want, have})
+		}
+	}
+	for key, have := range stored {
+		if _, ok := expected[key]; !ok {
+			diff.check.Extra++
+			diff.discrepancies = append(diff.discrepancies, Discrepancy{relationship, DiscrepancyExtra, key[0], key[1], 0, have})
+		}
+	}
+
+	sort.Slice(diff.discrepancies, func(i, j int) bool {
+		a, b := diff.discrepancies[i], diff.discrepancies[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	return diff
+}
+
+// countRow is one relationship count; a null times counts as zero
+type countRow struct {
+	From  int   `db:"from"`
+	To    int   `db:"to"`
+	Times int64 `db:"times,optional"`
+}
+
+// readCounts runs a query returning from, to and times rows and indexes them by (from, to)
+func readCounts(ctx context.Context, client *Neo4jClient, query string) (map[[2]int]int64, error) {
+	results, err := client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := DecodeAll[countRow](results)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[[2]int]int64, len(rows))
+	for _, row := range rows {
+		counts[[2]int{row.From, row.To}] += row.Times
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffCounts(t *testing.T) {
+	expected := map[[2]int]int64{{1, 10}: 3, {1, 11}: 1, {2, 10}: 2}
+	stored := map[[2]int]int64{{1, 10}: 3, {2, 10}: 5, {3, 12}: 1}
+
+	diff := diffCounts(RelHasOrdered, expected, stored)
+
+	wantCheck := RelationshipCheck{Relationship: RelHasOrdered, Expected: 3, Stored: 3, Missing: 1, Extra: 1, Mismatched: 1}
+	if diff.check != wantCheck {
+		t.Errorf("got check %+v, want %+v", diff.check, wantCheck)
+	}
+
+	want := []Discrepancy{
+		{RelHasOrdered, DiscrepancyMissing, 1, 11, 1, 0},
+		{RelHasOrdered, DiscrepancyMismatched, 2, 10, 2, 5},
+		{RelHasOrdered, DiscrepancyExtra, 3, 12, 0, 1},
+	}
+	if !slices.Equal(diff.discrepancies, want) {
+		t.Errorf("got discrepancies %v, want %v", diff.discrepancies, want)
+	}
+}
+
+func TestDiffCountsConsistent(t *testing.T) {
+	counts := map[[2]int]int64{{1, 2}: 4, {2, 1}: 4}
+
+	diff := diffCounts(RelOrderedAlongWith, counts, counts)
+	if len(diff.discrepancies) != 0 || diff.check.Missing+diff.check.Extra+diff.check.Mismatched != 0 {
+		t.Errorf("got %+v for identical counts", diff)
+	}
+}
+
+func TestRepairRows(t *testing.T) {
+	userItems, pairs := repairRows([]Discrepancy{
+		{Relationship: RelHasOrdered, Kind: DiscrepancyMissing, From: 1, To: 10},
+		{Relationship: RelOrderedAlongWith, Kind: DiscrepancyMismatched, From: 7, To: 3},
+		{Relationship: RelOrderedAlongWith, Kind: DiscrepancyMismatched, From: 3, To: 7},
+		{Relationship: RelOrderedAlongWith, Kind: DiscrepancyExtra, From: 4, To: 9},
+		{Relationship: RelHasOrdered, Kind: DiscrepancyExtra, From: 2, To: 11},
+	})
+
+	keys := func(rows []map[string]interface{}) [][2]int {
+		var keys [][2]int
+		for _, row := range rows {
+			keys = append(keys, [2]int{row["from"].(int), row["to"].(int)})
+		}
+		slices.SortFunc(keys, func(a, b [2]int) int {
+			if a[0] != b[0] {
+				return a[0] - b[0]
+			}
+			return a[1] - b[1]
+		})
+		return keys
+	}
+
+	if got, want := keys(userItems), [][2]int{{1, 10}, {2, 11}}; !slices.Equal(got, want) {
+		t.Errorf("got user items %v, want %v", got, want)
+	}
+	// Both directions of 3-7 are one pair, rewritten once
+	if got, want := keys(pairs), [][2]int{{3, 7}, {4, 9}}; !slices.Equal(got, want) {
+		t.Errorf("got pairs %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"math"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// daysPerMonth is how long a month of a Neo4j Duration counts for, since time.Duration has no months
+const daysPerMonth = 30
+
+// AsInt64 converts any Go or Neo4j integer, or a whole float, into an int64
+func AsInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case float64:
+		// float64(math.MaxInt64) rounds up to 2^63, which no longer fits, so the bound is exclusive
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+// AsFloat64 converts a float or any integer into a float64
+func AsFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+	if i, ok := AsInt64(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+// AsTime converts a Neo4j temporal value into a time.Time.
+// DateTime keeps its zone; Date and LocalDateTime carry no zone, so their wall-clock
+// fields are read as UTC, which is how this application writes them.
+func AsTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case dbtype.LocalDateTime:
+		return wallClockUTC(time.Time(v)), true
+	case dbtype.Date:
+		t := time.Time(v)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// AsDuration converts a Neo4j Duration into a time.Duration, counting months as 30 days
+// and saturating instead of overflowing
+func AsDuration(value interface{}) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case dbtype.Duration:
+		days := float64(v.Months)*daysPerMonth + float64(v.Days)
+		nanos := days*float64(24*time.Hour) + float64(v.Seconds)*float64(time.Second) + float64(v.Nanos)
+		switch {
+		case nanos >= math.MaxInt64:
+			return time.Duration(math.MaxInt64), true
+		case nanos <= math.MinInt64:
+			return time.Duration(math.MinInt64), true
+		}
+		return time.Duration(nanos), true
+	}
+	return 0, false
+}
+
+// wallClockUTC keeps the date and clock reading of t but places it in UTC
+func wallClockUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// addExposuresQuery adds buffered exposure counts to their variants in one transaction
+const addExposuresQuery = `
+	UNWIND $exposures AS exposure
+	MATCH (:Experiment {name: exposure.experiment})-[:HAS_VARIANT]->(v:Variant {name: exposure.variant})
+	SET v.exposures = coalesce(v.exposures, 0) + exposure.count
+`
+
+// ExposureKey names one experiment variant
+type ExposureKey struct {
+	Experiment string
+	Variant    string
+}
+
+// AddExposures adds exposure counts to experiment variants
+func AddExposures(ctx context.Context, client *Neo4jClient, counts map[ExposureKey]int) error {
+	exposures := make([]map[string]interface{}, 0, len(counts))
+	for key, count := range counts {
+		exposures = append(exposures, map[string]interface{}{
+			"experiment": key.Experiment,
+			"variant":    key.Variant,
+			"count":      count,
+		})
+	}
+
+	params := map[string]interface{}{
+		"exposures": exposures,
+	}
+
+	if err := client.ExecuteWrite(ctx, addExposuresQuery, params); err != nil {
+		return fmt.Errorf("failed to record exposures: %w", err)
+	}
+	return nil
+}
+
+// ExposureBuffer counts experiment exposures in memory and adds them to the graph
+// periodically, so serving a variant costs no write of its own and a busy variant
+// node is written once per flush rather than once per response. Counts that fail to
+// flush are kept for the next attempt.
+type ExposureBuffer struct {
+	write    func(ctx context.Context, counts map[ExposureKey]int) error
+	interval time.Duration
+
+	mu     sync.Mutex
+	counts map[ExposureKey]int
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewExposureBuffer creates a buffer that flushes every interval; call Start to run it
+func NewExposureBuffer(client *Neo4jClient, interval time.Duration) *ExposureBuffer {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &ExposureBuffer{
+		write: func(ctx context.Context, counts map[ExposureKey]int) error {
+			return AddExposures(ctx, client, counts)
+		},
+		interval: interval,
+		counts:   make(map[ExposureKey]int),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start launches the flushing goroutine
+func (b *ExposureBuffer) Start() {
+	go b.run()
+}
+
+// Record counts one response served by a variant
+func (b *ExposureBuffer) Record(experiment, variant string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.counts[ExposureKey{Experiment: experiment, Variant: variant}]++
+}
+
+// Stop stops the periodic flush and writes what is still buffered
+func (b *ExposureBuffer) Stop(ctx context.Context) error {
+	b.once.Do(func() { close(b.stop) })
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return fmt.Errorf("exposure buffer did not stop: %w", ctx.Err())
+	}
+	return b.flush(ctx)
+}
+
+// run flushes every interval until stopped
+func (b *ExposureBuffer) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := b.flush(ctx); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+			cancel()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// flush writes the buffered counts, putting them back if the write fails
+func (b *ExposureBuffer) flush(ctx context.Context) error {
+	b.mu.Lock()
+	counts := b.counts
+	b.counts = make(map[ExposureKey]int)
+	b.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+	if err := b.write(ctx, counts); err != nil {
+		b.mu.Lock()
+		for key, count := range counts {
+			b.counts[key] += count
+		}
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingWrites collects what an ExposureBuffer writes, failing while fail is set
+type recordingWrites struct {
+	mu     sync.Mutex
+	fail   bool
+	writes []map[ExposureKey]int
+}
+
+func (r *recordingWrites) write(_ context.Context, counts map[ExposureKey]int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("neo4j unavailable")
+	}
+	r.writes = append(r.writes, maps.Clone(counts))
+	return nil
+}
+
+func newTestExposureBuffer(writes *recordingWrites) *ExposureBuffer {
+	buffer := NewExposureBuffer(nil, time.Hour)
+	buffer.write = writes.write
+	return buffer
+}
+
+func TestExposureBufferCoalescesCounts(t *testing.T) {
+	writes := &recordingWrites{}
+	buffer := newTestExposureBuffer(writes)
+	buffer.Start()
+
+	for i := 0; i < 3; i++ {
+		buffer.Record("ratings-boost", "control")
+	}
+	buffer.Record("ratings-boost", "treatment")
+
+	if err := buffer.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[ExposureKey]int{
+		{Experiment: "ratings-boost", Variant: "control"}:   3,
+		{Experiment: "ratings-boost", Variant: "treatment"}: 1,
+	}
+	if len(writes.writes) != 1 || !maps.Equal(writes.writes[0], want) {
+		t.Errorf("got writes %v, want one write of %v", writes.writes, want)
+	}
+}
+
+func TestExposureBufferKeepsCountsOfFailedFlush(t *testing.T) {
+	writes := &recordingWrites{fail: true}
+	buffer := newTestExposureBuffer(writes)
+	key := ExposureKey{Experiment: "ratings-boost", Variant: "control"}
+
+	buffer.Record(key.Experiment, key.Variant)
+	if err := buffer.flush(context.Background()); err == nil {
+		t.Fatal("expected the flush to fail")
+	}
+
+	buffer.Record(key.Experiment, key.Variant)
+	writes.fail = false
+	if err := buffer.flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(writes.writes) != 1 || writes.writes[0][key] != 2 {
+		t.Errorf("got writes %v, want one write counting both exposures", writes.writes)
+	}
+}
+
+func TestExposureBufferSkipsEmptyFlush(t *testing.T) {
+	writes := &recordingWrites{}
+	buffer := newTestExposureBuffer(writes)
+	buffer.Start()
+
+	if err := buffer.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(writes.writes) != 0 {
+		t.Errorf("got writes %v, want none", writes.writes)
+	}
+}
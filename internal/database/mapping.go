@@ -0,0 +1,255 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDecode is wrapped by every error Decode returns
+var ErrDecode = errors.New("cannot decode result")
+
+// FieldError describes a column that could not be stored in its struct field
+type FieldError struct {
+	Column string // result column named by the db tag
+	Field  string // Go field name
+	Want   string // Go type of the field
+	Got    string // Go type of the value, "null" or "missing"
+}
+
+// Error implements error
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("column %q (field %s): want %s, got %s", e.Column, e.Field, e.Want, e.Got)
+}
+
+// Unwrap lets errors.Is match ErrDecode
+func (e *FieldError) Unwrap() error {
+	return ErrDecode
+}
+
+// Decode maps one result row into a struct of type T using `db:"column"` field tags.
+//
+// Integers widen into floats and whole floats narrow into integers; Neo4j temporal
+// values decode into time.Time and time.Duration; lists decode into slices and maps
+// into nested structs. A missing or null column is an error unless the field is a
+// pointer or its tag says `db:"column,optional"`, in which case it is left zero.
+// Every bad column is reported, not just the first. Untagged fields are ignored and
+// embedded structs are flattened.
+func Decode[T any](row map[string]interface{}) (T, error) {
+	var out T
+	value := reflect.ValueOf(&out).Elem()
+	if value.Kind() != reflect.Struct {
+		return out, fmt.Errorf("%w: %s is not a struct", ErrDecode, value.Type())
+	}
+	return out, decodeStruct(value, row)
+}
+
+// DecodeAll maps every row into a T, stopping at the first row that fails
+func DecodeAll[T any](rows []map[string]interface{}) ([]T, error) {
+	out := make([]T, 0, len(rows))
+	for i, row := range rows {
+		decoded, err := Decode[T](row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		out = append(out, decoded)
+	}
+	return out, nil
+}
+
+// Value decodes one column of a row with the same conversions and errors as Decode;
+// a null column is only allowed when T is a pointer
+func Value[T any](row map[string]interface{}, column string) (T, error) {
+	var out T
+	target := reflect.ValueOf(&out).Elem()
+
+	value, present := row[column]
+	if value == nil {
+		if target.Kind() == reflect.Pointer {
+			return out, nil
+		}
+		got := "null"
+		if !present {
+			got = "missing"
+		}
+		return out, &FieldError{Column: column, Field: "-", Want: target.Type().String(), Got: got}
+	}
+
+	if err := assign(target, value); err != nil {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) && fieldErr.Column == "" {
+			fieldErr.Column, fieldErr.Field = column, "-"
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+// fieldPlan is how one tagged field is filled
+type fieldPlan struct {
+	index    []int
+	name     string
+	column   string
+	optional bool
+}
+
+// plans caches the field plans of each struct type
+var plans sync.Map // map[reflect.Type][]fieldPlan
+
+// planFor returns the tagged fields of a struct type, including promoted ones
+func planFor(t reflect.Type) []fieldPlan {
+	if cached, ok := plans.Load(t); ok {
+		return cached.([]fieldPlan)
+	}
+
+	var fields []fieldPlan
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := field.Tag.Lookup("db")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		column, options, _ := strings.Cut(tag, ",")
+		fields = append(fields, fieldPlan{
+			index:    field.Index,
+			name:     field.Name,
+			column:   column,
+			optional: options == "optional",
+		})
+	}
+
+	plans.Store(t, fields)
+	return fields
+}
+
+// decodeStruct fills the tagged fields of a struct value from a row
+func decodeStruct(out reflect.Value, row map[string]interface{}) error {
+	var errs []error
+	for _, plan := range planFor(out.Type()) {
+		field := out.FieldByIndex(plan.index)
+		value, present := row[plan.column]
+
+		if value == nil {
+			if plan.optional || field.Kind() == reflect.Pointer {
+				continue
+			}
+			got := "null"
+			if !present {
+				got = "missing"
+			}
+			errs = append(errs, &FieldError{Column: plan.column, Field: plan.name, Want: field.Type().String(), Got: got})
+			continue
+		}
+
+		if err := assign(field, value); err != nil {
+			var fieldErr *FieldError
+			if errors.As(err, &fieldErr) && fieldErr.Column == "" {
+				fieldErr.Column, fieldErr.Field = plan.column, plan.name
+			} else {
+				// The error came from a nested struct; say which column it is in
+				err = fmt.Errorf("in column %q: %w", plan.column, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// assign stores a non-null value in dst, converting where it is safe to
+func assign(dst reflect.Value, value interface{}) error {
+	mismatch := func() error {
+		return &FieldError{Want: dst.Type().String(), Got: fmt.Sprintf("%T", value)}
+	}
+
+	switch dst.Type() {
+	case timeType:
+		t, ok := AsTime(value)
+		if !ok {
+			return mismatch()
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, ok := AsDuration(value)
+		if !ok {
+			return mismatch()
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := assign(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := AsInt64(value)
+		if !ok || dst.OverflowInt(i) {
+			return mismatch()
+		}
+		dst.SetInt(i)
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := AsFloat64(value)
+		if !ok {
+			return mismatch()
+		}
+		dst.SetFloat(f)
+
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return mismatch()
+		}
+		dst.SetString(s)
+
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return mismatch()
+		}
+		dst.SetBool(b)
+
+	case reflect.Slice:
+		list, ok := value.([]interface{})
+		if !ok {
+			return mismatch()
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(list), len(list))
+		for i, item := range list {
+			if item == nil {
+				continue
+			}
+			if err := assign(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+
+	case reflect.Struct:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		return decodeStruct(dst, m)
+
+	default:
+		if !reflect.TypeOf(value).AssignableTo(dst.Type()) {
+			return mismatch()
+		}
+		dst.Set(reflect.ValueOf(value))
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package database
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+type scalarRow struct {
+	ID    int     `db:"id"`
+	Small int8    `db:"small,optional"`
+	Price float64 `db:"price,optional"`
+	Name  string  `db:"name,optional"`
+	OK    bool    `db:"ok,optional"`
+}
+
+type optionalRow struct {
+	ID       int       `db:"id"`
+	Note     *string   `db:"note"`
+	Quantity int       `db:"quantity,optional"`
+	At       time.Time `db:"at,optional"`
+}
+
+type lineRow struct {
+	ItemID   int `db:"item_id"`
+	Quantity int `db:"quantity"`
+}
+
+type nestedRow struct {
+	OrderID int       `db:"order_id"`
+	Lines   []lineRow `db:"lines"`
+	Tags    []string  `db:"tags,optional"`
+	Best    *lineRow  `db:"best"`
+}
+
+type temporalRow struct {
+	At     time.Time     `db:"at"`
+	Until  *time.Time    `db:"until"`
+	Window time.Duration `db:"window,optional"`
+}
+
+type embeddedRow struct {
+	scalarRow
+	Extra   string `db:"extra"`
+	Ignored string
+}
+
+func TestDecodeScalars(t *testing.T) {
+	tests := []struct {
+		name string
+		row  map[string]interface{}
+		want scalarRow
+		bad  []string // columns the error must name; none means success
+	}{
+		{"exact types", map[string]interface{}{"id": int64(1), "price": 2.5, "name": "Tea", "ok": true}, scalarRow{ID: 1, Price: 2.5, Name: "Tea", OK: true}, nil},
+		{"int into float", map[string]interface{}{"id": int64(1), "price": int64(3)}, scalarRow{ID: 1, Price: 3}, nil},
+		{"whole float into int", map[string]interface{}{"id": 4.0}, scalarRow{ID: 4}, nil},
+		{"fractional float into int", map[string]interface{}{"id": 4.5}, scalarRow{}, []string{"id"}},
+		{"overflowing int", map[string]interface{}{"id": int64(1), "small": int64(300)}, scalarRow{}, []string{"small"}},
+		{"float beyond int64", map[string]interface{}{"id": math.Pow(2, 63)}, scalarRow{}, []string{"id"}},
+		{"string into int", map[string]interface{}{"id": "1"}, scalarRow{}, []string{"id"}},
+		{"int into string", map[string]interface{}{"id": int64(1), "name": int64(7)}, scalarRow{}, []string{"name"}},
+		{"string into bool", map[string]interface{}{"id": int64(1), "ok": "true"}, scalarRow{}, []string{"ok"}},
+		{"bool into float", map[string]interface{}{"id": int64(1), "price": true}, scalarRow{}, []string{"price"}},
+		{"missing column", map[string]interface{}{"price": 1.0}, scalarRow{}, []string{"id"}},
+		{"null column", map[string]interface{}{"id": nil}, scalarRow{}, []string{"id"}},
+		{"every bad column", map[string]interface{}{"name": false, "ok": int64(1)}, scalarRow{}, []string{"id", "name", "ok"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode[scalarRow](tt.row)
+			checkDecodeError(t, err, tt.bad)
+			if len(tt.bad) == 0 && got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeNullsAndOptionals(t *testing.T) {
+	note := "no onions"
+	tests := []struct {
+		name string
+		row  map[string]interface{}
+		want optionalRow
+		bad  []string
+	}{
+		{"null pointer", map[string]interface{}{"id": int64(1), "note": nil}, optionalRow{ID: 1}, nil},
+		{"missing pointer", map[string]interface{}{"id": int64(1)}, optionalRow{ID: 1}, nil},
+		{"set pointer", map[string]interface{}{"id": int64(1), "note": note}, optionalRow{ID: 1, Note: &note}, nil},
+		{"null optional", map[string]interface{}{"id": int64(1), "quantity": nil, "at": nil}, optionalRow{ID: 1}, nil},
+		{"mismatched optional", map[string]interface{}{"id": int64(1), "quantity": "two"}, optionalRow{}, []string{"quantity"}},
+		{"null required", map[string]interface{}{"id": nil, "note": note}, optionalRow{}, []string{"id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode[optionalRow](tt.row)
+			checkDecodeError(t, err, tt.bad)
+			if len(tt.bad) == 0 && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeListsAndMaps(t *testing.T) {
+	line := func(itemID, quantity int64) map[string]interface{} {
+		return map[string]interface{}{"item_id": itemID, "quantity": quantity}
+	}
+
+	tests := []struct {
+		name string
+		row  map[string]interface{}
+		want nestedRow
+		bad  []string
+	}{
+		{
+			"slices of maps",
+			map[string]interface{}{
+				"order_id": int64(9),
+				"lines":    []interface{}{line(1, 2), line(3, 1)},
+				"tags":     []interface{}{"spicy", "vegan"},
+				"best":     line(1, 2),
+			},
+			nestedRow{OrderID: 9, Lines: []lineRow{{1, 2}, {3, 1}}, Tags: []string{"spicy", "vegan"}, Best: &lineRow{1, 2}},
+			nil,
+		},
+		{
+			"empty list",
+			map[string]interface{}{"order_id": int64(9), "lines": []interface{}{}},
+			nestedRow{OrderID: 9, Lines: []lineRow{}},
+			nil,
+		},
+		{
+			"null list element",
+			map[string]interface{}{"order_id": int64(9), "lines": []interface{}{nil, line(3, 1)}},
+			nestedRow{OrderID: 9, Lines: []lineRow{{}, {3, 1}}},
+			nil,
+		},
+		{
+			"bad list element",
+			map[string]interface{}{"order_id": int64(9), "lines": []interface{}{line(1, 2)}, "tags": []interface{}{"spicy", int64(1)}},
+			nestedRow{},
+			[]string{"tags"},
+		},
+		{
+			"bad nested column",
+			map[string]interface{}{"order_id": int64(9), "lines": []interface{}{map[string]interface{}{"item_id": int64(1)}}},
+			nestedRow{},
+			[]string{"lines", "quantity"},
+		},
+		{
+			"scalar instead of list",
+			map[string]interface{}{"order_id": int64(9), "lines": int64(1)},
+			nestedRow{},
+			[]string{"lines"},
+		},
+		{
+			"list instead of map",
+			map[string]interface{}{"order_id": int64(9), "lines": []interface{}{}, "best": []interface{}{}},
+			nestedRow{},
+			[]string{"best"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode[nestedRow](tt.row)
+			checkDecodeError(t, err, tt.bad)
+			if len(tt.bad) == 0 && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTemporal(t *testing.T) {
+	nairobi := time.FixedZone("EAT", 3*60*60)
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, nairobi)
+	until := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		row  map[string]interface{}
+		want temporalRow
+		bad  []string
+	}{
+		{
+			"datetime keeps its zone",
+			map[string]interface{}{"at": at},
+			temporalRow{At: at},
+			nil,
+		},
+		{
+			"local datetime and date read as UTC",
+			map[string]interface{}{
+				"at":    dbtype.LocalDateTime(time.Date(2024, 3, 1, 12, 30, 0, 0, nairobi)),
+				"until": dbtype.Date(time.Date(2024, 3, 2, 0, 0, 0, 0, nairobi)),
+			},
+			temporalRow{At: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), Until: &until},
+			nil,
+		},
+		{
+			"duration counts months as 30 days",
+			map[string]interface{}{"at": at, "window": dbtype.Duration{Months: 1, Days: 1, Seconds: 60}},
+			temporalRow{At: at, Window: 31*24*time.Hour + time.Minute},
+			nil,
+		},
+		{
+			"duration saturates",
+			map[string]interface{}{"at": at, "window": dbtype.Duration{Months: math.MaxInt32}},
+			temporalRow{At: at, Window: time.Duration(math.MaxInt64)},
+			nil,
+		},
+		{
+			"string is not a time",
+			map[string]interface{}{"at": "2024-03-01T12:30:00Z"},
+			temporalRow{},
+			[]string{"at"},
+		},
+		{
+			"time is not a duration",
+			map[string]interface{}{"at": at, "window": at},
+			temporalRow{},
+			[]string{"window"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode[temporalRow](tt.row)
+			checkDecodeError(t, err, tt.bad)
+			if len(tt.bad) > 0 {
+				return
+			}
+			if !got.At.Equal(tt.want.At) || got.At.Location().String() != tt.want.At.Location().String() {
+				t.Errorf("at = %v, want %v", got.At, tt.want.At)
+			}
+			if (got.Until == nil) != (tt.want.Until == nil) || (got.Until != nil && !got.Until.Equal(*tt.want.Until)) {
+				t.Errorf("until = %v, want %v", got.Until, tt.want.Until)
+			}
+			if got.Window != tt.want.Window {
+				t.Errorf("window = %v, want %v", got.Window, tt.want.Window)
+			}
+		})
+	}
+}
+
+func TestDecodeEmbeddedStructs(t *testing.T) {
+	got, err := Decode[embeddedRow](map[string]interface{}{"id": int64(1), "name": "Tea", "extra": "x", "Ignored": "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := embeddedRow{scalarRow: scalarRow{ID: 1, Name: "Tea"}, Extra: "x"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeRejectsNonStructs(t *testing.T) {
+	if _, err := Decode[int](map[string]interface{}{}); !errors.Is(err, ErrDecode) {
+		t.Errorf("got %v, want ErrDecode", err)
+	}
+}
+
+func TestDecodeAllNamesTheRow(t *testing.T) {
+	_, err := DecodeAll[scalarRow]([]map[string]interface{}{{"id": int64(1)}, {"id": "two"}})
+	if !errors.Is(err, ErrDecode) || !strings.HasPrefix(err.Error(), "row 1:") {
+		t.Errorf("got %v, want an ErrDecode for row 1", err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	row := map[string]interface{}{"count": int64(3), "ratio": int64(1), "none": nil}
+
+	if got, err := Value[int](row, "count"); err != nil || got != 3 {
+		t.Errorf("count = %v, %v", got, err)
+	}
+	if got, err := Value[float64](row, "ratio"); err != nil || got != 1 {
+		t.Errorf("ratio = %v, %v", got, err)
+	}
+	if got, err := Value[*int](row, "none"); err != nil || got != nil {
+		t.Errorf("none = %v, %v", got, err)
+	}
+
+	var fieldErr *FieldError
+	if _, err := Value[int](row, "absent"); !errors.As(err, &fieldErr) || fieldErr.Got != "missing" || fieldErr.Column != "absent" {
+		t.Errorf("absent: got %v, want a missing-column error", err)
+	}
+	if _, err := Value[int](row, "none"); !errors.As(err, &fieldErr) || fieldErr.Got != "null" {
+		t.Errorf("none: got %v, want a null-column error", err)
+	}
+	if _, err := Value[string](row, "count"); !errors.As(err, &fieldErr) || fieldErr.Column != "count" {
+		t.Errorf("count as string: got %v, want a FieldError naming the column", err)
+	}
+}
+
+// checkDecodeError fails unless err is nil when no columns are bad, or an ErrDecode
+// naming every bad column otherwise
+func checkDecodeError(t *testing.T, err error, bad []string) {
+	t.Helper()
+	if len(bad) == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, ErrDecode) {
+		t.Fatalf("got %v, want ErrDecode", err)
+	}
+	for _, column := range bad {
+		if !strings.Contains(err.Error(), `"`+column+`"`) {
+			t.Errorf("error %q does not name column %q", err, column)
+		}
+	}
+}
@@ -3,15 +3,21 @@ package database
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 )
 
+// ErasedUserName replaces the name of anonymised users
+const ErasedUserName = "Erased user"
+
 // CSVImporter handles importing CSV data into Neo4j
 type CSVImporter struct {
-	client *Neo4jClient
+	client      *Neo4jClient
+	orderCutoff time.Time
 }
 
 // NewCSVImporter creates a new CSV importer
@@ -19,6 +25,13 @@ func NewCSVImporter(client *Neo4jClient) *CSVImporter {
 	return &CSVImporter{client: client}
 }
 
+// SetOrderCutoff limits ImportOrders to orders created before cutoff.
+// Order items of skipped orders are dropped too, since their order never exists,
+// and so are ratings given on or after the cutoff.
+func (i *CSVImporter) SetOrderCutoff(cutoff time.Time) {
+	i.orderCutoff = cutoff
+}
+
 // ImportAllData imports all CSV files in the correct order
 func (i *CSVImporter) ImportAllData(ctx context.Context, baseURL string) error {
 	log.Println("Starting CSV import process...")
@@ -37,6 +50,8 @@ func (i *CSVImporter) ImportAllData(ctx context.Context, baseURL string) error {
 		{"items", i.ImportItems},
 		{"orders", i.ImportOrders},
 		{"order_items", i.ImportOrderItems},
+		{"ratings", i.ImportRatings},
+		{"erasures", i.ApplyErasures},
 		{"build_relationships", i.BuildRelationships},
 	}
 
@@ -92,7 +107,7 @@ func (i *CSVImporter) ImportUsers(ctx context.Context, baseURL string) error {
 	return i.client.ExecuteWrite(ctx, query, params)
 }
 
-// ImportItems imports menu items from CSV
+// ImportItems imports menu items and their categories from CSV
 func (i *CSVImporter) ImportItems(ctx context.Context, baseURL string) error {
 	filePath := "data/items.csv"
 	records, err := readCsvFile(filePath)
@@ -103,15 +118,18 @@ func (i *CSVImporter) ImportItems(ctx context.Context, baseURL string) error {
 	header := records[0]
 	dataRows := records[1:]
 
+	// Categories are nodes of their own; items point at theirs through IN_CATEGORY
 	query := `
 		UNWIND $rows as row
 		MERGE (i:Item {
 			db_id: toInteger(row.item_id),
 			name: row.name,
 			price: toFloat(row.price),
-			category: row.category,
-			description: row.description
+			description: row.description,
+			available: true
 		})
+		MERGE (c:Category {name: row.category})
+		MERGE (i)-[:IN_CATEGORY]->(c)
 		RETURN count(i) as imported_items
 	`
 
@@ -160,6 +178,15 @@ func (i *CSVImporter) ImportOrders(ctx context.Context, baseURL string) error {
 		for j, value := range record {
 			order[header[j]] = strings.TrimSpace(value)
 		}
+
+		// Skip held-out orders when a cutoff is set
+		if !i.orderCutoff.IsZero() {
+			createdAt, err := time.Parse(time.RFC3339, order["created_at"].(string))
+			if err == nil && !createdAt.Before(i.orderCutoff) {
+				continue
+			}
+		}
+
 		orderList = append(orderList, order)
 	}
 
@@ -207,6 +234,102 @@ func (i *CSVImporter) ImportOrderItems(ctx context.Context, baseURL string) erro
 	return i.client.ExecuteWrite(ctx, query, params)
 }
 
+// ImportRatings imports star ratings and reviews from CSV; the file is optional.
+// Rows outside the 1-5 star range the API accepts (services.MinStars to MaxStars) are skipped.
+func (i *CSVImporter) ImportRatings(ctx context.Context, baseURL string) error {
+	filePath := "data/ratings.csv"
+	if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
+		log.Printf("No %s found, skipping ratings", filePath)
+		return nil
+	}
+
+	records, err := readCsvFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	header := records[0]
+	dataRows := records[1:]
+
+	query := `
+		UNWIND $rows as row
+		WITH row WHERE toInteger(row.stars) >= 1 AND toInteger(row.stars) <= 5
+		MATCH (u:User {db_id: toInteger(row.user_id)})
+		MATCH (i:Item {db_id: toInteger(row.item_id)})
+		MERGE (u)-[r:RATED]->(i)
+		SET r.stars = toInteger(row.stars),
+			r.comment = row.comment,
+			r.at = datetime(row.rated_at)
+		RETURN count(r) as imported_ratings
+	`
+
+	var ratingList []map[string]interface{}
+	for _, record := range dataRows {
+		rating := make(map[string]interface{})
+		for j, value := range record {
+			rating[header[j]] = strings.TrimSpace(value)
+		}
+
+		// Ratings given after the cutoff belong to the held-out period too
+		if !i.orderCutoff.IsZero() {
+			ratedAt, err := time.Parse(time.RFC3339, rating["rated_at"].(string))
+			if err == nil && !ratedAt.Before(i.orderCutoff) {
+				continue
+			}
+		}
+
+		ratingList = append(ratingList, rating)
+	}
+
+	params := map[string]interface{}{
+		"rows": ratingList,
+	}
+
+	return i.client.ExecuteWrite(ctx, query, params)
+}
+
+// ApplyErasures erases imported users again who were erased before the import, so a
+// reimport never brings a forgotten user back. Erasing a user leaves an ErasedUser tombstone
+// recording the policy used; the policies match services.EraseUser, and since derived
+// relationships are built afterwards, nothing has to be subtracted from them.
+func (i *CSVImporter) ApplyErasures(ctx context.Context, baseURL string) error {
+	queries := []struct {
+		name  string
+		query string
+	}{
+		{"purged orders", `
+			MATCH (t:ErasedUser {policy: "purge"})
+			MATCH (:User {db_id: t.db_id})-[:HAS_MADE]->(o)
+			DETACH DELETE o
+		`},
+		{"deleted users", `
+			MATCH (t:ErasedUser)
+			WHERE t.policy IN ["delete", "purge"]
+			MATCH (u:User {db_id: t.db_id})
+			DETACH DELETE u
+		`},
+		{"anonymised users", `
+			MATCH (t:ErasedUser {policy: "anonymise"})
+			MATCH (u:User {db_id: t.db_id})
+			SET u.name = $erasedName, u.email = "", u.erased_at = t.erased_at
+			WITH u
+			OPTIONAL MATCH (u)-[r:RATED]->(:Item)
+			REMOVE r.comment
+		`},
+	}
+
+	params := map[string]interface{}{
+		"erasedName": ErasedUserName,
+	}
+
+	for _, q := range queries {
+		if err := i.client.ExecuteWrite(ctx, q.query, params); err != nil {
+			return fmt.Errorf("failed to apply erasures to %s: %w", q.name, err)
+		}
+	}
+	return nil
+}
+
 // BuildRelationships builds the derived relationships for recommendations
 func (i *CSVImporter) BuildRelationships(ctx context.Context, baseURL string) error {
 	log.Println("Building HAS_ORDERED relationships...")
@@ -219,6 +342,16 @@ func (i *CSVImporter) BuildRelationships(ctx context.Context, baseURL string) er
 		return fmt.Errorf("failed to build ORDERED_ALONG_WITH relationships: %w", err)
 	}
 
+	// Mark every order as counted so replaying one through the update queue is a no-op
+	markQuery := `
+		MATCH (o:Order)
+		SET o.derived_at = datetime()
+		RETURN count(o) as marked_orders
+	`
+	if err := i.client.ExecuteWrite(ctx, markQuery, nil); err != nil {
+		return fmt.Errorf("failed to mark orders as applied: %w", err)
+	}
+
 	return nil
 }
 
@@ -266,10 +399,15 @@ func (i *CSVImporter) buildOrderedAlongWithRelationships(ctx context.Context) er
 	return nil
 }
 
-// clearDatabase removes all existing data (for development/testing)
+// clearDatabase removes all imported data (for development/testing).
+// Weight profiles, segment assignments and experiments are configuration, not imported data, so they survive,
+// and so do erasure tombstones, which ApplyErasures needs, the record of applied data upgrades, and the ID
+// sequences, so IDs handed out before the reload are never handed out again.
 func (i *CSVImporter) clearDatabase(ctx context.Context) error {
 	query := `
 		MATCH (n)
+		WHERE NOT n:WeightProfile AND NOT n:SegmentAssignment AND NOT n:Experiment AND NOT n:Variant AND NOT n:ErasedUser
+			AND NOT n:SchemaUpgrade AND NOT n:Sequence
 		DETACH DELETE n
 		RETURN count(n) as deleted_nodes
 	`
@@ -278,44 +416,51 @@ func (i *CSVImporter) clearDatabase(ctx context.Context) error {
 	return i.client.ExecuteWrite(ctx, query, nil)
 }
 
-// UpdateRelationshipsForNewOrder updates relationships when a new order is placed
+// UpdateRelationshipsForNewOrder updates relationships when a new order is placed.
+// It applies the update synchronously; the server goes through UpdateQueue instead.
+// Orders already applied are skipped, so calling it twice never double-counts.
 func (i *CSVImporter) UpdateRelationshipsForNewOrder(ctx context.Context, orderID int) error {
-	// Update HAS_ORDERED relationships for the new order
-	updateHasOrderedQuery := `
-		MATCH (o:Order {db_id: $orderID})-[hi:HAS_ITEM]->(i:Item)
-		MATCH (o)<-[:HAS_MADE]-(u:User)
-		WITH u, i, hi.quantity as quantity
-		MERGE (u)-[ho:HAS_ORDERED]->(i)
-		SET ho.times = COALESCE(ho.times, 0) + quantity
-		RETURN count(ho) as updated_relationships
-	`
-
-	params := map[string]interface{}{
-		"orderID": orderID,
+	if _, err := ApplyOrderUpdates(ctx, i.client, []int{orderID}); err != nil {
+		return fmt.Errorf("failed to update relationships for order %d: %w", orderID, err)
 	}
+	return nil
+}
 
-	if err := i.client.ExecuteWrite(ctx, updateHasOrderedQuery, params); err != nil {
-		return fmt.Errorf("failed to update HAS_ORDERED relationships: %w", err)
+// schemaConstraints are created at startup. Constraints are schema, not data, so
+// clearDatabase leaves them in place.
+var schemaConstraints = []string{
+	// Saving a profile reads the latest version and writes the next one; two concurrent
+	// saves would otherwise both write the same version
+	`CREATE CONSTRAINT weight_profile_version IF NOT EXISTS
+	 FOR (p:WeightProfile) REQUIRE (p.name, p.version) IS UNIQUE`,
+}
+
+// EnsureConstraints creates the uniqueness constraints the services rely on; existing ones are kept
+func (i *CSVImporter) EnsureConstraints(ctx context.Context) error {
+	for _, query := range schemaConstraints {
+		if err := i.client.ExecuteWrite(ctx, query, nil); err != nil {
+			return fmt.Errorf("failed to create constraint: %w", err)
+		}
 	}
+	return nil
+}
 
-	// Update ORDERED_ALONG_WITH relationships for the new order
-	updateCoOccurrenceQuery := `
-		MATCH (o:Order {db_id: $orderID})-[:HAS_ITEM]->(i1:Item)
-		MATCH (o)-[:HAS_ITEM]->(i2:Item)
-		WHERE i1.db_id < i2.db_id
-		WITH i1, i2
-		MERGE (i1)-[oaw1:ORDERED_ALONG_WITH]->(i2)
-		SET oaw1.times = COALESCE(oaw1.times, 0) + 1
-		MERGE (i2)-[oaw2:ORDERED_ALONG_WITH]->(i1)
-		SET oaw2.times = COALESCE(oaw2.times, 0) + 1
-		RETURN count(oaw1) as updated_relationships
+// HasData reports whether the database holds any users, items or orders
+func (i *CSVImporter) HasData(ctx context.Context) (bool, error) {
+	query := `
+		MATCH (n)
+		WHERE n:User OR n:Item OR n:Order OR n:CancelledOrder
+		RETURN count(n) > 0 AS has_data
 	`
 
-	if err := i.client.ExecuteWrite(ctx, updateCoOccurrenceQuery, params); err != nil {
-		return fmt.Errorf("failed to update ORDERED_ALONG_WITH relationships: %w", err)
+	results, err := i.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for existing data: %w", err)
 	}
-
-	return nil
+	if len(results) == 0 {
+		return false, nil
+	}
+	return Value[bool](results[0], "has_data")
 }
 
 // GetImportStatus returns the current state of the database
@@ -344,13 +489,13 @@ func (i *CSVImporter) GetImportStatus(ctx context.Context) (map[string]int, erro
 		}, nil
 	}
 
-	result := results[0]
-	status := map[string]int{
-		"users":              int(result["users"].(int64)),
-		"items":              int(result["items"].(int64)),
-		"orders":             int(result["orders"].(int64)),
-		"has_ordered":        int(result["has_ordered"].(int64)),
-		"ordered_along_with": int(result["ordered_along_with"].(int64)),
+	status := make(map[string]int, len(results[0]))
+	for _, column := range []string{"users", "items", "orders", "has_ordered", "ordered_along_with"} {
+		count, err := Value[int](results[0], column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode database status: %w", err)
+		}
+		status[column] = count
 	}
 
 	return status, nil
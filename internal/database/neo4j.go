@@ -212,3 +212,27 @@ func (c *Neo4jClient) ExecuteWriteTransactionSimple(ctx context.Context, work fu
 	_, err := c.ExecuteWriteTransaction(ctx, wrappedWork)
 	return err
 }
+
+// RunInTransaction runs a query inside a managed transaction and collects its records
+func RunInTransaction(ctx context.Context, tx neo4j.ManagedTransaction, query string, params map[string]interface{}) ([]map[string]interface{}, error) {
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect results: %w", err)
+	}
+
+	var results []map[string]interface{}
+	for _, record := range records {
+		recordMap := make(map[string]interface{})
+		for i, key := range record.Keys {
+			recordMap[key] = record.Values[i]
+		}
+		results = append(results, recordMap)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,462 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrQueueClosed is returned when enqueueing after the update queue has stopped
+var ErrQueueClosed = errors.New("update queue is closed")
+
+// applyOrdersQuery applies the derived-relationship updates of a batch of orders in one transaction.
+// Orders are claimed by setting derived_at, so an order that was already applied is skipped and
+// replaying it never double-counts. Increments are summed per user-item and item-item pair first,
+// so a hot pair is written once per batch rather than once per order.
+const applyOrdersQuery = `
+	UNWIND $orderIds AS orderId
+	MATCH (o:Order {db_id: orderId})
+	WHERE o.derived_at IS NULL
+	SET o.derived_at = datetime()
+	WITH collect(o) AS orders
+	CALL {
+		WITH orders
+		UNWIND orders AS o
+		MATCH (u:User)-[:HAS_MADE]->(o)-[hi:HAS_ITEM]->(i:Item)
+		WITH u, i, sum(hi.quantity) AS quantity
+		MERGE (u)-[ho:HAS_ORDERED]->(i)
+		SET ho.times = coalesce(ho.times, 0) + quantity
+		RETURN count(ho) AS has_ordered
+	}
+	CALL {
+		WITH orders
+		UNWIND orders AS o
+		MATCH (o)-[:HAS_ITEM]->(i1:Item)
+		MATCH (o)-[:HAS_ITEM]->(i2:Item)
+		WHERE i1.db_id < i2.db_id
+		WITH i1, i2, count(DISTINCT o) AS together
+		MERGE (i1)-[oaw1:ORDERED_ALONG_WITH]->(i2)
+		SET oaw1.times = coalesce(oaw1.times, 0) + together
+		MERGE (i2)-[oaw2:ORDERED_ALONG_WITH]->(i1)
+		SET oaw2.times = coalesce(oaw2.times, 0) + together
+		RETURN count(oaw1) AS ordered_along_with
+	}
+	RETURN size(orders) AS applied
+`
+
+// ApplyOrderUpdates increments HAS_ORDERED and ORDERED_ALONG_WITH for the given orders
+// and returns how many of them had not been applied before
+func ApplyOrderUpdates(ctx context.Context, client *Neo4jClient, orderIDs []int) (int, error) {
+	params := map[string]interface{}{
+		"orderIds": orderIDs,
+	}
+
+	results, err := client.ExecuteWriteWithResult(ctx, applyOrdersQuery, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply order updates: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	applied, err := Value[int](results[0], "applied")
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode order updates: %w", err)
+	}
+	return applied, nil
+}
+
+// pendingOrdersQuery finds orders whose derived relationships were never applied,
+// e.g. because the server stopped before its queue drained
+const pendingOrdersQuery = `
+	MATCH (o:Order)
+	WHERE o.derived_at IS NULL
+	RETURN o.db_id AS order_id
+	ORDER BY order_id
+`
+
+// QueueConfig controls the update queue's concurrency and batching
+type QueueConfig struct {
+	Workers        int           // worker goroutines; each owns a shard of order IDs
+	Capacity       int           // buffered events per worker before Enqueue blocks
+	BatchSize      int           // most orders applied in one transaction
+	FlushInterval  time.Duration // longest a partial batch waits for more events
+	MaxAttempts    int           // tries per batch before its orders are dead-lettered
+	RetryBackoff   time.Duration // wait before the first retry; doubles every attempt
+	RedeliverEvery time.Duration // how often dead-lettered orders are queued again
+}
+
+// DefaultQueueConfig returns the queue settings used by the server
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		Workers:        2,
+		Capacity:       1000,
+		BatchSize:      50,
+		FlushInterval:  200 * time.Millisecond,
+		MaxAttempts:    5,
+		RetryBackoff:   100 * time.Millisecond,
+		RedeliverEvery: time.Minute,
+	}
+}
+
+// QueueStats describes the update queue's backlog and throughput
+type QueueStats struct {
+	Depth          int64   `json:"depth"`
+	Enqueued       int64   `json:"enqueued"`
+	Applied        int64   `json:"applied"`
+	Coalesced      int64   `json:"coalesced"`
+	Duplicates     int64   `json:"duplicates"`
+	Failed         int64   `json:"failed"`
+	DeadLettered   int64   `json:"dead_lettered"`
+	Retries        int64   `json:"retries"`
+	Batches        int64   `json:"batches"`
+	LastLagSeconds float64 `json:"last_lag_seconds"`
+	MaxLagSeconds  float64 `json:"max_lag_seconds"`
+}
+
+// orderEvent is one order waiting for its derived relationships
+type orderEvent struct {
+	orderID    int
+	enqueuedAt time.Time
+}
+
+// UpdateQueue applies derived-relationship updates for new orders in the background.
+// Events for the same order always go to the same worker, so one order is never applied
+// by two transactions at once; each worker coalesces what it receives into batches.
+// Delivery is at least once: failed batches are retried, orders of a batch that keeps
+// failing are dead-lettered and queued again later, and the derived_at claim makes
+// retries and replays safe.
+type UpdateQueue struct {
+	apply  func(ctx context.Context, orderIDs []int) (int, error)
+	client *Neo4jClient
+	config QueueConfig
+	shards []chan orderEvent
+	wg     sync.WaitGroup
+	stop   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	deadMu sync.Mutex
+	dead   map[int]time.Time
+
+	enqueued   atomic.Int64
+	processed  atomic.Int64
+	coalesced  atomic.Int64
+	applied    atomic.Int64
+	duplicates atomic.Int64
+	failed     atomic.Int64
+	retries    atomic.Int64
+	batches    atomic.Int64
+	lastLag    atomic.Int64
+	maxLag     atomic.Int64
+}
+
+// NewUpdateQueue creates an update queue; call Start to run its workers
+func NewUpdateQueue(client *Neo4jClient, config QueueConfig) *UpdateQueue {
+	defaults := DefaultQueueConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.Capacity <= 0 {
+		config.Capacity = defaults.Capacity
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.RedeliverEvery <= 0 {
+		config.RedeliverEvery = defaults.RedeliverEvery
+	}
+
+	shards := make([]chan orderEvent, config.Workers)
+	for i := range shards {
+		shards[i] = make(chan orderEvent, config.Capacity)
+	}
+
+	return &UpdateQueue{
+		apply: func(ctx context.Context, orderIDs []int) (int, error) {
+			return ApplyOrderUpdates(ctx, client, orderIDs)
+		},
+		client: client,
+		config: config,
+		shards: shards,
+		stop:   make(chan struct{}),
+		dead:   make(map[int]time.Time),
+	}
+}
+
+// Start launches the worker goroutines and the redelivery of dead-lettered orders
+func (q *UpdateQueue) Start() {
+	for _, shard := range q.shards {
+		q.wg.Add(1)
+		go q.work(shard)
+	}
+	q.wg.Add(1)
+	go q.redeliverLoop()
+	log.Printf("Update queue started with %d workers", len(q.shards))
+}
+
+// EnqueuePending queues every order whose derived relationships were never applied and
+// returns how many it queued. Run it at startup: the queue lives in memory, so orders
+// queued when the server last stopped are only remembered by their missing derived_at.
+func (q *UpdateQueue) EnqueuePending(ctx context.Context) (int, error) {
+	results, err := q.client.ExecuteRead(ctx, pendingOrdersQuery, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find pending order updates: %w", err)
+	}
+
+	queued := 0
+	for _, row := range results {
+		orderID, err := Value[int](row, "order_id")
+		if err != nil {
+			return queued, fmt.Errorf("failed to decode pending order: %w", err)
+		}
+		if err := q.Enqueue(ctx, orderID); err != nil {
+			return queued, err
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// Enqueue schedules the derived-relationship update of an order, blocking while its shard is full
+func (q *UpdateQueue) Enqueue(ctx context.Context, orderID int) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	event := orderEvent{orderID: orderID, enqueuedAt: time.Now()}
+	shard := q.shards[uint(orderID)%uint(len(q.shards))]
+
+	// Count before sending so the worker can never process an event that was not counted yet
+	q.enqueued.Add(1)
+	select {
+	case shard <- event:
+		return nil
+	case <-ctx.Done():
+		q.enqueued.Add(-1)
+		return ctx.Err()
+	}
+}
+
+// Stop stops accepting events and waits for the workers to drain what is queued.
+// Orders still dead-lettered keep no derived_at, so EnqueuePending picks them up on the next start.
+func (q *UpdateQueue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.stop)
+		for _, shard := range q.shards {
+			close(shard)
+		}
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		if dead := q.deadLettered(); dead > 0 {
+			log.Printf("Update queue drained; %d dead-lettered orders are left for the next start", dead)
+			return nil
+		}
+		log.Println("Update queue drained")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("update queue did not drain: %w", ctx.Err())
+	}
+}
+
+// Stats returns the queue's current depth, counters and lag.
+// Depth counts events enqueued but not yet applied or given up on, including coalesced duplicates.
+func (q *UpdateQueue) Stats() QueueStats {
+	enqueued := q.enqueued.Load()
+	return QueueStats{
+		Depth:          enqueued - q.processed.Load(),
+		Enqueued:       enqueued,
+		Coalesced:      q.coalesced.Load(),
+		Applied:        q.applied.Load(),
+		Duplicates:     q.duplicates.Load(),
+		Failed:         q.failed.Load(),
+		DeadLettered:   int64(q.deadLettered()),
+		Retries:        q.retries.Load(),
+		Batches:        q.batches.Load(),
+		LastLagSeconds: time.Duration(q.lastLag.Load()).Seconds(),
+		MaxLagSeconds:  time.Duration(q.maxLag.Load()).Seconds(),
+	}
+}
+
+// work collects events from one shard into batches until the shard is closed
+func (q *UpdateQueue) work(shard chan orderEvent) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make(map[int]time.Time)
+	events := 0
+	for {
+		select {
+		case event, ok := <-shard:
+			if !ok {
+				q.flush(batch, events)
+				return
+			}
+			events++
+			// Coalesce: an order queued twice is applied once, timed from its first event
+			if _, seen := batch[event.orderID]; seen {
+				q.coalesced.Add(1)
+			} else {
+				batch[event.orderID] = event.enqueuedAt
+			}
+			if len(batch) >= q.config.BatchSize {
+				q.flush(batch, events)
+				batch = make(map[int]time.Time)
+				events = 0
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				q.flush(batch, events)
+				batch = make(map[int]time.Time)
+				events = 0
+			}
+		}
+	}
+}
+
+// flush applies one batch of orders built from `events` queued events, retrying with exponential backoff
+func (q *UpdateQueue) flush(batch map[int]time.Time, events int) {
+	if len(batch) == 0 {
+		return
+	}
+	defer q.processed.Add(int64(events))
+
+	orderIDs := make([]int, 0, len(batch))
+	oldest := time.Now()
+	for orderID, enqueuedAt := range batch {
+		orderIDs = append(orderIDs, orderID)
+		if enqueuedAt.Before(oldest) {
+			oldest = enqueuedAt
+		}
+	}
+
+	backoff := q.config.RetryBackoff
+	var err error
+	for attempt := 1; attempt <= q.config.MaxAttempts; attempt++ {
+		var applied int
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		applied, err = q.apply(ctx, orderIDs)
+		cancel()
+
+		if err == nil {
+			q.batches.Add(1)
+			q.applied.Add(int64(applied))
+			q.duplicates.Add(int64(len(orderIDs) - applied))
+			q.recordLag(time.Since(oldest))
+			break
+		}
+
+		if attempt < q.config.MaxAttempts {
+			q.retries.Add(1)
+			log.Printf("Warning: Failed to apply %d order updates (attempt %d): %v", len(orderIDs), attempt, err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	if err != nil {
+		q.failed.Add(int64(len(orderIDs)))
+		log.Printf("Error applying order updates %v after %d attempts, dead-lettering them: %v", orderIDs, q.config.MaxAttempts, err)
+		q.deadMu.Lock()
+		for orderID, enqueuedAt := range batch {
+			if _, seen := q.dead[orderID]; !seen {
+				q.dead[orderID] = enqueuedAt
+			}
+		}
+		q.deadMu.Unlock()
+	}
+}
+
+// redeliverLoop queues dead-lettered orders again every RedeliverEvery until the queue stops
+func (q *UpdateQueue) redeliverLoop() {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.config.RedeliverEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			q.redeliver()
+		}
+	}
+}
+
+// redeliver moves dead-lettered orders back onto their shards; orders that cannot be
+// queued, because the queue is stopping, stay dead-lettered
+func (q *UpdateQueue) redeliver() {
+	q.deadMu.Lock()
+	dead := q.dead
+	q.dead = make(map[int]time.Time)
+	q.deadMu.Unlock()
+	if len(dead) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-q.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	log.Printf("Redelivering %d dead-lettered order updates", len(dead))
+	for orderID, enqueuedAt := range dead {
+		if err := q.Enqueue(ctx, orderID); err != nil {
+			q.deadMu.Lock()
+			q.dead[orderID] = enqueuedAt
+			q.deadMu.Unlock()
+		}
+	}
+}
+
+// deadLettered returns how many orders are waiting to be redelivered
+func (q *UpdateQueue) deadLettered() int {
+	q.deadMu.Lock()
+	defer q.deadMu.Unlock()
+	return len(q.dead)
+}
+
+// recordLag keeps the latest and the largest enqueue-to-apply delay
+func (q *UpdateQueue) recordLag(lag time.Duration) {
+	q.lastLag.Store(int64(lag))
+	for {
+		current := q.maxLag.Load()
+		if int64(lag) <= current || q.maxLag.CompareAndSwap(current, int64(lag)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingApplies collects the batches an UpdateQueue applies, failing while failures > 0
+type recordingApplies struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]int
+}
+
+func (r *recordingApplies) apply(_ context.Context, orderIDs []int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		return 0, errors.New("neo4j unavailable")
+	}
+	batch := slices.Clone(orderIDs)
+	slices.Sort(batch)
+	r.batches = append(r.batches, batch)
+	return len(orderIDs), nil
+}
+
+func (r *recordingApplies) applied() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.batches)
+}
+
+func newTestUpdateQueue(applies *recordingApplies, config QueueConfig) *UpdateQueue {
+	queue := NewUpdateQueue(nil, config)
+	queue.apply = applies.apply
+	return queue
+}
+
+func TestUpdateQueueBatchesAndCoalesces(t *testing.T) {
+	applies := &recordingApplies{}
+	queue := newTestUpdateQueue(applies, QueueConfig{Workers: 1, BatchSize: 3, FlushInterval: time.Hour})
+	queue.Start()
+
+	ctx := context.Background()
+	for _, orderID := range []int{1, 2, 1, 3, 4, 5} {
+		if err := queue.Enqueue(ctx, orderID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := queue.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The repeated order 1 is coalesced, so the first batch fills at three distinct orders
+	want := [][]int{{1, 2, 3}, {4, 5}}
+	got := applies.applied()
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got batches %v, want %v", got, want)
+	}
+
+	stats := queue.Stats()
+	if stats.Enqueued != 6 || stats.Coalesced != 1 || stats.Applied != 5 || stats.Batches != 2 || stats.Depth != 0 {
+		t.Errorf("got stats %+v", stats)
+	}
+}
+
+func TestUpdateQueueFlushesPartialBatches(t *testing.T) {
+	applies := &recordingApplies{}
+	queue := newTestUpdateQueue(applies, QueueConfig{Workers: 1, BatchSize: 50, FlushInterval: 10 * time.Millisecond})
+	queue.Start()
+	defer queue.Stop(context.Background())
+
+	if err := queue.Enqueue(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(applies.applied()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("partial batch was not flushed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestUpdateQueueShardsByOrder(t *testing.T) {
+	applies := &recordingApplies{}
+	queue := newTestUpdateQueue(applies, QueueConfig{Workers: 2, BatchSize: 10, FlushInterval: time.Hour})
+	queue.Start()
+
+	ctx := context.Background()
+	for _, orderID := range []int{1, 2, 3, 4} {
+		if err := queue.Enqueue(ctx, orderID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := queue.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each worker owns the orders of its shard, so no batch mixes odd and even IDs
+	for _, batch := range applies.applied() {
+		for _, orderID := range batch {
+			if orderID%2 != batch[0]%2 {
+				t.Errorf("batch %v mixes shards", batch)
+			}
+		}
+	}
+}
+
+func TestUpdateQueueRetriesFailedBatches(t *testing.T) {
+	applies := &recordingApplies{failures: 2}
+	queue := newTestUpdateQueue(applies, QueueConfig{Workers: 1, BatchSize: 10, FlushInterval: time.Hour, MaxAttempts: 3, RetryBackoff: time.Millisecond})
+	queue.Start()
+
+	if err := queue.Enqueue(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := queue.Stats()
+	if stats.Applied != 1 || stats.Retries != 2 || stats.Failed != 0 || stats.DeadLettered != 0 {
+		t.Errorf("got stats %+v", stats)
+	}
+}
+
+func TestUpdateQueueRedeliversDeadLetters(t *testing.T) {
+	applies := &recordingApplies{failures: 2}
+	queue := newTestUpdateQueue(applies, QueueConfig{
+		Workers:        1,
+		BatchSize:      10,
+		FlushInterval:  5 * time.Millisecond,
+		MaxAttempts:    2,
+		RetryBackoff:   time.Millisecond,
+		RedeliverEvery: 20 * time.Millisecond,
+	})
+	queue.Start()
+
+	if err := queue.Enqueue(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(applies.applied()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("dead-lettered order was not redelivered, stats %+v", queue.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := queue.Stats()
+	if stats.Failed != 1 || stats.Applied != 1 || stats.DeadLettered != 0 {
+		t.Errorf("got stats %+v", stats)
+	}
+}
+
+func TestUpdateQueueKeepsDeadLettersOnStop(t *testing.T) {
+	applies := &recordingApplies{failures: 1}
+	queue := newTestUpdateQueue(applies, QueueConfig{Workers: 1, BatchSize: 10, FlushInterval: time.Hour, MaxAttempts: 1, RedeliverEvery: time.Hour})
+	queue.Start()
+
+	if err := queue.Enqueue(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := queue.Stats(); stats.DeadLettered != 1 {
+		t.Errorf("got stats %+v, want the failed order dead-lettered", stats)
+	}
+	if err := queue.Enqueue(context.Background(), 2); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("got %v, want ErrQueueClosed", err)
+	}
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// upgrade is a one-off change to data written by an earlier version of the server
+type upgrade struct {
+	name  string
+	query string
+}
+
+// upgrades bring a database kept from an earlier version up to date, in order. Each runs
+// once per database: a SchemaUpgrade node records it, so later starts skip it.
+var upgrades = []upgrade{
+	// Orders placed before the update queue were counted as they were placed, so only
+	// orders left unmarked since then are pending; marking the old ones keeps
+	// EnqueuePending from counting them twice
+	{"orders_derived_at", `
+		MATCH (o:Order)
+		WHERE o.derived_at IS NULL
+		SET o.derived_at = datetime()
+	`},
+	// Categories used to be a string property of the item; they are nodes now
+	{"items_category_nodes", `
+		MATCH (i:Item)
+		WHERE i.category IS NOT NULL
+		MERGE (c:Category {name: i.category})
+		MERGE (i)-[:IN_CATEGORY]->(c)
+		REMOVE i.category
+	`},
+	// Items from before withdrawals were all on the menu
+	{"items_available", `
+		MATCH (i:Item)
+		WHERE i.available IS NULL
+		SET i.available = true
+	`},
+}
+
+// UpgradeData applies the upgrades this database has not had yet and returns their names.
+// A freshly imported database has nothing to upgrade, but still records every upgrade
+// as applied. Run it before EnqueuePending.
+func (i *CSVImporter) UpgradeData(ctx context.Context) ([]string, error) {
+	results, err := i.client.ExecuteRead(ctx, `
+		MATCH (u:SchemaUpgrade)
+		RETURN u.name AS name
+	`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied upgrades: %w", err)
+	}
+
+	applied := make(map[string]bool, len(results))
+	for _, row := range results {
+		name, err := Value[string](row, "name")
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode applied upgrades: %w", err)
+		}
+		applied[name] = true
+	}
+
+	return runUpgrades(ctx, upgrades, applied, i.applyUpgrade)
+}
+
+// runUpgrades runs the upgrades not yet applied, in order, stopping at the first failure
+func runUpgrades(ctx context.Context, all []upgrade, applied map[string]bool, run func(context.Context, upgrade) error) ([]string, error) {
+	var ran []string
+	for _, u := range all {
+		if applied[u.name] {
+			continue
+		}
+		if err := run(ctx, u); err != nil {
+			return ran, fmt.Errorf("failed to apply upgrade %s: %w", u.name, err)
+		}
+		log.Printf("Applied data upgrade %s", u.name)
+		ran = append(ran, u.name)
+	}
+	return ran, nil
+}
+
+// applyUpgrade runs an upgrade and records it in the same transaction, so a failed
+// upgrade is retried on the next start
+func (i *CSVImporter) applyUpgrade(ctx context.Context, u upgrade) error {
+	return i.client.ExecuteWriteTransactionSimple(ctx, func(tx neo4j.ManagedTransaction) error {
+		if _, err := RunInTransaction(ctx, tx, u.query, nil); err != nil {
+			return err
+		}
+		_, err := RunInTransaction(ctx, tx, `
+			MERGE (u:SchemaUpgrade {name: $name})
+			ON CREATE SET u.applied_at = datetime()
+		`, map[string]interface{}{"name": u.name})
+		return err
+	})
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRunUpgrades(t *testing.T) {
+	all := []upgrade{{name: "first"}, {name: "second"}, {name: "third"}}
+	failing := errors.New("neo4j unavailable")
+
+	tests := []struct {
+		name    string
+		applied map[string]bool
+		fail    string
+		wantRan []string
+		wantErr bool
+	}{
+		{"fresh", map[string]bool{}, "", []string{"first", "second", "third"}, false},
+		{"partly applied", map[string]bool{"first": true, "third": true}, "", []string{"second"}, false},
+		{"up to date", map[string]bool{"first": true, "second": true, "third": true}, "", nil, false},
+		{"stops at a failure", map[string]bool{}, "second", []string{"first"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempted []string
+			ran, err := runUpgrades(context.Background(), all, tt.applied, func(_ context.Context, u upgrade) error {
+				attempted = append(attempted, u.name)
+				if u.name == tt.fail {
+					return failing
+				}
+				return nil
+			})
+
+			if tt.wantErr != errors.Is(err, failing) {
+				t.Errorf("got error %v", err)
+			}
+			if !slices.Equal(ran, tt.wantRan) {
+				t.Errorf("ran %v, want %v", ran, tt.wantRan)
+			}
+			if tt.fail != "" && attempted[len(attempted)-1] != tt.fail {
+				t.Errorf("attempted %v after the failure", attempted)
+			}
+		})
+	}
+}
+
+func TestUpgradesHaveUniqueNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, u := range upgrades {
+		if u.name == "" || seen[u.name] {
+			t.Errorf("upgrade name %q is empty or repeated", u.name)
+		}
+		seen[u.name] = true
+		if u.query == "" {
+			t.Errorf("upgrade %s has no query", u.name)
+		}
+	}
+}
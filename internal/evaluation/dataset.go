@@ -0,0 +1,169 @@
+package evaluation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Order is a historical order as read from the CSV exports
+type Order struct {
+	ID          int
+	UserID      int
+	CreatedAt   time.Time
+	TotalAmount float64
+	ItemIDs     []int
+}
+
+// Dataset is the order history split into a training and a held-out part
+type Dataset struct {
+	Cutoff time.Time
+	Train  []Order
+	Test   []Order
+}
+
+// LoadOrders reads orders.csv and order_items.csv from dataDir, oldest order first
+func LoadOrders(dataDir string) ([]Order, error) {
+	orderRows, err := readCSV(filepath.Join(dataDir, "orders.csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	ordersByID := make(map[int]*Order)
+	var orders []*Order
+	for _, row := range orderRows {
+		id, err := strconv.Atoi(row["order_id"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid order_id %q: %w", row["order_id"], err)
+		}
+		userID, err := strconv.Atoi(row["user_id"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid user_id %q: %w", row["user_id"], err)
+		}
+		createdAt, err := time.Parse(time.RFC3339, row["created_at"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at %q: %w", row["created_at"], err)
+		}
+		total, err := strconv.ParseFloat(row["total_amount"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total_amount %q: %w", row["total_amount"], err)
+		}
+
+		order := &Order{ID: id, UserID: userID, CreatedAt: createdAt, TotalAmount: total}
+		ordersByID[id] = order
+		orders = append(orders, order)
+	}
+
+	itemRows, err := readCSV(filepath.Join(dataDir, "order_items.csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range itemRows {
+		orderID, err := strconv.Atoi(row["order_id"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid order_id %q: %w", row["order_id"], err)
+		}
+		itemID, err := strconv.Atoi(row["item_id"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid item_id %q: %w", row["item_id"], err)
+		}
+		if order, ok := ordersByID[orderID]; ok {
+			order.ItemIDs = append(order.ItemIDs, itemID)
+		}
+	}
+
+	result := make([]Order, 0, len(orders))
+	for _, order := range orders {
+		if len(order.ItemIDs) > 0 {
+			result = append(result, *order)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// SplitByTime puts orders created before cutoff into training and the rest into test
+func SplitByTime(orders []Order, cutoff time.Time) Dataset {
+	dataset := Dataset{Cutoff: cutoff}
+	for _, order := range orders {
+		if order.CreatedAt.Before(cutoff) {
+			dataset.Train = append(dataset.Train, order)
+		} else {
+			dataset.Test = append(dataset.Test, order)
+		}
+	}
+	return dataset
+}
+
+// ValidationSplit carves a validation split out of the training period of a
+// train/test split at testCutoff: the latest validationRatio of the orders before
+// testCutoff become the held-out part, and orders from testCutoff on are left out
+// entirely so tuning never sees the split the final report is scored on.
+func ValidationSplit(orders []Order, testCutoff time.Time, validationRatio float64) (Dataset, error) {
+	training := SplitByTime(orders, testCutoff).Train
+	cutoff, err := CutoffForRatio(training, 1-validationRatio)
+	if err != nil {
+		return Dataset{}, fmt.Errorf("failed to carve a validation split from the training orders: %w", err)
+	}
+	return SplitByTime(training, cutoff), nil
+}
+
+// CutoffForRatio returns the time that leaves trainRatio of the (time-sorted) orders before it
+func CutoffForRatio(orders []Order, trainRatio float64) (time.Time, error) {
+	if len(orders) < 2 {
+		return time.Time{}, fmt.Errorf("need at least 2 orders to split, have %d", len(orders))
+	}
+	if trainRatio <= 0 || trainRatio >= 1 {
+		return time.Time{}, fmt.Errorf("train ratio must be between 0 and 1, got %v", trainRatio)
+	}
+
+	idx := int(math.Round(float64(len(orders)) * trainRatio))
+	if idx < 1 {
+		idx = 1
+	}
+	if idx > len(orders)-1 {
+		idx = len(orders) - 1
+	}
+
+	return orders[idx].CreatedAt, nil
+}
+
+// readCSV reads a CSV file with a header row into one map per data row
+func readCSV(filePath string) ([]map[string]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV records from %s: %w", filePath, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for j, value := range record {
+			row[strings.TrimSpace(header[j])] = strings.TrimSpace(value)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,42 @@
+package evaluation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidationSplit(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	orders := make([]Order, 10)
+	for i := range orders {
+		orders[i] = Order{ID: i, CreatedAt: start.AddDate(0, 0, i), ItemIDs: []int{1}}
+	}
+
+	testCutoff, err := CutoffForRatio(orders, 0.8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataset, err := ValidationSplit(orders, testCutoff, 0.2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := start.AddDate(0, 0, 6); !dataset.Cutoff.Equal(want) {
+		t.Errorf("cutoff = %s, want %s", dataset.Cutoff, want)
+	}
+	if len(dataset.Train) != 6 || len(dataset.Test) != 2 {
+		t.Fatalf("got %d training and %d validation orders, want 6 and 2", len(dataset.Train), len(dataset.Test))
+	}
+	for _, order := range append(dataset.Train, dataset.Test...) {
+		if !order.CreatedAt.Before(testCutoff) {
+			t.Errorf("order %d from the test split was used", order.ID)
+		}
+	}
+}
+
+func TestValidationSplitNeedsTrainingOrders(t *testing.T) {
+	orders := []Order{{ID: 1, CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	if _, err := ValidationSplit(orders, orders[0].CreatedAt, 0.2); err == nil {
+		t.Error("expected an error without training orders")
+	}
+}
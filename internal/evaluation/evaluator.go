@@ -0,0 +1,199 @@
+package evaluation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// Case is one held-out order replayed as a recommendation request.
+// For multi-item orders the first item plays the item in the cart and the
+// remaining items are what the strategy should have recommended.
+type Case struct {
+	OrderID    int
+	UserID     int
+	CartItemID *int
+	Relevant   map[int]bool
+}
+
+// Strategy produces a ranked list of recommendations for a case
+type Strategy struct {
+	Name      string
+	Recommend func(ctx context.Context, c Case) ([]models.Recommendation, error)
+}
+
+// Report is the outcome of evaluating a set of strategies on one split
+type Report struct {
+	Cutoff      time.Time `json:"cutoff"`
+	TrainOrders int       `json:"train_orders"`
+	TestOrders  int       `json:"test_orders"`
+	Cases       int       `json:"cases"`
+	K           int       `json:"k"`
+	Results     []Metrics `json:"results"`
+}
+
+// Evaluator scores strategies against held-out orders
+type Evaluator struct {
+	k             int
+	catalogueSize int
+	popularity    map[int]float64
+}
+
+// NewEvaluator creates an evaluator; train is used to measure item popularity for novelty
+func NewEvaluator(k int, catalogueSize int, train []Order) *Evaluator {
+	counts := make(map[int]int)
+	for _, order := range train {
+		seen := make(map[int]bool)
+		for _, itemID := range order.ItemIDs {
+			if !seen[itemID] {
+				counts[itemID]++
+				seen[itemID] = true
+			}
+		}
+	}
+
+	popularity := make(map[int]float64, len(counts))
+	for itemID, count := range counts {
+		popularity[itemID] = float64(count) / float64(len(train))
+	}
+
+	return &Evaluator{
+		k:             k,
+		catalogueSize: catalogueSize,
+		popularity:    popularity,
+	}
+}
+
+// BuildCases turns held-out orders into evaluation cases
+func BuildCases(test []Order) []Case {
+	cases := make([]Case, 0, len(test))
+	for _, order := range test {
+		c := Case{
+			OrderID:  order.ID,
+			UserID:   order.UserID,
+			Relevant: make(map[int]bool),
+		}
+
+		targets := order.ItemIDs
+		if len(order.ItemIDs) > 1 {
+			cartItemID := order.ItemIDs[0]
+			c.CartItemID = &cartItemID
+			targets = order.ItemIDs[1:]
+		}
+		for _, itemID := range targets {
+			if c.CartItemID == nil || itemID != *c.CartItemID {
+				c.Relevant[itemID] = true
+			}
+		}
+
+		if len(c.Relevant) > 0 {
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// Evaluate runs every strategy over every case
+func (e *Evaluator) Evaluate(ctx context.Context, strategies []Strategy, cases []Case) []Metrics {
+	results := make([]Metrics, 0, len(strategies))
+	for _, strategy := range strategies {
+		results = append(results, e.EvaluateStrategy(ctx, strategy, cases))
+	}
+	return results
+}
+
+// EvaluateStrategy runs a single strategy over every case
+func (e *Evaluator) EvaluateStrategy(ctx context.Context, strategy Strategy, cases []Case) Metrics {
+	acc := newAccumulator(e.k)
+	for _, c := range cases {
+		recommendations, err := strategy.Recommend(ctx, c)
+		if err != nil {
+			log.Printf("Warning: %s failed for order %d: %v", strategy.Name, c.OrderID, err)
+			acc.errors++
+			continue
+		}
+		acc.add(rankedItemIDs(recommendations, c.CartItemID), c.Relevant, e.popularity)
+	}
+	return acc.metrics(strategy.Name, e.catalogueSize)
+}
+
+// rankedItemIDs flattens recommendations into item IDs, dropping the cart item and duplicates
+func rankedItemIDs(recommendations []models.Recommendation, cartItemID *int) []int {
+	seen := make(map[int]bool)
+	ranked := make([]int, 0, len(recommendations))
+	for _, rec := range recommendations {
+		itemID := rec.Item.DbID
+		if seen[itemID] || (cartItemID != nil && itemID == *cartItemID) {
+			continue
+		}
+		seen[itemID] = true
+		ranked = append(ranked, itemID)
+	}
+	return ranked
+}
+
+// DefaultStrategies returns every RecommendationService strategy plus the hybrid
+// with the weights the API would pick for each user
+func DefaultStrategies(service *services.RecommendationService, trendDays int) []Strategy {
+	return []Strategy{
+		{
+			Name: "UserFrequency",
+			Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+				return service.GetUserFrequentItems(ctx, c.UserID)
+			},
+		},
+		{
+			Name: "UserCoOrders",
+			Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+				if c.CartItemID == nil {
+					return nil, nil
+				}
+				return service.GetUserCoOrderedItems(ctx, c.UserID, *c.CartItemID)
+			},
+		},
+		{
+			Name: "GlobalCoOrders",
+			Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+				if c.CartItemID == nil {
+					return nil, nil
+				}
+				return service.GetGlobalCoOrderedItems(ctx, *c.CartItemID)
+			},
+		},
+		{
+			Name: "TimeBasedTrend",
+			Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+				return service.GetTimeBasedTrendingItems(ctx, trendDays)
+			},
+		},
+		{
+			Name: "PriceSensitivity",
+			Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+				return service.GetPriceSensitiveItems(ctx, c.UserID)
+			},
+		},
+		{
+			Name: "Ratings",
+			Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+				return service.GetRatingBasedItems(ctx, c.UserID)
+			},
+		},
+		HybridStrategy("Hybrid", service, service.GetWeightsForUser),
+		HybridStrategy("HybridDefault", service, func(ctx context.Context, userID int) models.HybridWeights {
+			return service.GetDefaultWeights()
+		}),
+	}
+}
+
+// HybridStrategy evaluates HybridRecommendation with weights chosen per user
+func HybridStrategy(name string, service *services.RecommendationService, weightsFor func(ctx context.Context, userID int) models.HybridWeights) Strategy {
+	return Strategy{
+		Name: name,
+		Recommend: func(ctx context.Context, c Case) ([]models.Recommendation, error) {
+			return service.HybridRecommendation(ctx, c.UserID, c.CartItemID, weightsFor(ctx, c.UserID))
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package evaluation
+
+import "math"
+
+// Metrics are ranking quality measures averaged over all evaluated cases
+type Metrics struct {
+	Strategy  string  `json:"strategy"`
+	K         int     `json:"k"`
+	Cases     int     `json:"cases"`
+	Precision float64 `json:"precision_at_k"`
+	Recall    float64 `json:"recall_at_k"`
+	HitRate   float64 `json:"hit_rate_at_k"`
+	NDCG      float64 `json:"ndcg_at_k"`
+	MAP       float64 `json:"map_at_k"`
+	Coverage  float64 `json:"catalogue_coverage"`
+	Novelty   float64 `json:"novelty"`
+	Errors    int     `json:"errors"`
+}
+
+// accumulator sums per-case metrics for one strategy
+type accumulator struct {
+	k           int
+	cases       int
+	errors      int
+	precision   float64
+	recall      float64
+	hits        float64
+	ndcg        float64
+	ap          float64
+	novelty     float64
+	novelItems  int
+	recommended map[int]bool
+}
+
+func newAccumulator(k int) *accumulator {
+	return &accumulator{k: k, recommended: make(map[int]bool)}
+}
+
+// add scores one ranked list against the items the user actually ordered.
+// popularity maps item IDs to the share of training orders containing them.
+func (a *accumulator) add(ranked []int, relevant map[int]bool, popularity map[int]float64) {
+	if len(ranked) > a.k {
+		ranked = ranked[:a.k]
+	}
+
+	a.cases++
+	a.precision += precisionAtK(ranked, relevant, a.k)
+	a.recall += recallAtK(ranked, relevant)
+	a.ndcg += ndcgAtK(ranked, relevant, a.k)
+	a.ap += averagePrecisionAtK(ranked, relevant, a.k)
+	if hitAtK(ranked, relevant) {
+		a.hits++
+	}
+
+	for _, itemID := range ranked {
+		a.recommended[itemID] = true
+		a.novelty += selfInformation(popularity[itemID])
+		a.novelItems++
+	}
+}
+
+// metrics returns the averages accumulated so far
+func (a *accumulator) metrics(strategy string, catalogueSize int) Metrics {
+	m := Metrics{Strategy: strategy, K: a.k, Cases: a.cases, Errors: a.errors}
+	if a.cases > 0 {
+		n := float64(a.cases)
+		m.Precision = a.precision / n
+		m.Recall = a.recall / n
+		m.HitRate = a.hits / n
+		m.NDCG = a.ndcg / n
+		m.MAP = a.ap / n
+	}
+	if catalogueSize > 0 {
+		m.Coverage = float64(len(a.recommended)) / float64(catalogueSize)
+	}
+	if a.novelItems > 0 {
+		m.Novelty = a.novelty / float64(a.novelItems)
+	}
+	return m
+}
+
+// precisionAtK is the share of the top K slots filled with relevant items
+func precisionAtK(ranked []int, relevant map[int]bool, k int) float64 {
+	if k == 0 {
+		return 0
+	}
+	return float64(countHits(ranked, relevant)) / float64(k)
+}
+
+// recallAtK is the share of relevant items found in the top K
+func recallAtK(ranked []int, relevant map[int]bool) float64 {
+	if len(relevant) == 0 {
+		return 0
+	}
+	return float64(countHits(ranked, relevant)) / float64(len(relevant))
+}
+
+// hitAtK reports whether any relevant item made the top K
+func hitAtK(ranked []int, relevant map[int]bool) bool {
+	return countHits(ranked, relevant) > 0
+}
+
+// ndcgAtK is the discounted cumulative gain normalised by the best possible ranking
+func ndcgAtK(ranked []int, relevant map[int]bool, k int) float64 {
+	dcg := 0.0
+	for i, itemID := range ranked {
+		if relevant[itemID] {
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+	}
+
+	ideal := 0.0
+	for i := 0; i < len(relevant) && i < k; i++ {
+		ideal += 1 / math.Log2(float64(i+2))
+	}
+	if ideal == 0 {
+		return 0
+	}
+	return dcg / ideal
+}
+
+// averagePrecisionAtK averages the precision at every rank holding a relevant item
+func averagePrecisionAtK(ranked []int, relevant map[int]bool, k int) float64 {
+	hits := 0
+	sum := 0.0
+	for i, itemID := range ranked {
+		if relevant[itemID] {
+			hits++
+			sum += float64(hits) / float64(i+1)
+		}
+	}
+
+	denominator := len(relevant)
+	if denominator > k {
+		denominator = k
+	}
+	if denominator == 0 {
+		return 0
+	}
+	return sum / float64(denominator)
+}
+
+// selfInformation measures how surprising an item is; unseen items get the
+// information of an item seen once in a very large history
+func selfInformation(popularity float64) float64 {
+	if popularity <= 0 {
+		popularity = 1e-6
+	}
+	return -math.Log2(popularity)
+}
+
+func countHits(ranked []int, relevant map[int]bool) int {
+	hits := 0
+	for _, itemID := range ranked {
+		if relevant[itemID] {
+			hits++
+		}
+	}
+	return hits
+}
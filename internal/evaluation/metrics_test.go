@@ -0,0 +1,148 @@
+package evaluation
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func relevantSet(ids ...int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func TestRankingMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		ranked    []int
+		relevant  map[int]bool
+		k         int
+		precision float64
+		recall    float64
+		hit       bool
+		ndcg      float64
+		ap        float64
+	}{
+		{
+			name: "hits at ranks 1 and 3", ranked: []int{1, 2, 3}, relevant: relevantSet(1, 3), k: 3,
+			precision: 2.0 / 3, recall: 1, hit: true,
+			ndcg: (1 + 0.5) / (1 + 1/math.Log2(3)), ap: (1 + 2.0/3) / 2,
+		},
+		{
+			name: "single hit at rank 2", ranked: []int{9, 1}, relevant: relevantSet(1), k: 2,
+			precision: 0.5, recall: 1, hit: true,
+			ndcg: 1 / math.Log2(3), ap: 0.5,
+		},
+		{
+			name: "more relevant items than slots", ranked: []int{1, 2}, relevant: relevantSet(1, 2, 3, 4), k: 2,
+			precision: 1, recall: 0.5, hit: true,
+			ndcg: 1, ap: 1,
+		},
+		{
+			name: "short list is not padded", ranked: []int{1}, relevant: relevantSet(1), k: 5,
+			precision: 0.2, recall: 1, hit: true,
+			ndcg: 1, ap: 1,
+		},
+		{
+			name: "no hits", ranked: []int{4, 5}, relevant: relevantSet(1), k: 2,
+		},
+		{
+			name: "nothing relevant", ranked: []int{1, 2}, relevant: relevantSet(), k: 2,
+		},
+		{
+			name: "k of zero", ranked: nil, relevant: relevantSet(1), k: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := precisionAtK(tt.ranked, tt.relevant, tt.k); !approxEqual(got, tt.precision) {
+				t.Errorf("precision = %v, want %v", got, tt.precision)
+			}
+			if got := recallAtK(tt.ranked, tt.relevant); !approxEqual(got, tt.recall) {
+				t.Errorf("recall = %v, want %v", got, tt.recall)
+			}
+			if got := hitAtK(tt.ranked, tt.relevant); got != tt.hit {
+				t.Errorf("hit = %v, want %v", got, tt.hit)
+			}
+			if got := ndcgAtK(tt.ranked, tt.relevant, tt.k); !approxEqual(got, tt.ndcg) {
+				t.Errorf("ndcg = %v, want %v", got, tt.ndcg)
+			}
+			if got := averagePrecisionAtK(tt.ranked, tt.relevant, tt.k); !approxEqual(got, tt.ap) {
+				t.Errorf("average precision = %v, want %v", got, tt.ap)
+			}
+		})
+	}
+}
+
+func TestSelfInformation(t *testing.T) {
+	tests := []struct {
+		popularity float64
+		want       float64
+	}{
+		{1, 0},
+		{0.5, 1},
+		{0.25, 2},
+		{0, -math.Log2(1e-6)},
+	}
+	for _, tt := range tests {
+		if got := selfInformation(tt.popularity); !approxEqual(got, tt.want) {
+			t.Errorf("selfInformation(%v) = %v, want %v", tt.popularity, got, tt.want)
+		}
+	}
+}
+
+func TestAccumulatorMetrics(t *testing.T) {
+	acc := newAccumulator(2)
+	popularity := map[int]float64{1: 0.5, 2: 0.25}
+	// The third item is beyond K and must not count
+	acc.add([]int{1, 2, 3}, relevantSet(1), popularity)
+	acc.add([]int{3}, relevantSet(4), popularity)
+	acc.errors++
+
+	m := acc.metrics("hybrid", 6)
+	want := Metrics{
+		Strategy:  "hybrid",
+		K:         2,
+		Cases:     2,
+		Errors:    1,
+		Precision: 0.25,
+		Recall:    0.5,
+		HitRate:   0.5,
+		NDCG:      0.5,
+		MAP:       0.5,
+		Coverage:  0.5,
+		Novelty:   (1 + 2 - math.Log2(1e-6)) / 3,
+	}
+	if m.Strategy != want.Strategy || m.K != want.K || m.Cases != want.Cases || m.Errors != want.Errors {
+		t.Fatalf("got %+v, want %+v", m, want)
+	}
+	for _, f := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"precision", m.Precision, want.Precision},
+		{"recall", m.Recall, want.Recall},
+		{"hit rate", m.HitRate, want.HitRate},
+		{"ndcg", m.NDCG, want.NDCG},
+		{"map", m.MAP, want.MAP},
+		{"coverage", m.Coverage, want.Coverage},
+		{"novelty", m.Novelty, want.Novelty},
+	} {
+		if !approxEqual(f.got, f.want) {
+			t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+}
+
+func TestAccumulatorWithoutCases(t *testing.T) {
+	m := newAccumulator(10).metrics("trending", 0)
+	if m != (Metrics{Strategy: "trending", K: 10}) {
+		t.Errorf("got %+v, want zero metrics", m)
+	}
+}
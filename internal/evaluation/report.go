@@ -0,0 +1,29 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteJSON writes the report as indented JSON
+func WriteJSON(w io.Writer, report Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// WriteTable writes the report as an aligned plain-text table
+func WriteTable(w io.Writer, report Report) error {
+	fmt.Fprintf(w, "Cutoff: %s  train orders: %d  test orders: %d  cases: %d  K: %d\n\n",
+		report.Cutoff.Format("2006-01-02"), report.TrainOrders, report.TestOrders, report.Cases, report.K)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "strategy\tP@K\tR@K\tHit@K\tNDCG@K\tMAP@K\tcoverage\tnovelty\terrors\t")
+	for _, m := range report.Results {
+		fmt.Fprintf(tw, "%s\t%.4f\t%.4f\t%.4f\t%.4f\t%.4f\t%.4f\t%.3f\t%d\t\n",
+			m.Strategy, m.Precision, m.Recall, m.HitRate, m.NDCG, m.MAP, m.Coverage, m.Novelty, m.Errors)
+	}
+	return tw.Flush()
+}
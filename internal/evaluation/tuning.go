@@ -0,0 +1,270 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// Search methods supported by the tuner
+const (
+	MethodGrid       = "grid"
+	MethodRandom     = "random"
+	MethodCoordinate = "coordinate"
+)
+
+// weightDimensions is the number of components in models.HybridWeights
+const weightDimensions = 6
+
+// TuningResult is the best weighting found for one segment
+type TuningResult struct {
+	Segment     string               `json:"segment"`
+	Weights     models.HybridWeights `json:"weights"`
+	Score       float64              `json:"score"`
+	Cases       int                  `json:"cases"`
+	Evaluations int                  `json:"evaluations"`
+}
+
+// Tuner searches the hybrid weight space for the weights that maximise a metric
+type Tuner struct {
+	evaluator *Evaluator
+	service   *services.RecommendationService
+	metric    string
+	rng       *rand.Rand
+}
+
+// NewTuner creates a tuner optimising the named metric (see MetricValue)
+func NewTuner(evaluator *Evaluator, service *services.RecommendationService, metric string, seed int64) (*Tuner, error) {
+	if _, err := MetricValue(Metrics{}, metric); err != nil {
+		return nil, err
+	}
+	return &Tuner{
+		evaluator: evaluator,
+		service:   service,
+		metric:    metric,
+		rng:       rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// MetricValue extracts a metric by its JSON-style name
+func MetricValue(m Metrics, name string) (float64, error) {
+	switch name {
+	case "precision":
+		return m.Precision, nil
+	case "recall":
+		return m.Recall, nil
+	case "hit_rate":
+		return m.HitRate, nil
+	case "ndcg":
+		return m.NDCG, nil
+	case "map":
+		return m.MAP, nil
+	case "coverage":
+		return m.Coverage, nil
+	case "novelty":
+		return m.Novelty, nil
+	default:
+		return 0, fmt.Errorf("unknown metric %q (use precision, recall, hit_rate, ndcg, map, coverage or novelty)", name)
+	}
+}
+
+// SplitCasesBySegment separates cases of new and experienced users as IsNewUser sees them
+func SplitCasesBySegment(ctx context.Context, service *services.RecommendationService, cases []Case) (newUser, experienced []Case, err error) {
+	segments := make(map[int]bool)
+	for _, c := range cases {
+		isNew, known := segments[c.UserID]
+		if !known {
+			isNew, err = service.IsNewUser(ctx, c.UserID)
+			if err != nil {
+				return nil, nil, err
+			}
+			segments[c.UserID] = isNew
+		}
+
+		if isNew {
+			newUser = append(newUser, c)
+		} else {
+			experienced = append(experienced, c)
+		}
+	}
+	return newUser, experienced, nil
+}
+
+// Tune runs the chosen search method over the cases of one segment
+func (t *Tuner) Tune(ctx context.Context, segment, method string, cases []Case, start models.HybridWeights, budget int) (TuningResult, error) {
+	result := TuningResult{Segment: segment, Cases: len(cases), Weights: start}
+	if len(cases) == 0 {
+		log.Printf("No validation cases for segment %s, keeping current weights", segment)
+		return result, nil
+	}
+
+	var err error
+	switch method {
+	case MethodGrid:
+		err = t.gridSearch(ctx, cases, budget, &result)
+	case MethodRandom:
+		err = t.randomSearch(ctx, cases, budget, &result)
+	case MethodCoordinate:
+		err = t.coordinateAscent(ctx, cases, budget, &result)
+	default:
+		err = fmt.Errorf("unknown search method %q (use grid, random or coordinate)", method)
+	}
+
+	return result, err
+}
+
+// score evaluates the hybrid with fixed weights and returns the tuned metric
+func (t *Tuner) score(ctx context.Context, cases []Case, weights models.HybridWeights) float64 {
+	strategy := HybridStrategy("Hybrid", t.service, func(context.Context, int) models.HybridWeights {
+		return weights
+	})
+	value, _ := MetricValue(t.evaluator.EvaluateStrategy(ctx, strategy, cases), t.metric)
+	return value
+}
+
+// consider scores a candidate and keeps it if it beats the best so far
+func (t *Tuner) consider(ctx context.Context, cases []Case, vector []float64, result *TuningResult) bool {
+	weights, ok := toWeights(vector)
+	if !ok {
+		return false
+	}
+
+	value := t.score(ctx, cases, weights)
+	result.Evaluations++
+	if result.Evaluations == 1 || value > result.Score {
+		result.Score = value
+		result.Weights = weights
+		return true
+	}
+	return false
+}
+
+// gridSearch tries every combination of `steps` evenly spaced values per weight.
+// Weightings that are multiples of each other rank identically, so they are scored once.
+func (t *Tuner) gridSearch(ctx context.Context, cases []Case, steps int, result *TuningResult) error {
+	if steps < 2 {
+		return fmt.Errorf("grid search needs at least 2 steps per weight, got %d", steps)
+	}
+
+	seen := make(map[[weightDimensions]int64]bool)
+	vector := make([]float64, weightDimensions)
+
+	var walk func(dim int)
+	walk = func(dim int) {
+		if ctx.Err() != nil {
+			return
+		}
+		if dim == weightDimensions {
+			key, ok := normalisedKey(vector)
+			if !ok || seen[key] {
+				return
+			}
+			seen[key] = true
+			t.consider(ctx, cases, vector, result)
+			return
+		}
+		for step := 0; step < steps; step++ {
+			vector[dim] = float64(step) / float64(steps-1)
+			walk(dim + 1)
+		}
+	}
+	walk(0)
+
+	return ctx.Err()
+}
+
+// randomSearch scores `iterations` weightings drawn uniformly from the simplex
+func (t *Tuner) randomSearch(ctx context.Context, cases []Case, iterations int, result *TuningResult) error {
+	if iterations < 1 {
+		return fmt.Errorf("random search needs at least 1 iteration, got %d", iterations)
+	}
+
+	// Always score the starting point so tuning never does worse than it
+	t.consider(ctx, cases, fromWeights(result.Weights), result)
+
+	for i := 0; i < iterations && ctx.Err() == nil; i++ {
+		vector := make([]float64, weightDimensions)
+		for d := range vector {
+			vector[d] = -math.Log(1 - t.rng.Float64())
+		}
+		t.consider(ctx, cases, vector, result)
+	}
+
+	return ctx.Err()
+}
+
+// coordinateAscent nudges one weight at a time from the starting point,
+// halving the step whenever no single move improves the metric
+func (t *Tuner) coordinateAscent(ctx context.Context, cases []Case, rounds int, result *TuningResult) error {
+	if rounds < 1 {
+		return fmt.Errorf("coordinate ascent needs at least 1 round, got %d", rounds)
+	}
+
+	t.consider(ctx, cases, fromWeights(result.Weights), result)
+	step := 0.2
+
+	for round := 0; round < rounds && step >= 0.01 && ctx.Err() == nil; round++ {
+		improved := false
+		for d := 0; d < weightDimensions; d++ {
+			for _, delta := range []float64{step, -step} {
+				vector := fromWeights(result.Weights)
+				vector[d] = math.Max(0, vector[d]+delta)
+				if t.consider(ctx, cases, vector, result) {
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+
+	return ctx.Err()
+}
+
+// fromWeights and toWeights convert between HybridWeights and a search vector
+func fromWeights(w models.HybridWeights) []float64 {
+	return []float64{w.UserFrequency, w.UserCoOrders, w.GlobalCoOrders, w.TimeBasedTrend, w.PriceSensitivity, w.Ratings}
+}
+
+func toWeights(vector []float64) (models.HybridWeights, bool) {
+	total := 0.0
+	for _, v := range vector {
+		total += v
+	}
+	if total <= 0 {
+		return models.HybridWeights{}, false
+	}
+
+	// Normalise so profiles are comparable; rounding keeps the output file readable
+	round := func(v float64) float64 { return math.Round(v/total*1000) / 1000 }
+	return models.HybridWeights{
+		UserFrequency:    round(vector[0]),
+		UserCoOrders:     round(vector[1]),
+		GlobalCoOrders:   round(vector[2]),
+		TimeBasedTrend:   round(vector[3]),
+		PriceSensitivity: round(vector[4]),
+		Ratings:          round(vector[5]),
+	}, true
+}
+
+// normalisedKey identifies a weighting up to scale
+func normalisedKey(vector []float64) ([weightDimensions]int64, bool) {
+	var key [weightDimensions]int64
+	total := 0.0
+	for _, v := range vector {
+		total += v
+	}
+	if total <= 0 {
+		return key, false
+	}
+	for d, v := range vector {
+		key[d] = int64(math.Round(v / total * 1e6))
+	}
+	return key, true
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"log"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// resolverError is an error as GraphQL reports it, with the service error kind as the
+// "code" extension so clients can branch on it as they do on the REST error envelope
+type resolverError struct {
+	kind    services.ErrorKind
+	message string
+	field   string
+}
+
+// Error implements error
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+// Extensions implements the graphql-go hook that adds extensions to an error
+func (e *resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": string(e.kind)}
+	if e.field != "" {
+		extensions["field"] = e.field
+	}
+	return extensions
+}
+
+// serviceError reports an error returned by a service as respondError does for the
+// REST API: errors the caller can fix carry the service's message, while unavailable,
+// timeout and internal errors are logged and reported with the given message instead.
+func serviceError(err error, message string) error {
+	kind := services.KindOf(err)
+	switch kind {
+	case services.KindInvalidArgument, services.KindNotFound, services.KindConflict, services.KindUnsupported:
+		message = err.Error()
+	default:
+		log.Printf("%s: %v", message, err)
+	}
+	return &resolverError{kind: kind, message: message}
+}
+
+// invalidArgument reports a rejected argument
+func invalidArgument(field, message string) error {
+	return &resolverError{kind: services.KindInvalidArgument, message: field + " " + message, field: field}
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// loader batches one kind of lookup within a request. Resolvers that return a list
+// prime it with the keys of every element, so the first nested field that needs the
+// data fetches it for all of them in one query and the other elements are answered
+// from memory. Keys that were never primed are fetched together with whatever is
+// pending when they are first loaded. The lock is not held while a batch is fetched;
+// loads of a key in that batch wait for it instead, and loads of other keys go ahead.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	// inflight holds, for each key being fetched, a channel closed when its batch is done
+	inflight map[K]chan struct{}
+	// fetched holds the error of the batch that fetched each key, nil on success
+	fetched map[K]error
+	values  map[K]V
+}
+
+// newLoader creates a loader that fetches batches with fetch; keys missing from its
+// result have no value
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		queued:   map[K]bool{},
+		inflight: map[K]chan struct{}{},
+		fetched:  map[K]error{},
+		values:   map[K]V{},
+	}
+}
+
+// prime queues keys to be fetched with the next batch
+func (l *loader[K, V]) prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		l.enqueue(key)
+	}
+}
+
+// load returns the value of a key, fetching it with every queued key first if needed;
+// ok is false when the key has no value
+func (l *loader[K, V]) load(ctx context.Context, key K) (value V, ok bool, err error) {
+	l.mu.Lock()
+	for {
+		if err, done := l.fetched[key]; done {
+			value, ok = l.values[key]
+			l.mu.Unlock()
+			return value, ok, err
+		}
+		wait, busy := l.inflight[key]
+		if !busy {
+			break
+		}
+		l.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return value, false, ctx.Err()
+		}
+		l.mu.Lock()
+	}
+
+	l.enqueue(key)
+	keys := l.pending
+	l.pending = nil
+	done := make(chan struct{})
+	for _, k := range keys {
+		l.inflight[k] = done
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, keys)
+
+	l.mu.Lock()
+	for _, k := range keys {
+		l.fetched[k] = err
+		if v, found := values[k]; found && err == nil {
+			l.values[k] = v
+		}
+		delete(l.inflight, k)
+	}
+	close(done)
+	value, ok = l.values[key]
+	l.mu.Unlock()
+	return value, ok, err
+}
+
+// enqueue adds a key to the next batch unless it is already queued or fetched
+func (l *loader[K, V]) enqueue(key K) {
+	if l.queued[key] {
+		return
+	}
+	l.queued[key] = true
+	l.pending = append(l.pending, key)
+}
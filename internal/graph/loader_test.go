@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// recordingFetch answers every key with its double and records the batches it was asked for
+type recordingFetch struct {
+	mu      sync.Mutex
+	batches [][]int
+	entered chan struct{} // when set, receives a value as each fetch starts
+	release chan struct{} // when set, fetches wait for it to close
+	err     error
+}
+
+func (r *recordingFetch) fetch(_ context.Context, keys []int) (map[int]int, error) {
+	if r.entered != nil {
+		r.entered <- struct{}{}
+	}
+	if r.release != nil {
+		<-r.release
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	batch := slices.Clone(keys)
+	slices.Sort(batch)
+	r.batches = append(r.batches, batch)
+	if r.err != nil {
+		return nil, r.err
+	}
+	values := make(map[int]int, len(keys))
+	for _, key := range keys {
+		if key > 0 {
+			values[key] = key * 2
+		}
+	}
+	return values, nil
+}
+
+func (r *recordingFetch) fetched() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.batches)
+}
+
+func TestLoaderBatchesPrimedKeys(t *testing.T) {
+	fetch := &recordingFetch{}
+	l := newLoader(fetch.fetch)
+	l.prime(1, 2, 3)
+
+	ctx := context.Background()
+	for _, key := range []int{2, 1, 3, 2} {
+		value, ok, err := l.load(ctx, key)
+		if err != nil || !ok || value != key*2 {
+			t.Errorf("load(%d) = %d, %v, %v", key, value, ok, err)
+		}
+	}
+	if _, ok, err := l.load(ctx, -1); ok || err != nil {
+		t.Errorf("load(-1) found a value or failed: %v", err)
+	}
+
+	want := [][]int{{1, 2, 3}, {-1}}
+	if got := fetch.fetched(); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got batches %v, want %v", got, want)
+	}
+}
+
+func TestLoaderRemembersErrors(t *testing.T) {
+	fetch := &recordingFetch{err: errors.New("neo4j unavailable")}
+	l := newLoader(fetch.fetch)
+	l.prime(1, 2)
+
+	for _, key := range []int{1, 2} {
+		if _, _, err := l.load(context.Background(), key); !errors.Is(err, fetch.err) {
+			t.Errorf("load(%d) = %v, want the fetch error", key, err)
+		}
+	}
+	if got := len(fetch.fetched()); got != 1 {
+		t.Errorf("fetched %d batches, want 1", got)
+	}
+}
+
+func TestLoaderWaitsForInflightBatch(t *testing.T) {
+	fetch := &recordingFetch{entered: make(chan struct{}, 2), release: make(chan struct{})}
+	l := newLoader(fetch.fetch)
+	l.prime(1, 2)
+
+	var wg sync.WaitGroup
+	results := make([]int, 2)
+	for i, key := range []int{1, 2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, _ = l.load(context.Background(), key)
+		}()
+	}
+
+	// The loader must not be locked while the batch is fetched
+	<-fetch.entered
+	primed := make(chan struct{})
+	go func() {
+		l.prime(3)
+		close(primed)
+	}()
+	select {
+	case <-primed:
+	case <-time.After(time.Second):
+		t.Fatal("prime blocked while a batch was being fetched")
+	}
+
+	close(fetch.release)
+	wg.Wait()
+
+	if results[0] != 2 || results[1] != 4 {
+		t.Errorf("got %v, want [2 4]", results)
+	}
+	if got := fetch.fetched(); len(got) != 1 {
+		t.Errorf("got batches %v, want one batch shared by both loads", got)
+	}
+}
+
+func TestLoadersBatchOrdersOfListedUsers(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]ordersKey
+	l := &loaders{
+		profiles: newLoader(func(context.Context, []int) (map[int]models.UserProfile, error) {
+			return nil, nil
+		}),
+		orders: newLoader(func(_ context.Context, keys []ordersKey) (map[ordersKey][]models.Order, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, slices.Clone(keys))
+			orders := make(map[ordersKey][]models.Order, len(keys))
+			for _, key := range keys {
+				orders[key] = []models.Order{{DbID: key.userID*100 + key.limit}}
+			}
+			return orders, nil
+		}),
+		primedLimits: map[int]bool{},
+	}
+	l.primeUsers([]models.User{{DbID: 1}, {DbID: 2}, {DbID: 3}})
+
+	ctx := context.Background()
+	for _, userID := range []int{1, 2, 3} {
+		orders, err := l.loadOrders(ctx, userID, 5)
+		if err != nil || len(orders) != 1 || orders[0].DbID != userID*100+5 {
+			t.Errorf("loadOrders(%d) = %v, %v", userID, orders, err)
+		}
+	}
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("got batches %v, want one batch for all three users", batches)
+	}
+
+	// Another limit is one more batch for everyone
+	if _, err := l.loadOrders(ctx, 2, 10); err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 2 || len(batches[1]) != 3 {
+		t.Errorf("got batches %v, want a second batch for all three users", batches)
+	}
+}
@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// loadersKey is the context key a request's loaders are stored under
+type loadersKey struct{}
+
+// ordersKey asks for a user's latest orders
+type ordersKey struct {
+	userID int
+	limit  int
+}
+
+// loaders holds the batched lookups of one request, so nested fields of a list cost
+// one query per field rather than one per element
+type loaders struct {
+	items         *loader[int, models.Item]
+	ratings       *loader[int, models.RatingSummary]
+	coOrders      *loader[int, []models.Recommendation]
+	categories    *loader[string, models.Category]
+	categoryItems *loader[string, []models.Item]
+	profiles      *loader[int, models.UserProfile]
+	orders        *loader[ordersKey, []models.Order]
+
+	// Orders are keyed by limit, which is only known once an orders field is resolved,
+	// so listed users are kept until then and primed once per limit
+	mu           sync.Mutex
+	listedUsers  []int
+	primedLimits map[int]bool
+}
+
+// newLoaders creates the loaders of one request
+func newLoaders(service *services.RecommendationService) *loaders {
+	return &loaders{
+		items: newLoader(service.GetItemsByIDs),
+		ratings: newLoader(func(ctx context.Context, _ []int) (map[int]models.RatingSummary, error) {
+			// Summaries are aggregated across the whole menu, so they are read in one go
+			return service.GetRatingSummaries(ctx)
+		}),
+		coOrders: newLoader(service.GetGlobalCoOrderedItemsFor),
+		categories: newLoader(func(ctx context.Context, _ []string) (map[string]models.Category, error) {
+			categories, err := service.ListCategories(ctx)
+			if err != nil {
+				return nil, err
+			}
+			byName := make(map[string]models.Category, len(categories))
+			for _, category := range categories {
+				byName[category.Name] = category
+			}
+			return byName, nil
+		}),
+		categoryItems: newLoader(service.GetItemsByCategories),
+		profiles:      newLoader(service.GetUserProfiles),
+		orders: newLoader(func(ctx context.Context, keys []ordersKey) (map[ordersKey][]models.Order, error) {
+			// Different limits are rare within one query; each gets its own batch
+			byLimit := map[int][]int{}
+			for _, key := range keys {
+				byLimit[key.limit] = append(byLimit[key.limit], key.userID)
+			}
+
+			orders := make(map[ordersKey][]models.Order, len(keys))
+			for limit, userIDs := range byLimit {
+				byUser, err := service.GetRecentOrders(ctx, userIDs, limit)
+				if err != nil {
+					return nil, err
+				}
+				for _, userID := range userIDs {
+					orders[ordersKey{userID, limit}] = byUser[userID]
+				}
+			}
+			return orders, nil
+		}),
+		primedLimits: map[int]bool{},
+	}
+}
+
+// withLoaders attaches fresh loaders to a request's context
+func withLoaders(ctx context.Context, service *services.RecommendationService) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(service))
+}
+
+// loadersFrom returns the loaders of the current request
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// primeUsers queues every per-user lookup for a list of users
+func (l *loaders) primeUsers(users []models.User) {
+	ids := make([]int, len(users))
+	for i, user := range users {
+		ids[i] = user.DbID
+	}
+	l.profiles.prime(ids...)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listedUsers = append(l.listedUsers, ids...)
+	clear(l.primedLimits)
+}
+
+// loadOrders returns a user's latest orders, fetching them for every listed user at once
+func (l *loaders) loadOrders(ctx context.Context, userID, limit int) ([]models.Order, error) {
+	l.mu.Lock()
+	if !l.primedLimits[limit] {
+		l.primedLimits[limit] = true
+		keys := make([]ordersKey, len(l.listedUsers))
+		for i, id := range l.listedUsers {
+			keys[i] = ordersKey{userID: id, limit: limit}
+		}
+		l.orders.prime(keys...)
+	}
+	l.mu.Unlock()
+
+	orders, _, err := l.orders.load(ctx, ordersKey{userID: userID, limit: limit})
+	return orders, err
+}
+
+// primeItems queues every per-item lookup for a list of items
+func (l *loaders) primeItems(items []models.Item) {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.DbID
+	}
+	l.ratings.prime(ids...)
+	l.coOrders.prime(ids...)
+}
@@ -0,0 +1,420 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// queryResolver resolves the root Query type
+type queryResolver struct {
+	service *services.RecommendationService
+}
+
+// Items lists the available menu items, optionally of one category
+func (r *queryResolver) Items(ctx context.Context, args struct{ Category *string }) ([]*itemResolver, error) {
+	var items []models.Item
+	var err error
+	if args.Category == nil {
+		items, err = r.service.GetAllItems(ctx)
+	} else {
+		items, err = r.service.GetItemsByCategory(ctx, *args.Category)
+	}
+	if err != nil {
+		return nil, serviceError(err, "Failed to get items")
+	}
+	return itemResolvers(ctx, items), nil
+}
+
+// Item gets one menu item
+func (r *queryResolver) Item(ctx context.Context, args struct{ ID int32 }) (*itemResolver, error) {
+	item, ok, err := loadersFrom(ctx).items.load(ctx, int(args.ID))
+	if err != nil {
+		return nil, serviceError(err, "Failed to get item")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &itemResolver{item: item}, nil
+}
+
+// Categories lists every menu category
+func (r *queryResolver) Categories(ctx context.Context) ([]*categoryResolver, error) {
+	categories, err := r.service.ListCategories(ctx)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get categories")
+	}
+
+	l := loadersFrom(ctx)
+	resolvers := make([]*categoryResolver, len(categories))
+	for i, category := range categories {
+		l.categoryItems.prime(category.Name)
+		resolvers[i] = &categoryResolver{category: category}
+	}
+	return resolvers, nil
+}
+
+// Users lists the registered users
+func (r *queryResolver) Users(ctx context.Context) ([]*userResolver, error) {
+	users, err := r.service.GetAllUsers(ctx)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get users")
+	}
+
+	loadersFrom(ctx).primeUsers(users)
+	resolvers := make([]*userResolver, len(users))
+	for i, user := range users {
+		resolvers[i] = &userResolver{service: r.service, user: user}
+	}
+	return resolvers, nil
+}
+
+// User gets one user
+func (r *queryResolver) User(ctx context.Context, args struct{ ID int32 }) (*userResolver, error) {
+	profile, ok, err := loadersFrom(ctx).profiles.load(ctx, int(args.ID))
+	if err != nil {
+		return nil, serviceError(err, "Failed to process user")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &userResolver{service: r.service, user: profile.User}, nil
+}
+
+// Trending recommends the items trending over the last days
+func (r *queryResolver) Trending(ctx context.Context, args struct {
+	Days   int32
+	UserID *int32
+}) ([]*recommendationResolver, error) {
+	if args.Days < 1 || args.Days > 365 {
+		return nil, invalidArgument("days", "must be between 1 and 365")
+	}
+
+	recommendations, err := r.service.GetTimeBasedTrendingItems(ctx, int(args.Days))
+	if err != nil {
+		return nil, serviceError(err, "Failed to get recommendations")
+	}
+	if args.UserID != nil {
+		if err := r.service.RequireUser(ctx, int(*args.UserID)); err != nil {
+			return nil, serviceError(err, "Failed to get recommendations")
+		}
+		recommendations, err = r.service.FilterSuppressed(ctx, int(*args.UserID), recommendations)
+		if err != nil {
+			return nil, serviceError(err, "Failed to get recommendations")
+		}
+	}
+	return recommendationResolvers(ctx, recommendations), nil
+}
+
+// itemResolver resolves a menu item
+type itemResolver struct {
+	item models.Item
+}
+
+// itemResolvers resolves a list of items, priming their nested lookups
+func itemResolvers(ctx context.Context, items []models.Item) []*itemResolver {
+	loadersFrom(ctx).primeItems(items)
+	resolvers := make([]*itemResolver, len(items))
+	for i, item := range items {
+		resolvers[i] = &itemResolver{item: item}
+	}
+	return resolvers
+}
+
+func (r *itemResolver) ID() int32      { return int32(r.item.DbID) }
+func (r *itemResolver) Name() string   { return r.item.Name }
+func (r *itemResolver) Price() float64 { return r.item.Price }
+func (r *itemResolver) Description() *string {
+	return optionalString(r.item.Description)
+}
+
+// Category resolves the item's category
+func (r *itemResolver) Category(ctx context.Context) (*categoryResolver, error) {
+	if r.item.Category == "" {
+		return nil, nil
+	}
+	category, ok, err := loadersFrom(ctx).categories.load(ctx, r.item.Category)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get categories")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &categoryResolver{category: category}, nil
+}
+
+// Ratings resolves the item's rating summary
+func (r *itemResolver) Ratings(ctx context.Context) (*ratingSummaryResolver, error) {
+	if r.item.Ratings != nil {
+		return &ratingSummaryResolver{summary: *r.item.Ratings}, nil
+	}
+	summary, ok, err := loadersFrom(ctx).ratings.load(ctx, r.item.DbID)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get ratings")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &ratingSummaryResolver{summary: summary}, nil
+}
+
+// AlsoOrderedWith resolves the items all users frequently order with this one
+func (r *itemResolver) AlsoOrderedWith(ctx context.Context, args struct{ Limit int32 }) ([]*recommendationResolver, error) {
+	if args.Limit < 1 || args.Limit > 50 {
+		return nil, invalidArgument("limit", "must be between 1 and 50")
+	}
+	recommendations, _, err := loadersFrom(ctx).coOrders.load(ctx, r.item.DbID)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get recommendations")
+	}
+	if len(recommendations) > int(args.Limit) {
+		recommendations = recommendations[:args.Limit]
+	}
+	return recommendationResolvers(ctx, recommendations), nil
+}
+
+// ratingSummaryResolver resolves an item's rating aggregates
+type ratingSummaryResolver struct {
+	summary models.RatingSummary
+}
+
+func (r *ratingSummaryResolver) Mean() float64            { return r.summary.Mean }
+func (r *ratingSummaryResolver) Count() int32             { return int32(r.summary.Count) }
+func (r *ratingSummaryResolver) BayesianAverage() float64 { return r.summary.BayesianAverage }
+
+// categoryResolver resolves a menu category
+type categoryResolver struct {
+	category models.Category
+}
+
+func (r *categoryResolver) Name() string              { return r.category.Name }
+func (r *categoryResolver) Description() *string      { return optionalString(r.category.Description) }
+func (r *categoryResolver) ItemCount() int32          { return int32(r.category.Items) }
+func (r *categoryResolver) AvailableItemCount() int32 { return int32(r.category.AvailableItems) }
+
+// Items resolves the available items of the category
+func (r *categoryResolver) Items(ctx context.Context) ([]*itemResolver, error) {
+	items, _, err := loadersFrom(ctx).categoryItems.load(ctx, r.category.Name)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get items")
+	}
+	return itemResolvers(ctx, items), nil
+}
+
+// userResolver resolves a user
+type userResolver struct {
+	service *services.RecommendationService
+	user    models.User
+}
+
+func (r *userResolver) ID() int32     { return int32(r.user.DbID) }
+func (r *userResolver) Name() string  { return r.user.Name }
+func (r *userResolver) Email() string { return r.user.Email }
+func (r *userResolver) CreatedAt() *graphql.Time {
+	return optionalTime(r.user.CreatedAt)
+}
+
+// Stats resolves the user's order summary
+func (r *userResolver) Stats(ctx context.Context) (*userStatsResolver, error) {
+	profile, ok, err := loadersFrom(ctx).profiles.load(ctx, r.user.DbID)
+	if err != nil {
+		return nil, serviceError(err, "Failed to process user")
+	}
+	if !ok {
+		return nil, serviceError(services.ErrUserNotFound, "Failed to process user")
+	}
+	return &userStatsResolver{stats: profile.Stats}, nil
+}
+
+// Orders resolves the user's latest orders
+func (r *userResolver) Orders(ctx context.Context, args struct{ Limit int32 }) ([]*orderResolver, error) {
+	if args.Limit < 1 || args.Limit > 50 {
+		return nil, invalidArgument("limit", "must be between 1 and 50")
+	}
+	l := loadersFrom(ctx)
+	orders, err := l.loadOrders(ctx, r.user.DbID, int(args.Limit))
+	if err != nil {
+		return nil, serviceError(err, "Failed to process user")
+	}
+
+	resolvers := make([]*orderResolver, len(orders))
+	for i, order := range orders {
+		for _, line := range order.Items {
+			l.items.prime(line.ItemID)
+		}
+		resolvers[i] = &orderResolver{order: order}
+	}
+	return resolvers, nil
+}
+
+// Recommendations resolves the user's hybrid recommendations
+func (r *userResolver) Recommendations(ctx context.Context, args struct {
+	ItemInCart *int32
+	Mode       *string
+	Diversity  float64
+	MixRatio   float64
+}) (*hybridResolver, error) {
+	if args.Diversity < 0 || args.Diversity > 1 {
+		return nil, invalidArgument("diversity", "must be between 0 and 1")
+	}
+	if args.MixRatio < 0 || args.MixRatio > 1 {
+		return nil, invalidArgument("mixRatio", "must be between 0 and 1")
+	}
+
+	query := services.HybridQuery{
+		UserID:         r.user.DbID,
+		ExperimentUnit: requestFrom(ctx).SessionID,
+		Diversity:      services.DiversityOptions{Diversity: args.Diversity},
+		MixRatio:       args.MixRatio,
+	}
+	if args.Mode != nil {
+		switch mode := services.RecommendationMode(*args.Mode); mode {
+		case services.ModeReorder, services.ModeExplore, services.ModeMixed:
+			query.Mode = mode
+		default:
+			return nil, invalidArgument("mode", "must be one of reorder, explore, mixed")
+		}
+	}
+	if args.ItemInCart != nil {
+		itemID := int(*args.ItemInCart)
+		if _, ok, err := loadersFrom(ctx).items.load(ctx, itemID); err != nil {
+			return nil, serviceError(err, "Failed to get item")
+		} else if !ok {
+			return nil, invalidArgument("itemInCart", "does not exist")
+		}
+		query.ItemInCart = &itemID
+	}
+
+	result, err := r.service.RecommendHybrid(ctx, query)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get recommendations")
+	}
+	return &hybridResolver{result: result}, nil
+}
+
+// userStatsResolver resolves a user's order summary
+type userStatsResolver struct {
+	stats models.UserStats
+}
+
+func (r *userStatsResolver) OrderCount() int32      { return int32(r.stats.OrderCount) }
+func (r *userStatsResolver) LifetimeSpend() float64 { return r.stats.LifetimeSpend }
+func (r *userStatsResolver) FavouriteCategory() *string {
+	return optionalString(r.stats.FavouriteCategory)
+}
+func (r *userStatsResolver) LastOrderAt() *graphql.Time {
+	if r.stats.LastOrderAt == nil {
+		return nil
+	}
+	return optionalTime(*r.stats.LastOrderAt)
+}
+
+// orderResolver resolves an order
+type orderResolver struct {
+	order models.Order
+}
+
+func (r *orderResolver) ID() int32 { return int32(r.order.DbID) }
+func (r *orderResolver) CreatedAt() *graphql.Time {
+	return optionalTime(r.order.CreatedAt)
+}
+func (r *orderResolver) TotalAmount() float64 { return r.order.TotalAmount }
+func (r *orderResolver) Status() string       { return r.order.Status }
+
+// Lines resolves the items of the order
+func (r *orderResolver) Lines() []*orderLineResolver {
+	resolvers := make([]*orderLineResolver, len(r.order.Items))
+	for i, line := range r.order.Items {
+		resolvers[i] = &orderLineResolver{line: line}
+	}
+	return resolvers
+}
+
+// orderLineResolver resolves one item of an order
+type orderLineResolver struct {
+	line models.OrderItem
+}
+
+func (r *orderLineResolver) Quantity() int32 { return int32(r.line.Quantity) }
+
+// Item resolves the ordered item
+func (r *orderLineResolver) Item(ctx context.Context) (*itemResolver, error) {
+	item, ok, err := loadersFrom(ctx).items.load(ctx, r.line.ItemID)
+	if err != nil {
+		return nil, serviceError(err, "Failed to get item")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &itemResolver{item: item}, nil
+}
+
+// recommendationResolver resolves a recommended item
+type recommendationResolver struct {
+	rec  models.Recommendation
+	item *itemResolver
+}
+
+// recommendationResolvers resolves a list of recommendations, priming the nested
+// lookups of their items
+func recommendationResolvers(ctx context.Context, recommendations []models.Recommendation) []*recommendationResolver {
+	items := make([]models.Item, len(recommendations))
+	for i, rec := range recommendations {
+		items[i] = rec.Item
+	}
+	itemResolvers := itemResolvers(ctx, items)
+
+	resolvers := make([]*recommendationResolver, len(recommendations))
+	for i, rec := range recommendations {
+		resolvers[i] = &recommendationResolver{rec: rec, item: itemResolvers[i]}
+	}
+	return resolvers
+}
+
+func (r *recommendationResolver) Item() *itemResolver { return r.item }
+func (r *recommendationResolver) Score() float64      { return r.rec.Score }
+func (r *recommendationResolver) Explanation() string { return r.rec.Explanation }
+func (r *recommendationResolver) Strategy() string    { return r.rec.Strategy }
+
+// hybridResolver resolves hybrid recommendations with the settings that produced them
+type hybridResolver struct {
+	result services.HybridResult
+}
+
+// RequestID resolves the ID feedback events quote
+func (r *hybridResolver) RequestID(ctx context.Context) string {
+	return requestFrom(ctx).RequestID
+}
+
+func (r *hybridResolver) Segment() string { return r.result.Segment }
+
+// Profile resolves the name of the weight profile used, if any
+func (r *hybridResolver) Profile() *string {
+	if r.result.Profile == nil {
+		return nil
+	}
+	return &r.result.Profile.Name
+}
+
+// Experiment resolves the experiment that produced the results, if any
+func (r *hybridResolver) Experiment() *string {
+	if r.result.Experiment == nil {
+		return nil
+	}
+	return &r.result.Experiment.Experiment
+}
+
+// Variant resolves the experiment variant that produced the results, if any
+func (r *hybridResolver) Variant() *string {
+	if r.result.Experiment == nil {
+		return nil
+	}
+	return &r.result.Experiment.Variant
+}
+
+// Recommendations resolves the recommended items
+func (r *hybridResolver) Recommendations(ctx context.Context) []*recommendationResolver {
+	return recommendationResolvers(ctx, r.result.Recommendations)
+}
@@ -0,0 +1,78 @@
+// Package graph serves the menu, guests and recommendations as one GraphQL schema, so a
+// screen that needs several of them can ask in a single request. Resolvers are backed
+// by RecommendationService; nested fields of lists are batched per request (see loader).
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth caps how deeply a query may nest, so one request cannot fan out without bound
+const maxDepth = 8
+
+// Schema executes GraphQL queries against the recommendation service
+type Schema struct {
+	schema  *graphql.Schema
+	service *services.RecommendationService
+}
+
+// Request is one GraphQL request with the HTTP context its resolvers need
+type Request struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	// RequestID is quoted in feedback events about the recommendations returned
+	RequestID string
+	// SessionID keeps experiment variants stable for anonymous callers; the user ID is used when empty
+	SessionID string
+}
+
+// requestKey is the context key the current request is stored under
+type requestKey struct{}
+
+// NewSchema parses the schema; it panics if the schema and resolvers disagree, which
+// is a programming error caught at startup
+func NewSchema(service *services.RecommendationService) *Schema {
+	schema := graphql.MustParseSchema(schemaSDL, &queryResolver{service: service},
+		graphql.MaxDepth(maxDepth),
+		graphql.UseStringDescriptions(),
+	)
+	return &Schema{schema: schema, service: service}
+}
+
+// Exec runs one request with its own loaders
+func (s *Schema) Exec(ctx context.Context, req Request) *graphql.Response {
+	ctx = context.WithValue(ctx, requestKey{}, req)
+	ctx = withLoaders(ctx, s.service)
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+// requestFrom returns the request being executed
+func requestFrom(ctx context.Context) Request {
+	req, _ := ctx.Value(requestKey{}).(Request)
+	return req
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalTime returns nil for the zero time
+func optionalTime(t time.Time) *graphql.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: t}
+}
@@ -0,0 +1,143 @@
+package grpcserver
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultCartRefresh is how often a cart stream looks for changed recommendations
+	defaultCartRefresh = 30 * time.Second
+	// maxCartItems caps the cart a stream accepts
+	maxCartItems = 50
+)
+
+// StreamCartRecommendations sends hybrid recommendations for a cart, then again
+// whenever the refreshed recommendations differ, until the caller cancels or the
+// server shuts down. The last cart item drives co-order suggestions and no cart item
+// is recommended. Refreshes reuse the weights of the first answer, so the guest keeps
+// their experiment variant without being counted as a new exposure each time.
+func (s *Server) StreamCartRecommendations(req *restaurantv1.CartRecommendationsRequest, stream grpc.ServerStreamingServer[restaurantv1.HybridResponse]) error {
+	ctx := stream.Context()
+
+	refresh := defaultCartRefresh
+	if req.GetRefreshSeconds() != 0 {
+		refresh = time.Duration(req.GetRefreshSeconds()) * time.Second
+	}
+
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	if len(req.GetCartItemIds()) > maxCartItems {
+		violations.add("cart_item_ids", fmt.Sprintf("must have at most %d entries", maxCartItems))
+	}
+	for i, id := range req.GetCartItemIds() {
+		violations.atLeast(fmt.Sprintf("cart_item_ids[%d]", i), float64(id), 1)
+	}
+	violations.between("refresh_seconds", refresh.Seconds(), 5, 600)
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return err
+	}
+	userID := int(req.GetUserId())
+	if err := s.recommendationService.RequireUser(ctx, userID); err != nil {
+		return statusError(err, "Failed to get recommendations")
+	}
+
+	cart := make([]models.Item, 0, len(req.GetCartItemIds()))
+	cartIDs := make([]int, 0, len(req.GetCartItemIds()))
+	for i, id := range req.GetCartItemIds() {
+		item, err := s.lookupCartItem(ctx, fmt.Sprintf("cart_item_ids[%d]", i), int(id))
+		if err != nil {
+			return err
+		}
+		cart = append(cart, *item)
+		cartIDs = append(cartIDs, item.DbID)
+	}
+
+	query := services.HybridQuery{
+		UserID:         userID,
+		ExperimentUnit: req.GetSessionId(),
+		Price:          withCart(filter, cart...),
+		MixRatio:       services.DefaultMixRatio,
+		Exclude:        cartIDs,
+	}
+	if len(cartIDs) > 0 {
+		query.ItemInCart = &cartIDs[len(cartIDs)-1]
+	}
+
+	first, err := s.recommendHybrid(ctx, query)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(hybridResponse(ctx, query.ItemInCart, first)); err != nil {
+		return err
+	}
+
+	weights := first.Weights
+	query.Overrides = services.WeightOverrides{
+		UserFrequency:    &weights.UserFrequency,
+		UserCoOrders:     &weights.UserCoOrders,
+		GlobalCoOrders:   &weights.GlobalCoOrders,
+		TimeBasedTrend:   &weights.TimeBasedTrend,
+		PriceSensitivity: &weights.PriceSensitivity,
+		Ratings:          &weights.Ratings,
+	}
+	last := first.Recommendations
+
+	ticker := s.newTicker(refresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.draining:
+			return status.Error(codes.Unavailable, "Server is shutting down")
+		case <-ticker.C:
+		}
+
+		result, err := s.recommendationService.RecommendHybrid(ctx, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			// A busy or briefly unreachable database should not end a long-lived stream
+			if kind := services.KindOf(err); kind == services.KindUnavailable || kind == services.KindTimeout {
+				log.Printf("Failed to refresh cart recommendations: %v", err)
+				continue
+			}
+			return statusError(err, "Failed to get recommendations")
+		}
+		if sameItems(last, result.Recommendations) {
+			continue
+		}
+		last = result.Recommendations
+
+		result.Profile, result.Experiment = first.Profile, first.Experiment
+		if err := stream.Send(hybridResponse(ctx, query.ItemInCart, result)); err != nil {
+			return err
+		}
+	}
+}
+
+// sameItems reports whether two sets of recommendations list the same items in the
+// same order. Scores are ignored: trend scores drift with the clock, which is no
+// reason to send the cart the same recommendations again.
+func sameItems(a, b []models.Recommendation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Item.DbID != b[i].Item.DbID {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recommendations lists the given items with the given score
+func recommendations(score float64, ids ...int) []models.Recommendation {
+	recs := make([]models.Recommendation, len(ids))
+	for i, id := range ids {
+		recs[i] = models.Recommendation{Item: models.Item{DbID: id}, Score: score}
+	}
+	return recs
+}
+
+// itemIDs lists the items of a streamed response
+func itemIDs(resp *restaurantv1.HybridResponse) []int64 {
+	var ids []int64
+	for _, rec := range resp.GetRecommendations() {
+		ids = append(ids, rec.GetItem().GetId())
+	}
+	return ids
+}
+
+func TestSameItems(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []models.Recommendation
+		want bool
+	}{
+		{"both empty", nil, recommendations(1), true},
+		{"scores drifted", recommendations(0.8, 1, 2), recommendations(0.6, 1, 2), true},
+		{"reordered", recommendations(1, 1, 2), recommendations(1, 2, 1), false},
+		{"one more", recommendations(1, 1, 2), recommendations(1, 1, 2, 3), false},
+		{"replaced", recommendations(1, 1, 2), recommendations(1, 1, 3), false},
+	}
+
+	for _, tt := range tests {
+		if got := sameItems(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStreamCartRecommendations(t *testing.T) {
+	weights := models.HybridWeights{UserFrequency: 0.3, Ratings: 0.7}
+	stub := &stubRecommender{answers: []hybridAnswer{
+		{result: services.HybridResult{
+			Segment:         "regular",
+			Profile:         &models.WeightProfile{Name: "regulars", Version: 2},
+			Experiment:      &models.ExperimentAssignment{Experiment: "weights-2026", Variant: "b"},
+			Weights:         weights,
+			Recommendations: recommendations(0.9, 10, 11),
+		}},
+		// The same items with drifted scores are not sent again
+		{result: services.HybridResult{Weights: weights, Recommendations: recommendations(0.7, 10, 11)}},
+		// A busy or unreachable database does not end the stream
+		{err: &neo4j.ConnectivityError{Inner: errors.New("connection refused")}},
+		{err: &neo4j.Neo4jError{Code: "Neo.ClientError.Transaction.TransactionTimedOut"}},
+		{result: services.HybridResult{Weights: weights, Recommendations: recommendations(0.8, 11, 12)}},
+	}}
+	srv, client := startServer(t, stub)
+
+	stream, err := client.StreamCartRecommendations(context.Background(), &restaurantv1.CartRecommendationsRequest{
+		UserId:      4,
+		CartItemIds: []int64{1, 2},
+		SessionId:   "s-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(itemIDs(first), []int64{10, 11}) || first.GetItemInCart() != 2 || first.GetProfile() != "regulars" || first.GetRequestId() == "" {
+		t.Errorf("got first %v", first)
+	}
+
+	second, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(itemIDs(second), []int64{11, 12}) {
+		t.Errorf("got items %v, want [11 12]", itemIDs(second))
+	}
+	// Refreshes keep the first answer's profile and variant, and the stream's request ID
+	if second.GetProfile() != "regulars" || second.GetProfileVersion() != 2 || second.GetExperiment() != "weights-2026" || second.GetVariant() != "b" || second.GetRequestId() != first.GetRequestId() {
+		t.Errorf("got second %v", second)
+	}
+
+	// Let a few unchanged refreshes pass; none of them may be sent
+	deadline := time.Now().Add(5 * time.Second)
+	for len(stub.queried()) < 8 {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d refreshes ran", len(stub.queried()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := srv.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown got %v", err)
+	}
+	_, err = stream.Recv()
+	if st := status.Convert(err); st.Code() != codes.Unavailable || st.Message() != "Server is shutting down" {
+		t.Errorf("after shutdown got %v, want the stream ended as unavailable", err)
+	}
+
+	queries := stub.queried()
+	if q := queries[0]; q.UserID != 4 || q.ExperimentUnit != "s-1" || *q.ItemInCart != 2 || !slices.Equal(q.Exclude, []int{1, 2}) || q.Overrides.Ratings != nil {
+		t.Errorf("got first query %+v", q)
+	}
+	for i, q := range queries[1:] {
+		o := q.Overrides
+		if o.UserFrequency == nil || *o.UserFrequency != 0.3 || o.Ratings == nil || *o.Ratings != 0.7 || o.GlobalCoOrders == nil || *o.GlobalCoOrders != 0 {
+			t.Errorf("refresh %d does not reuse the first answer's weights: %+v", i+1, o)
+		}
+	}
+}
+
+func TestStreamCartRecommendationsEndsOnError(t *testing.T) {
+	stub := &stubRecommender{answers: []hybridAnswer{
+		{result: services.HybridResult{Recommendations: recommendations(1, 10)}},
+		{err: &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}},
+	}}
+	_, client := startServer(t, stub)
+
+	stream, err := client.StreamCartRecommendations(context.Background(), &restaurantv1.CartRecommendationsRequest{UserId: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "Failed to get recommendations" {
+		t.Errorf("got %v, want an internal error", err)
+	}
+}
+
+func TestStreamCartRecommendationsValidates(t *testing.T) {
+	stub := &stubRecommender{answers: []hybridAnswer{{}}}
+	_, client := startServer(t, stub)
+
+	stream, err := client.StreamCartRecommendations(context.Background(), &restaurantv1.CartRecommendationsRequest{
+		UserId:         0,
+		CartItemIds:    []int64{3, 0},
+		RefreshSeconds: 1,
+		Price:          &restaurantv1.PriceFilter{MinPrice: 9, MaxPrice: 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+
+	got := violationsOf(t, err)
+	want := map[string]string{
+		"user_id":          "must be at least 1",
+		"cart_item_ids[1]": "must be at least 1",
+		"refresh_seconds":  "must be at least 5",
+		"price.max_price":  "must be at least price.min_price",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for field, description := range want {
+		if got[field] != description {
+			t.Errorf("%s: got %q, want %q", field, got[field], description)
+		}
+	}
+	if len(stub.queried()) != 0 {
+		t.Error("an invalid request reached the service")
+	}
+}
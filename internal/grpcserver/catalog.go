@@ -0,0 +1,129 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+)
+
+// ListItems lists the available menu items, optionally of one category
+func (s *Server) ListItems(ctx context.Context, req *restaurantv1.ListItemsRequest) (*restaurantv1.ListItemsResponse, error) {
+	if len(req.GetCategory()) > 100 {
+		return nil, invalidField("category", "must be at most 100 characters")
+	}
+
+	var items []models.Item
+	var err error
+	if req.GetCategory() == "" {
+		items, err = s.recommendationService.GetAllItems(ctx)
+	} else {
+		items, err = s.recommendationService.GetItemsByCategory(ctx, req.GetCategory())
+	}
+	if err != nil {
+		return nil, statusError(err, "Failed to get items")
+	}
+	if err := s.recommendationService.AttachRatingSummaries(ctx, items); err != nil {
+		return nil, statusError(err, "Failed to get items")
+	}
+
+	return &restaurantv1.ListItemsResponse{Items: toItems(items)}, nil
+}
+
+// GetItem gets one menu item with its rating summary
+func (s *Server) GetItem(ctx context.Context, req *restaurantv1.GetItemRequest) (*restaurantv1.Item, error) {
+	if req.GetItemId() < 1 {
+		return nil, invalidField("item_id", "must be at least 1")
+	}
+
+	item, err := s.requireItem(ctx, int(req.GetItemId()))
+	if err != nil {
+		return nil, err
+	}
+	items := []models.Item{*item}
+	if err := s.recommendationService.AttachRatingSummaries(ctx, items); err != nil {
+		return nil, statusError(err, "Failed to get item")
+	}
+
+	return toItem(items[0]), nil
+}
+
+// ListUsers lists the registered users
+func (s *Server) ListUsers(ctx context.Context, _ *restaurantv1.ListUsersRequest) (*restaurantv1.ListUsersResponse, error) {
+	users, err := s.recommendationService.GetAllUsers(ctx)
+	if err != nil {
+		return nil, statusError(err, "Failed to get users")
+	}
+
+	pb := make([]*restaurantv1.User, len(users))
+	for i, user := range users {
+		pb[i] = toUser(user)
+	}
+	return &restaurantv1.ListUsersResponse{Users: pb}, nil
+}
+
+// GetUserProfile gets a user with their order summary
+func (s *Server) GetUserProfile(ctx context.Context, req *restaurantv1.GetUserProfileRequest) (*restaurantv1.UserProfile, error) {
+	if req.GetUserId() < 1 {
+		return nil, invalidField("user_id", "must be at least 1")
+	}
+
+	profile, err := s.recommendationService.GetUserProfile(ctx, int(req.GetUserId()))
+	if err != nil {
+		return nil, statusError(err, "Failed to process user")
+	}
+
+	return toUserProfile(profile), nil
+}
+
+// CreateUser registers a user; the service checks the email and that it is not taken
+func (s *Server) CreateUser(ctx context.Context, req *restaurantv1.CreateUserRequest) (*restaurantv1.User, error) {
+	var violations fieldViolations
+	switch {
+	case req.GetName() == "":
+		violations.add("name", "is required")
+	case len(req.GetName()) > 100:
+		violations.add("name", "must be at most 100 characters")
+	}
+	if req.GetEmail() == "" {
+		violations.add("email", "is required")
+	}
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.recommendationService.CreateUser(ctx, models.User{
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+	})
+	if err != nil {
+		return nil, statusError(err, "Failed to process user")
+	}
+
+	return toUser(user), nil
+}
+
+// requireItem looks up an item, answering NOT_FOUND when it does not exist
+func (s *Server) requireItem(ctx context.Context, itemID int) (*models.Item, error) {
+	item, err := s.recommendationService.GetItemByID(ctx, itemID)
+	if err == nil && item == nil {
+		err = services.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, statusError(err, "Failed to get item")
+	}
+	return item, nil
+}
+
+// lookupCartItem looks up an item in the cart; one that does not exist is an invalid argument
+func (s *Server) lookupCartItem(ctx context.Context, field string, itemID int) (*models.Item, error) {
+	item, err := s.recommendationService.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, statusError(err, "Failed to get item")
+	}
+	if item == nil {
+		return nil, invalidField(field, "does not exist")
+	}
+	return item, nil
+}
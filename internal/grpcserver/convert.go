@@ -0,0 +1,162 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toItem converts a menu item
+func toItem(item models.Item) *restaurantv1.Item {
+	pb := &restaurantv1.Item{
+		Id:          int64(item.DbID),
+		Name:        item.Name,
+		Price:       item.Price,
+		Category:    item.Category,
+		Description: item.Description,
+	}
+	if item.Ratings != nil {
+		pb.Ratings = &restaurantv1.RatingSummary{
+			Mean:            item.Ratings.Mean,
+			Count:           int64(item.Ratings.Count),
+			BayesianAverage: item.Ratings.BayesianAverage,
+		}
+	}
+	return pb
+}
+
+// toItems converts a list of menu items
+func toItems(items []models.Item) []*restaurantv1.Item {
+	pb := make([]*restaurantv1.Item, len(items))
+	for i, item := range items {
+		pb[i] = toItem(item)
+	}
+	return pb
+}
+
+// toUser converts a user
+func toUser(user models.User) *restaurantv1.User {
+	return &restaurantv1.User{
+		Id:        int64(user.DbID),
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: toTimestamp(user.CreatedAt),
+	}
+}
+
+// toUserProfile converts a user with their order summary
+func toUserProfile(profile models.UserProfile) *restaurantv1.UserProfile {
+	stats := &restaurantv1.UserStats{
+		OrderCount:        int64(profile.Stats.OrderCount),
+		LifetimeSpend:     profile.Stats.LifetimeSpend,
+		FavouriteCategory: profile.Stats.FavouriteCategory,
+	}
+	if profile.Stats.LastOrderAt != nil {
+		stats.LastOrderAt = toTimestamp(*profile.Stats.LastOrderAt)
+	}
+	return &restaurantv1.UserProfile{
+		User:  toUser(profile.User),
+		Stats: stats,
+	}
+}
+
+// toRecommendations converts a list of recommendations
+func toRecommendations(recommendations []models.Recommendation) []*restaurantv1.Recommendation {
+	pb := make([]*restaurantv1.Recommendation, len(recommendations))
+	for i, rec := range recommendations {
+		pb[i] = &restaurantv1.Recommendation{
+			Item:        toItem(rec.Item),
+			Score:       rec.Score,
+			Explanation: rec.Explanation,
+			Strategy:    rec.Strategy,
+		}
+	}
+	return pb
+}
+
+// toWeights converts hybrid weights
+func toWeights(weights models.HybridWeights) *restaurantv1.HybridWeights {
+	return &restaurantv1.HybridWeights{
+		UserFrequency:    weights.UserFrequency,
+		UserCoOrders:     weights.UserCoOrders,
+		GlobalCoOrders:   weights.GlobalCoOrders,
+		TimeBasedTrend:   weights.TimeBasedTrend,
+		PriceSensitivity: weights.PriceSensitivity,
+		Ratings:          weights.Ratings,
+	}
+}
+
+// toBundles converts a list of complete-the-meal bundles
+func toBundles(bundles []models.Bundle) []*restaurantv1.Bundle {
+	pb := make([]*restaurantv1.Bundle, len(bundles))
+	for i, bundle := range bundles {
+		pb[i] = &restaurantv1.Bundle{
+			Items:       toItems(bundle.Items),
+			Roles:       bundle.Roles,
+			TotalPrice:  bundle.TotalPrice,
+			Score:       bundle.Score,
+			Explanation: bundle.Explanation,
+		}
+	}
+	return pb
+}
+
+// toBaskets converts a list of reorderable baskets
+func toBaskets(baskets []models.Basket) []*restaurantv1.Basket {
+	pb := make([]*restaurantv1.Basket, len(baskets))
+	for i, basket := range baskets {
+		orderIDs := make([]int64, len(basket.OrderIDs))
+		for j, id := range basket.OrderIDs {
+			orderIDs[j] = int64(id)
+		}
+		lines := make([]*restaurantv1.BasketLine, len(basket.Lines))
+		for j, line := range basket.Lines {
+			lines[j] = &restaurantv1.BasketLine{
+				Item:      toItem(line.Item),
+				Quantity:  int64(line.Quantity),
+				Available: line.Available,
+				LineTotal: line.LineTotal,
+			}
+		}
+		pb[i] = &restaurantv1.Basket{
+			OrderIds:      orderIDs,
+			Lines:         lines,
+			LastOrderedAt: toTimestamp(basket.LastOrderedAt),
+			TimesOrdered:  int64(basket.TimesOrdered),
+			OriginalTotal: basket.OriginalTotal,
+			CurrentTotal:  basket.CurrentTotal,
+			AllAvailable:  basket.AllAvailable,
+			Weekday:       basket.Weekday,
+			Explanation:   basket.Explanation,
+		}
+	}
+	return pb
+}
+
+// toTimestamp converts a time, leaving the zero time unset
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// optionalID converts an optional ID from the wire
+func optionalID(id *int64) *int {
+	if id == nil {
+		return nil
+	}
+	value := int(*id)
+	return &value
+}
+
+// wireID converts an optional ID for the wire
+func wireID(id *int) *int64 {
+	if id == nil {
+		return nil
+	}
+	value := int64(*id)
+	return &value
+}
@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codeByKind maps service error kinds onto gRPC status codes
+var codeByKind = map[services.ErrorKind]codes.Code{
+	services.KindInvalidArgument: codes.InvalidArgument,
+	services.KindNotFound:        codes.NotFound,
+	services.KindConflict:        codes.AlreadyExists,
+	services.KindUnsupported:     codes.Unimplemented,
+	services.KindUnavailable:     codes.Unavailable,
+	services.KindTimeout:         codes.DeadlineExceeded,
+	services.KindInternal:        codes.Internal,
+}
+
+// statusError turns an error returned by a service into a gRPC status, as
+// respondError does for the REST API: errors the caller can fix carry the service's
+// message, while unavailable, timeout and internal errors are logged and answered
+// with the given message instead.
+func statusError(err error, message string) error {
+	code, ok := codeByKind[services.KindOf(err)]
+	if !ok {
+		code = codes.Internal
+	}
+
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal:
+		log.Printf("%s: %v", message, err)
+	default:
+		message = err.Error()
+	}
+	return status.Error(code, message)
+}
+
+// fieldViolations collects the request fields that fail validation
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+// add rejects a field
+func (v *fieldViolations) add(field, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+// atLeast rejects a field below min
+func (v *fieldViolations) atLeast(field string, value, min float64) {
+	if value < min {
+		v.add(field, fmt.Sprintf("must be at least %v", min))
+	}
+}
+
+// between rejects a field outside [min, max]
+func (v *fieldViolations) between(field string, value, min, max float64) {
+	switch {
+	case value < min:
+		v.add(field, fmt.Sprintf("must be at least %v", min))
+	case value > max:
+		v.add(field, fmt.Sprintf("must be at most %v", max))
+	}
+}
+
+// err answers INVALID_ARGUMENT with a BadRequest detail listing the rejected fields,
+// or returns nil when every field passed
+func (v fieldViolations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	st := status.New(codes.InvalidArgument, "Request validation failed")
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// invalidField answers INVALID_ARGUMENT for a single rejected field
+func invalidField(field, description string) error {
+	var v fieldViolations
+	v.add(field, description)
+	return v.err()
+}
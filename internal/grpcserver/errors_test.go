@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	const generic = "Failed to get recommendations"
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"not found", services.ErrUserNotFound, codes.NotFound, "user not found"},
+		{"invalid", fmt.Errorf("%w: stars must be between 1 and 5", services.ErrInvalidRating), codes.InvalidArgument, "invalid rating: stars must be between 1 and 5"},
+		{"conflict", services.ErrEmailTaken, codes.AlreadyExists, "email is already in use"},
+		{"unsupported", services.ErrStatsUnsupported, codes.Unimplemented, "event sink does not support statistics"},
+		{"timeout", fmt.Errorf("failed to query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, generic},
+		{"unavailable", &neo4j.ConnectivityError{Inner: errors.New("dial tcp 10.0.0.7:7687: connection refused")}, codes.Unavailable, generic},
+		{"internal", &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError", Msg: "Invalid input 'MATCH'"}, codes.Internal, generic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusError(tt.err, generic))
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Errorf("got %s %q, want %s %q", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCodeByKindCoversEveryKind(t *testing.T) {
+	kinds := []services.ErrorKind{
+		services.KindInvalidArgument,
+		services.KindNotFound,
+		services.KindConflict,
+		services.KindUnsupported,
+		services.KindUnavailable,
+		services.KindTimeout,
+		services.KindInternal,
+	}
+	for _, kind := range kinds {
+		if _, ok := codeByKind[kind]; !ok {
+			t.Errorf("kind %q has no code", kind)
+		}
+	}
+}
+
+// violationsOf returns the field violations carried by an INVALID_ARGUMENT error
+func violationsOf(t *testing.T, err error) map[string]string {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %s %q, want InvalidArgument", st.Code(), st.Message())
+	}
+	got := map[string]string{}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.GetFieldViolations() {
+				got[v.GetField()] = v.GetDescription()
+			}
+		}
+	}
+	return got
+}
+
+func TestFieldViolations(t *testing.T) {
+	var violations fieldViolations
+	if err := violations.err(); err != nil {
+		t.Fatalf("no violations gave %v", err)
+	}
+
+	violations.atLeast("user_id", 0, 1)
+	violations.atLeast("days", 3, 1)
+	violations.between("diversity", 1.5, 0, 1)
+	violations.between("mix_ratio", -0.1, 0, 1)
+	violations.between("refresh_seconds", 30, 5, 600)
+
+	got := violationsOf(t, violations.err())
+	want := map[string]string{
+		"user_id":   "must be at least 1",
+		"diversity": "must be at most 1",
+		"mix_ratio": "must be at least 0",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for field, description := range want {
+		if got[field] != description {
+			t.Errorf("%s: got %q, want %q", field, got[field], description)
+		}
+	}
+}
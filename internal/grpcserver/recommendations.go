@@ -0,0 +1,419 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+)
+
+// GetUserFrequentItems recommends the items a user orders most frequently
+func (s *Server) GetUserFrequentItems(ctx context.Context, req *restaurantv1.UserRecommendationsRequest) (*restaurantv1.RecommendationsResponse, error) {
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	userID := int(req.GetUserId())
+	if err := s.recommendationService.RequireUser(ctx, userID); err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+
+	recommendations, err := s.recommendationService.GetUserFrequentItems(ctx, userID)
+	if err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	recommendations = s.recommendationService.FilterRecommendationsByPrice(recommendations, filter)
+
+	return recommendationsResponse(ctx, "UserFrequency", "Items you order most frequently", nil, recommendations), nil
+}
+
+// GetUserCoOrderedItems recommends items a user frequently orders with a specific item
+func (s *Server) GetUserCoOrderedItems(ctx context.Context, req *restaurantv1.UserCoOrdersRequest) (*restaurantv1.RecommendationsResponse, error) {
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	violations.atLeast("item_id", float64(req.GetItemId()), 1)
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	userID, itemID := int(req.GetUserId()), int(req.GetItemId())
+	if err := s.recommendationService.RequireUser(ctx, userID); err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	item, err := s.requireItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	filter = withCart(filter, *item)
+
+	recommendations, err := s.recommendationService.GetUserCoOrderedItems(ctx, userID, itemID)
+	if err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	recommendations = s.recommendationService.FilterRecommendationsByPrice(recommendations, filter)
+
+	return recommendationsResponse(ctx, "UserCoOrders", "Items you frequently order with this item", &itemID, recommendations), nil
+}
+
+// GetGlobalCoOrderedItems recommends items frequently ordered with a specific item by all users
+func (s *Server) GetGlobalCoOrderedItems(ctx context.Context, req *restaurantv1.GlobalCoOrdersRequest) (*restaurantv1.RecommendationsResponse, error) {
+	var violations fieldViolations
+	violations.atLeast("item_id", float64(req.GetItemId()), 1)
+	if req.UserId != nil {
+		violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	}
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	itemID := int(req.GetItemId())
+	item, err := s.requireItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	filter = withCart(filter, *item)
+
+	recommendations, err := s.recommendationService.GetGlobalCoOrderedItems(ctx, itemID)
+	if err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	recommendations, err = s.applySuppressions(ctx, optionalID(req.UserId), recommendations)
+	if err != nil {
+		return nil, err
+	}
+	recommendations = s.recommendationService.FilterRecommendationsByPrice(recommendations, filter)
+
+	return recommendationsResponse(ctx, "GlobalCoOrders", "Items frequently ordered with this item by all customers", &itemID, recommendations), nil
+}
+
+// GetTrendingItems recommends the items trending over the last days (7 unless set)
+func (s *Server) GetTrendingItems(ctx context.Context, req *restaurantv1.TrendingRequest) (*restaurantv1.RecommendationsResponse, error) {
+	days := int(req.GetDays())
+	if days == 0 {
+		days = 7
+	}
+
+	var violations fieldViolations
+	violations.between("days", float64(days), 1, 365)
+	if req.UserId != nil {
+		violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	}
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	recommendations, err := s.recommendationService.GetTimeBasedTrendingItems(ctx, days)
+	if err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	recommendations, err = s.applySuppressions(ctx, optionalID(req.UserId), recommendations)
+	if err != nil {
+		return nil, err
+	}
+	recommendations = s.recommendationService.FilterRecommendationsByPrice(recommendations, filter)
+
+	return recommendationsResponse(ctx, "TimeBasedTrend", "Currently trending items", nil, recommendations), nil
+}
+
+// GetRatingBasedItems recommends items rated highly by guests with similar taste
+func (s *Server) GetRatingBasedItems(ctx context.Context, req *restaurantv1.UserRecommendationsRequest) (*restaurantv1.RecommendationsResponse, error) {
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	userID := int(req.GetUserId())
+	if err := s.recommendationService.RequireUser(ctx, userID); err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+
+	ratingRecs, err := s.recommendationService.GetRatingBasedItems(ctx, userID)
+	if err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+
+	// Negative scores only exist to demote items in the hybrid; they are not recommendations
+	var recommendations []models.Recommendation
+	for _, rec := range ratingRecs {
+		if rec.Score > 0 {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	recommendations = s.recommendationService.FilterRecommendationsByPrice(recommendations, filter)
+
+	return recommendationsResponse(ctx, "Ratings", "Items rated highly by guests with similar taste", nil, recommendations), nil
+}
+
+// GetHybridRecommendations blends every strategy into personalised recommendations
+func (s *Server) GetHybridRecommendations(ctx context.Context, req *restaurantv1.HybridRequest) (*restaurantv1.HybridResponse, error) {
+	query, violations := hybridQuery(req)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	if err := s.recommendationService.RequireUser(ctx, query.UserID); err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	if query.ItemInCart != nil {
+		item, err := s.lookupCartItem(ctx, "item_in_cart", *query.ItemInCart)
+		if err != nil {
+			return nil, err
+		}
+		query.Price = withCart(query.Price, *item)
+	}
+
+	result, err := s.recommendHybrid(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return hybridResponse(ctx, query.ItemInCart, result), nil
+}
+
+// hybridQuery checks a hybrid request and turns it into a query
+func hybridQuery(req *restaurantv1.HybridRequest) (services.HybridQuery, fieldViolations) {
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	if req.ItemInCart != nil {
+		violations.atLeast("item_in_cart", float64(req.GetItemInCart()), 1)
+	}
+	if len(req.GetProfile()) > 100 {
+		violations.add("profile", "must be at most 100 characters")
+	}
+	violations.atLeast("profile_version", float64(req.GetProfileVersion()), 0)
+
+	overrides := services.WeightOverrides{
+		UserFrequency:    req.UserFrequency,
+		UserCoOrders:     req.UserCoOrders,
+		GlobalCoOrders:   req.GlobalCoOrders,
+		TimeBasedTrend:   req.TimeBasedTrend,
+		PriceSensitivity: req.PriceSensitivity,
+		Ratings:          req.Ratings,
+	}
+	weights := []struct {
+		field string
+		value *float64
+	}{
+		{"user_frequency", overrides.UserFrequency},
+		{"user_co_orders", overrides.UserCoOrders},
+		{"global_co_orders", overrides.GlobalCoOrders},
+		{"time_based_trend", overrides.TimeBasedTrend},
+		{"price_sensitivity", overrides.PriceSensitivity},
+		{"ratings", overrides.Ratings},
+	}
+	for _, weight := range weights {
+		if weight.value != nil {
+			violations.atLeast(weight.field, *weight.value, 0)
+		}
+	}
+
+	violations.between("diversity", req.GetDiversity(), 0, 1)
+	violations.atLeast("max_per_category", float64(req.GetMaxPerCategory()), 0)
+	mode := services.RecommendationMode(req.GetMode())
+	switch mode {
+	case "", services.ModeReorder, services.ModeExplore, services.ModeMixed:
+	default:
+		violations.add("mode", "must be one of reorder, explore, mixed")
+	}
+	mixRatio := services.DefaultMixRatio
+	if req.MixRatio != nil {
+		mixRatio = req.GetMixRatio()
+		violations.between("mix_ratio", mixRatio, 0, 1)
+	}
+
+	return services.HybridQuery{
+		UserID:         int(req.GetUserId()),
+		ItemInCart:     optionalID(req.ItemInCart),
+		Profile:        req.GetProfile(),
+		ProfileVersion: int(req.GetProfileVersion()),
+		Overrides:      overrides,
+		ExperimentUnit: req.GetSessionId(),
+		Price:          priceFilter(req.GetPrice(), &violations),
+		Diversity: services.DiversityOptions{
+			Diversity:      req.GetDiversity(),
+			MaxPerCategory: int(req.GetMaxPerCategory()),
+		},
+		Mode:     mode,
+		MixRatio: mixRatio,
+	}, violations
+}
+
+// recommendHybrid runs the hybrid pipeline, reporting a missing profile and all-zero
+// weights as invalid fields
+func (s *Server) recommendHybrid(ctx context.Context, query services.HybridQuery) (services.HybridResult, error) {
+	result, err := s.recommendationService.RecommendHybrid(ctx, query)
+	switch {
+	case query.Profile != "" && errors.Is(err, services.ErrWeightProfileNotFound):
+		return services.HybridResult{}, invalidField("profile", "does not exist")
+	case errors.Is(err, services.ErrInvalidWeights):
+		return services.HybridResult{}, invalidField("weights", "at least one weight must be positive")
+	case err != nil:
+		return services.HybridResult{}, statusError(err, "Failed to get recommendations")
+	}
+	return result, nil
+}
+
+// hybridResponse describes hybrid recommendations with the settings that produced them
+func hybridResponse(ctx context.Context, itemInCart *int, result services.HybridResult) *restaurantv1.HybridResponse {
+	resp := &restaurantv1.HybridResponse{
+		RequestId:       requestID(ctx),
+		Strategy:        "Hybrid",
+		Description:     "Personalized recommendations based on multiple factors",
+		ItemInCart:      wireID(itemInCart),
+		Segment:         result.Segment,
+		Weights:         toWeights(result.Weights),
+		Recommendations: toRecommendations(result.Recommendations),
+	}
+	if result.Profile != nil {
+		resp.Profile, resp.ProfileVersion = result.Profile.Name, int32(result.Profile.Version)
+	}
+	if result.Experiment != nil {
+		resp.Experiment, resp.Variant = result.Experiment.Experiment, result.Experiment.Variant
+	}
+	return resp
+}
+
+// GetBundleRecommendations suggests combinations that complete a user's meal
+func (s *Server) GetBundleRecommendations(ctx context.Context, req *restaurantv1.BundleRequest) (*restaurantv1.BundleResponse, error) {
+	size, limit := int(req.GetSize()), int(req.GetLimit())
+	if size == 0 {
+		size = 3
+	}
+	if limit == 0 {
+		limit = 5
+	}
+
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	if req.ItemInCart != nil {
+		violations.atLeast("item_in_cart", float64(req.GetItemInCart()), 1)
+	}
+	violations.between("size", float64(size), 2, 4)
+	violations.between("limit", float64(limit), 1, 50)
+	// The bundle total already includes the cart item, so it is not counted towards the budget twice
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	userID := int(req.GetUserId())
+	if err := s.recommendationService.RequireUser(ctx, userID); err != nil {
+		return nil, statusError(err, "Failed to get bundles")
+	}
+	itemInCart := optionalID(req.ItemInCart)
+	if itemInCart != nil {
+		if _, err := s.lookupCartItem(ctx, "item_in_cart", *itemInCart); err != nil {
+			return nil, err
+		}
+	}
+
+	bundles, err := s.recommendationService.GetBundleRecommendations(ctx, userID, services.BundleOptions{
+		ItemInCartID: itemInCart,
+		Size:         size,
+		Price:        filter,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, statusError(err, "Failed to get bundles")
+	}
+
+	return &restaurantv1.BundleResponse{
+		RequestId:   requestID(ctx),
+		Strategy:    "Bundles",
+		Description: "Combinations that complete your meal",
+		ItemInCart:  wireID(itemInCart),
+		Bundles:     toBundles(bundles),
+	}, nil
+}
+
+// GetReorderSuggestions suggests past orders the user can place again
+func (s *Server) GetReorderSuggestions(ctx context.Context, req *restaurantv1.ReorderRequest) (*restaurantv1.ReorderResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 5
+	}
+
+	var violations fieldViolations
+	violations.atLeast("user_id", float64(req.GetUserId()), 1)
+	violations.between("limit", float64(limit), 1, 50)
+	filter := priceFilter(req.GetPrice(), &violations)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	userID := int(req.GetUserId())
+	if err := s.recommendationService.RequireUser(ctx, userID); err != nil {
+		return nil, statusError(err, "Failed to get reorder suggestions")
+	}
+
+	suggestions, err := s.recommendationService.GetReorderSuggestions(ctx, userID, limit, filter)
+	if err != nil {
+		return nil, statusError(err, "Failed to get reorder suggestions")
+	}
+
+	return &restaurantv1.ReorderResponse{
+		RequestId:        requestID(ctx),
+		Strategy:         "Reorder",
+		Description:      "Your previous orders, ready to order again",
+		RecentBaskets:    toBaskets(suggestions.RecentBaskets),
+		RecurringBaskets: toBaskets(suggestions.RecurringBaskets),
+	}, nil
+}
+
+// recommendationsResponse describes the results of a single strategy
+func recommendationsResponse(ctx context.Context, strategy, description string, itemInCart *int, recommendations []models.Recommendation) *restaurantv1.RecommendationsResponse {
+	return &restaurantv1.RecommendationsResponse{
+		RequestId:       requestID(ctx),
+		Strategy:        strategy,
+		Description:     description,
+		ItemInCart:      wireID(itemInCart),
+		Recommendations: toRecommendations(recommendations),
+	}
+}
+
+// priceFilter checks the price constraints of a request and turns them into a filter
+func priceFilter(price *restaurantv1.PriceFilter, violations *fieldViolations) services.PriceFilter {
+	filter := services.PriceFilter{
+		MinPrice: price.GetMinPrice(),
+		MaxPrice: price.GetMaxPrice(),
+		Budget:   price.GetBudget(),
+	}
+	violations.atLeast("price.min_price", filter.MinPrice, 0)
+	violations.atLeast("price.max_price", filter.MaxPrice, 0)
+	violations.atLeast("price.budget", filter.Budget, 0)
+	if filter.MinPrice > 0 && filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice {
+		violations.add("price.max_price", "must be at least price.min_price")
+	}
+	return filter
+}
+
+// withCart counts the items already in the cart towards the budget, when one is set
+func withCart(filter services.PriceFilter, cart ...models.Item) services.PriceFilter {
+	if filter.Budget <= 0 {
+		return filter
+	}
+	for _, item := range cart {
+		filter.CartTotal += item.Price
+	}
+	return filter
+}
+
+// applySuppressions drops what the given user suppressed. Without a user these strategies
+// are not personal, so suppressions only apply when one is given.
+func (s *Server) applySuppressions(ctx context.Context, userID *int, recommendations []models.Recommendation) ([]models.Recommendation, error) {
+	if userID == nil {
+		return recommendations, nil
+	}
+	if err := s.recommendationService.RequireUser(ctx, *userID); err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+
+	recommendations, err := s.recommendationService.FilterSuppressed(ctx, *userID, recommendations)
+	if err != nil {
+		return nil, statusError(err, "Failed to get recommendations")
+	}
+	return recommendations, nil
+}
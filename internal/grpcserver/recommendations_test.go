@@ -0,0 +1,93 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+)
+
+func TestPriceFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		price *restaurantv1.PriceFilter
+		want  map[string]string
+	}{
+		{"absent", nil, map[string]string{}},
+		{"valid", &restaurantv1.PriceFilter{MinPrice: 5, MaxPrice: 20, Budget: 40}, map[string]string{}},
+		{"negative", &restaurantv1.PriceFilter{MinPrice: -1, MaxPrice: -2, Budget: -3}, map[string]string{
+			"price.min_price": "must be at least 0",
+			"price.max_price": "must be at least 0",
+			"price.budget":    "must be at least 0",
+		}},
+		{"inverted", &restaurantv1.PriceFilter{MinPrice: 20, MaxPrice: 5}, map[string]string{
+			"price.max_price": "must be at least price.min_price",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var violations fieldViolations
+			filter := priceFilter(tt.price, &violations)
+			if filter.MinPrice != tt.price.GetMinPrice() || filter.MaxPrice != tt.price.GetMaxPrice() || filter.Budget != tt.price.GetBudget() {
+				t.Errorf("got filter %+v", filter)
+			}
+			if len(tt.want) == 0 {
+				if err := violations.err(); err != nil {
+					t.Errorf("got %v", err)
+				}
+				return
+			}
+			got := violationsOf(t, violations.err())
+			if len(got) != len(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			for field, description := range tt.want {
+				if got[field] != description {
+					t.Errorf("%s: got %q, want %q", field, got[field], description)
+				}
+			}
+		})
+	}
+}
+
+func TestHybridQuery(t *testing.T) {
+	negative, cart := -0.5, int64(0)
+	req := &restaurantv1.HybridRequest{
+		UserId:         0,
+		ItemInCart:     &cart,
+		ProfileVersion: -1,
+		Ratings:        &negative,
+		Diversity:      2,
+		Mode:           "random",
+		Price:          &restaurantv1.PriceFilter{Budget: -1},
+	}
+
+	_, violations := hybridQuery(req)
+	got := violationsOf(t, violations.err())
+	for _, field := range []string{"user_id", "item_in_cart", "profile_version", "ratings", "diversity", "mode", "price.budget"} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("%s was not rejected; got %v", field, got)
+		}
+	}
+
+	query, violations := hybridQuery(&restaurantv1.HybridRequest{UserId: 3, SessionId: "s-1"})
+	if err := violations.err(); err != nil {
+		t.Fatal(err)
+	}
+	if query.UserID != 3 || query.ExperimentUnit != "s-1" || query.ItemInCart != nil || query.MixRatio != services.DefaultMixRatio {
+		t.Errorf("got %+v", query)
+	}
+}
+
+func TestWithCart(t *testing.T) {
+	cart := []models.Item{{DbID: 1, Price: 4.5}, {DbID: 2, Price: 3}}
+
+	if got := withCart(services.PriceFilter{MaxPrice: 10}, cart...); got.CartTotal != 0 {
+		t.Errorf("without a budget got cart total %v", got.CartTotal)
+	}
+	if got := withCart(services.PriceFilter{Budget: 20}, cart...); got.CartTotal != 7.5 {
+		t.Errorf("got cart total %v, want 7.5", got.CartTotal)
+	}
+}
@@ -0,0 +1,183 @@
+// Package grpcserver serves the recommendation service over gRPC, next to the REST
+// API and backed by the same services.
+package grpcserver
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/yishak-cs/Neo4j_DB --go-grpc_out=../.. --go-grpc_opt=module=github.com/yishak-cs/Neo4j_DB restaurant/v1/restaurant.proto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadata carries the ID clients quote when reporting feedback events
+const requestIDMetadata = "x-request-id"
+
+// requestIDKey is the context key the request ID is stored under
+type requestIDKey struct{}
+
+// recommender is the part of services.RecommendationService the server calls
+type recommender interface {
+	RequireUser(ctx context.Context, userID int) error
+	GetAllUsers(ctx context.Context) ([]models.User, error)
+	GetUserProfile(ctx context.Context, userID int) (models.UserProfile, error)
+	CreateUser(ctx context.Context, user models.User) (models.User, error)
+	GetAllItems(ctx context.Context) ([]models.Item, error)
+	GetItemsByCategory(ctx context.Context, category string) ([]models.Item, error)
+	GetItemByID(ctx context.Context, itemID int) (*models.Item, error)
+	AttachRatingSummaries(ctx context.Context, items []models.Item) error
+	GetUserFrequentItems(ctx context.Context, userID int) ([]models.Recommendation, error)
+	GetUserCoOrderedItems(ctx context.Context, userID int, itemInCartID int) ([]models.Recommendation, error)
+	GetGlobalCoOrderedItems(ctx context.Context, itemInCartID int) ([]models.Recommendation, error)
+	GetTimeBasedTrendingItems(ctx context.Context, days int) ([]models.Recommendation, error)
+	GetRatingBasedItems(ctx context.Context, userID int) ([]models.Recommendation, error)
+	RecommendHybrid(ctx context.Context, query services.HybridQuery) (services.HybridResult, error)
+	GetBundleRecommendations(ctx context.Context, userID int, opts services.BundleOptions) ([]models.Bundle, error)
+	GetReorderSuggestions(ctx context.Context, userID int, limit int, price services.PriceFilter) (models.ReorderSuggestions, error)
+	FilterRecommendationsByPrice(recommendations []models.Recommendation, filter services.PriceFilter) []models.Recommendation
+	FilterSuppressed(ctx context.Context, userID int, recommendations []models.Recommendation) ([]models.Recommendation, error)
+}
+
+// Server serves RecommendationService and the standard health service
+type Server struct {
+	restaurantv1.UnimplementedRecommendationServiceServer
+
+	recommendationService recommender
+	grpcServer            *grpc.Server
+	health                *health.Server
+	// newTicker paces cart stream refreshes
+	newTicker func(time.Duration) *time.Ticker
+
+	// draining is closed when shutdown starts, ending open cart streams so
+	// GracefulStop does not wait for them
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+// NewServer creates a gRPC server for the recommendation service
+func NewServer(recommendationService *services.RecommendationService) *Server {
+	return newServer(recommendationService)
+}
+
+// newServer creates a gRPC server backed by any recommender
+func newServer(recommendationService recommender) *Server {
+	s := &Server{
+		recommendationService: recommendationService,
+		health:                health.NewServer(),
+		newTicker:             time.NewTicker,
+		draining:              make(chan struct{}),
+	}
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	)
+	restaurantv1.RegisterRecommendationServiceServer(s.grpcServer, s)
+	healthpb.RegisterHealthServer(s.grpcServer, s.health)
+	return s
+}
+
+// Serve accepts connections on the listener until the server stops
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpcServer.Serve(lis)
+}
+
+// Shutdown stops the server gracefully: it reports NOT_SERVING, ends open cart
+// streams and waits for in-flight calls. If ctx expires first, the remaining calls
+// are cancelled and ctx's error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	s.drainOnce.Do(func() { close(s.draining) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
+// unaryInterceptor assigns each call a request ID and turns panics into internal errors
+func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer recoverPanic(info.FullMethod, &err)
+	return handler(withRequestID(ctx), req)
+}
+
+// streamInterceptor assigns each stream a request ID and turns panics into internal errors
+func streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverPanic(info.FullMethod, &err)
+	return handler(srv, &requestIDStream{ServerStream: stream, ctx: withRequestID(stream.Context())})
+}
+
+// requestIDStream is a server stream whose context carries the request ID
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream's context with the request ID
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+// recoverPanic logs a panicking call and answers it with an internal error, as gin's
+// recovery middleware does for the REST API
+func recoverPanic(method string, err *error) {
+	if r := recover(); r != nil {
+		log.Printf("Panic in %s: %v", method, r)
+		*err = status.Error(codes.Internal, "Internal error")
+	}
+}
+
+// withRequestID takes the request ID from the call's metadata, or generates one, and
+// echoes it back in the response header
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadata); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, id)); err != nil {
+		log.Printf("Failed to set request ID header: %v", err)
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestID returns the ID assigned to the current call
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// newRequestID returns a random 128-bit hex ID
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return hex.EncodeToString([]byte(time.Now().UTC().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,146 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+	restaurantv1 "github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// hybridAnswer is one answer of stubRecommender.RecommendHybrid
+type hybridAnswer struct {
+	result services.HybridResult
+	err    error
+}
+
+// stubRecommender answers hybrid queries from a script; the methods it does not
+// implement panic, which the interceptors turn into internal errors
+type stubRecommender struct {
+	recommender
+
+	// answers are given in turn, and the last one repeats
+	answers []hybridAnswer
+	// hold makes RecommendHybrid wait for the call to end, after closing started
+	hold    bool
+	started chan struct{}
+
+	mu      sync.Mutex
+	queries []services.HybridQuery
+}
+
+func (s *stubRecommender) RequireUser(context.Context, int) error {
+	return nil
+}
+
+func (s *stubRecommender) GetItemByID(_ context.Context, itemID int) (*models.Item, error) {
+	return &models.Item{DbID: itemID, Name: fmt.Sprintf("Item %d", itemID), Price: 5}, nil
+}
+
+func (s *stubRecommender) RecommendHybrid(ctx context.Context, query services.HybridQuery) (services.HybridResult, error) {
+	if s.hold {
+		close(s.started)
+		<-ctx.Done()
+		return services.HybridResult{}, ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, query)
+	answer := s.answers[0]
+	if len(s.answers) > 1 {
+		s.answers = s.answers[1:]
+	}
+	return answer.result, answer.err
+}
+
+// queried returns the queries received so far
+func (s *stubRecommender) queried() []services.HybridQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]services.HybridQuery(nil), s.queries...)
+}
+
+// startServer serves the stub over an in-memory connection, refreshing cart streams
+// every few milliseconds, and returns a client for it
+func startServer(t *testing.T, stub *stubRecommender) (*Server, restaurantv1.RecommendationServiceClient) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := newServer(stub)
+	srv.newTicker = func(time.Duration) *time.Ticker { return time.NewTicker(5 * time.Millisecond) }
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		srv.grpcServer.Stop()
+	})
+	return srv, restaurantv1.NewRecommendationServiceClient(conn)
+}
+
+func TestRequestIDMetadata(t *testing.T) {
+	stub := &stubRecommender{answers: []hybridAnswer{{}}}
+	_, client := startServer(t, stub)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDMetadata, "req-42")
+	resp, err := client.GetHybridRecommendations(ctx, &restaurantv1.HybridRequest{UserId: 1}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetRequestId() != "req-42" || len(header.Get(requestIDMetadata)) != 1 || header.Get(requestIDMetadata)[0] != "req-42" {
+		t.Errorf("got request ID %q and header %v, want the client's req-42", resp.GetRequestId(), header.Get(requestIDMetadata))
+	}
+
+	// Without one from the client, the generated ID is both echoed and in the response
+	resp, err = client.GetHybridRecommendations(context.Background(), &restaurantv1.HybridRequest{UserId: 1}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetRequestId() == "" || header.Get(requestIDMetadata)[0] != resp.GetRequestId() {
+		t.Errorf("got request ID %q and header %v", resp.GetRequestId(), header.Get(requestIDMetadata))
+	}
+}
+
+func TestShutdownStopsWhenContextExpires(t *testing.T) {
+	stub := &stubRecommender{hold: true, started: make(chan struct{})}
+	srv, client := startServer(t, stub)
+
+	callErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetHybridRecommendations(context.Background(), &restaurantv1.HybridRequest{UserId: 1})
+		callErr <- err
+	}()
+	<-stub.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := srv.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the context's deadline error", err)
+	}
+
+	select {
+	case err := <-callErr:
+		if err == nil {
+			t.Error("the held call succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the held call was not cancelled")
+	}
+}
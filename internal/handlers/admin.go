@@ -0,0 +1,400 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// weightsInput is a set of hybrid weights sent by a client
+type weightsInput struct {
+	UserFrequency    float64 `json:"user_frequency" binding:"gte=0"`
+	UserCoOrders     float64 `json:"user_co_orders" binding:"gte=0"`
+	GlobalCoOrders   float64 `json:"global_co_orders" binding:"gte=0"`
+	TimeBasedTrend   float64 `json:"time_based_trend" binding:"gte=0"`
+	PriceSensitivity float64 `json:"price_sensitivity" binding:"gte=0"`
+	Ratings          float64 `json:"ratings" binding:"gte=0"`
+}
+
+// weightProfileRequest is the body accepted when creating a weight profile
+type weightProfileRequest struct {
+	Name        string       `json:"name" binding:"required,max=100"`
+	Description string       `json:"description" binding:"max=500"`
+	Weights     weightsInput `json:"weights"`
+}
+
+// weightProfileUpdateRequest is the body accepted when publishing a new version of a weight profile
+type weightProfileUpdateRequest struct {
+	namePath
+	Description string       `json:"description" binding:"max=500"`
+	Weights     weightsInput `json:"weights"`
+}
+
+// weightProfileVersionRequest is the request for a weight profile, optionally at a given version
+type weightProfileVersionRequest struct {
+	namePath
+	Version *int `form:"version" binding:"omitempty,min=1"`
+}
+
+// segmentPath identifies the user segment in paths with :segment
+type segmentPath struct {
+	Segment string `uri:"segment" json:"-" binding:"required,oneof=default new_user experienced_user"`
+}
+
+// assignmentRequest is the body accepted when assigning a profile to a segment
+type assignmentRequest struct {
+	segmentPath
+	Profile string `json:"profile" binding:"required,max=100"`
+}
+
+// variantInput is one arm of a new experiment
+type variantInput struct {
+	Name       string   `json:"name" binding:"required,max=50"`
+	Allocation int      `json:"allocation" binding:"min=1,max=100"`
+	Profile    string   `json:"profile" binding:"max=100"`
+	Strategies []string `json:"strategies" binding:"omitempty,dive,oneof=UserFrequency UserCoOrders GlobalCoOrders TimeBasedTrend PriceSensitivity Ratings"`
+}
+
+// experimentRequest is the body accepted when defining an experiment
+type experimentRequest struct {
+	Name        string         `json:"name" binding:"required,max=100"`
+	Description string         `json:"description" binding:"max=500"`
+	Variants    []variantInput `json:"variants" binding:"required,min=2,max=10,dive"`
+}
+
+// weightProfileListResponse is the body of the latest version of every weight profile
+type weightProfileListResponse struct {
+	Profiles []models.WeightProfile `json:"profiles"`
+	Count    int                    `json:"count"`
+}
+
+// weightProfileVersionsResponse is the body of a weight profile's version history
+type weightProfileVersionsResponse struct {
+	Name     string                 `json:"name"`
+	Versions []models.WeightProfile `json:"versions"`
+	Count    int                    `json:"count"`
+}
+
+// segmentAssignmentsResponse is the body of the profile each segment uses
+type segmentAssignmentsResponse struct {
+	Assignments map[string]string `json:"assignments"`
+	Segments    []string          `json:"segments"`
+}
+
+// segmentAssignmentResponse is the body confirming a segment's new profile
+type segmentAssignmentResponse struct {
+	Segment string `json:"segment"`
+	Profile string `json:"profile"`
+}
+
+// experimentListResponse is the body of every experiment
+type experimentListResponse struct {
+	Experiments []models.Experiment `json:"experiments"`
+	Count       int                 `json:"count"`
+}
+
+// experimentExposuresResponse is the body of how often each variant of an experiment was served
+type experimentExposuresResponse struct {
+	Experiment string           `json:"experiment"`
+	Active     bool             `json:"active"`
+	Exposures  map[string]int64 `json:"exposures"`
+	Total      int64            `json:"total"`
+}
+
+// experimentStateResponse is the body confirming whether an experiment is running
+type experimentStateResponse struct {
+	Experiment string `json:"experiment"`
+	Active     bool   `json:"active"`
+}
+
+// ListWeightProfiles handles requests for the latest version of every weight profile
+func (h *APIHandler) ListWeightProfiles(c *gin.Context) {
+	profiles, err := h.recommendationService.ListWeightProfiles(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to get weight profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, weightProfileListResponse{
+		Profiles: profiles,
+		Count:    len(profiles),
+	})
+}
+
+// GetWeightProfile handles requests for one weight profile, optionally at a given version
+func (h *APIHandler) GetWeightProfile(c *gin.Context) {
+	var req weightProfileVersionRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	version := 0
+	if req.Version != nil {
+		version = *req.Version
+	}
+
+	profile, err := h.recommendationService.GetWeightProfile(c.Request.Context(), req.Name, version)
+	if err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetWeightProfileVersions handles requests for the full version history of a profile
+func (h *APIHandler) GetWeightProfileVersions(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	versions, err := h.recommendationService.GetWeightProfileVersions(c.Request.Context(), req.Name)
+	if err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, weightProfileVersionsResponse{
+		Name:     req.Name,
+		Versions: versions,
+		Count:    len(versions),
+	})
+}
+
+// CreateWeightProfile handles requests to create a new weight profile
+func (h *APIHandler) CreateWeightProfile(c *gin.Context) {
+	var req weightProfileRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	weights := models.HybridWeights(req.Weights)
+	if !requirePositiveWeight(c, weights) {
+		return
+	}
+
+	profile, err := h.recommendationService.CreateWeightProfile(c.Request.Context(), req.Name, req.Description, weights)
+	if err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// UpdateWeightProfile handles requests to publish a new version of a weight profile
+func (h *APIHandler) UpdateWeightProfile(c *gin.Context) {
+	var req weightProfileUpdateRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	weights := models.HybridWeights(req.Weights)
+	if !requirePositiveWeight(c, weights) {
+		return
+	}
+
+	profile, err := h.recommendationService.UpdateWeightProfile(c.Request.Context(), req.Name, req.Description, weights)
+	if err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteWeightProfile handles requests to delete every version of a weight profile
+func (h *APIHandler) DeleteWeightProfile(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.DeleteWeightProfile(c.Request.Context(), req.Name); err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSegmentAssignments handles requests for the profile each user segment uses
+func (h *APIHandler) GetSegmentAssignments(c *gin.Context) {
+	assignments, err := h.recommendationService.GetSegmentAssignments(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to get segment assignments")
+		return
+	}
+
+	c.JSON(http.StatusOK, segmentAssignmentsResponse{
+		Assignments: assignments,
+		Segments:    []string{services.SegmentDefault, services.SegmentNewUser, services.SegmentExperiencedUser},
+	})
+}
+
+// AssignWeightProfile handles requests to make a segment use a profile
+func (h *APIHandler) AssignWeightProfile(c *gin.Context) {
+	var req assignmentRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.AssignWeightProfile(c.Request.Context(), req.Segment, req.Profile); err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, segmentAssignmentResponse{
+		Segment: req.Segment,
+		Profile: req.Profile,
+	})
+}
+
+// UnassignWeightProfile handles requests to return a segment to its built-in weights
+func (h *APIHandler) UnassignWeightProfile(c *gin.Context) {
+	var req segmentPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.UnassignWeightProfile(c.Request.Context(), req.Segment); err != nil {
+		respondError(c, err, "Failed to manage weight profiles")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListExperiments handles requests for every experiment
+func (h *APIHandler) ListExperiments(c *gin.Context) {
+	experiments, err := h.recommendationService.ListExperiments(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to get experiments")
+		return
+	}
+
+	c.JSON(http.StatusOK, experimentListResponse{
+		Experiments: experiments,
+		Count:       len(experiments),
+	})
+}
+
+// GetExperiment handles requests for one experiment
+func (h *APIHandler) GetExperiment(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	experiment, err := h.recommendationService.GetExperiment(c.Request.Context(), req.Name)
+	if err != nil {
+		respondError(c, err, "Failed to manage experiments")
+		return
+	}
+
+	c.JSON(http.StatusOK, experiment)
+}
+
+// GetExperimentExposures handles requests for how often each variant was served
+func (h *APIHandler) GetExperimentExposures(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	experiment, err := h.recommendationService.GetExperiment(c.Request.Context(), req.Name)
+	if err != nil {
+		respondError(c, err, "Failed to manage experiments")
+		return
+	}
+
+	var total int64
+	exposures := make(map[string]int64, len(experiment.Variants))
+	for _, variant := range experiment.Variants {
+		exposures[variant.Name] = variant.Exposures
+		total += variant.Exposures
+	}
+
+	c.JSON(http.StatusOK, experimentExposuresResponse{
+		Experiment: experiment.Name,
+		Active:     experiment.Active,
+		Exposures:  exposures,
+		Total:      total,
+	})
+}
+
+// CreateExperiment handles requests to define a new experiment
+func (h *APIHandler) CreateExperiment(c *gin.Context) {
+	var req experimentRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	variants := make([]models.ExperimentVariant, len(req.Variants))
+	for i, variant := range req.Variants {
+		variants[i] = models.ExperimentVariant{
+			Name:       variant.Name,
+			Allocation: variant.Allocation,
+			Profile:    variant.Profile,
+			Strategies: variant.Strategies,
+		}
+	}
+
+	experiment, err := h.recommendationService.CreateExperiment(c.Request.Context(), models.Experiment{
+		Name:        req.Name,
+		Description: req.Description,
+		Variants:    variants,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to manage experiments")
+		return
+	}
+
+	c.JSON(http.StatusCreated, experiment)
+}
+
+// StartExperiment handles requests to make an experiment the running one
+func (h *APIHandler) StartExperiment(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.StartExperiment(c.Request.Context(), req.Name); err != nil {
+		respondError(c, err, "Failed to manage experiments")
+		return
+	}
+
+	c.JSON(http.StatusOK, experimentStateResponse{Experiment: req.Name, Active: true})
+}
+
+// StopExperiment handles requests to stop an experiment
+func (h *APIHandler) StopExperiment(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.StopExperiment(c.Request.Context(), req.Name); err != nil {
+		respondError(c, err, "Failed to manage experiments")
+		return
+	}
+
+	c.JSON(http.StatusOK, experimentStateResponse{Experiment: req.Name, Active: false})
+}
+
+// DeleteExperiment handles requests to delete an experiment
+func (h *APIHandler) DeleteExperiment(c *gin.Context) {
+	var req namePath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.DeleteExperiment(c.Request.Context(), req.Name); err != nil {
+		respondError(c, err, "Failed to manage experiments")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,224 +1,594 @@
 package handlers
 
 import (
-	"log"
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/graph"
 	"github.com/yishak-cs/Neo4j_DB/internal/models"
 	"github.com/yishak-cs/Neo4j_DB/internal/services"
 )
 
+// priceParams are the optional price constraints every recommendation endpoint accepts
+type priceParams struct {
+	MinPrice float64 `form:"minPrice" binding:"gte=0"`
+	MaxPrice float64 `form:"maxPrice" binding:"gte=0"`
+	Budget   float64 `form:"budget" binding:"gte=0"`
+}
+
+// suppressionParams name the user whose suppressions apply to a non-personal strategy
+type suppressionParams struct {
+	UserID *int `form:"userId" binding:"omitempty,min=1"`
+}
+
+// userFrequentRequest is the request for a user's most frequently ordered items
+type userFrequentRequest struct {
+	userPath
+	priceParams
+}
+
+// userCoOrdersRequest is the request for items a user orders with a given item
+type userCoOrdersRequest struct {
+	userPath
+	itemPath
+	priceParams
+}
+
+// globalCoOrdersRequest is the request for items everyone orders with a given item
+type globalCoOrdersRequest struct {
+	itemPath
+	suppressionParams
+	priceParams
+}
+
+// trendingRequest is the request for currently trending items
+type trendingRequest struct {
+	Days int `form:"days,default=7" binding:"min=1,max=365"`
+	suppressionParams
+	priceParams
+}
+
+// hybridRequest is the request for hybrid recommendations. Weight overrides are
+// pointers so an explicit zero can be told apart from no override.
+type hybridRequest struct {
+	userPath
+	ItemInCart       *int     `form:"itemInCart" binding:"omitempty,min=1"`
+	Profile          string   `form:"profile" binding:"max=100"`
+	ProfileVersion   *int     `form:"profileVersion" binding:"omitempty,min=1"`
+	UserFreq         *float64 `form:"userFreq" binding:"omitempty,gte=0"`
+	UserCoOrders     *float64 `form:"userCoOrders" binding:"omitempty,gte=0"`
+	GlobalCoOrders   *float64 `form:"globalCoOrders" binding:"omitempty,gte=0"`
+	TimeTrend        *float64 `form:"timeTrend" binding:"omitempty,gte=0"`
+	PriceSensitivity *float64 `form:"priceSensitivity" binding:"omitempty,gte=0"`
+	Ratings          *float64 `form:"ratings" binding:"omitempty,gte=0"`
+	Diversity        float64  `form:"diversity" binding:"gte=0,lte=1"`
+	MaxPerCategory   int      `form:"maxPerCategory" binding:"gte=0"`
+	Mode             string   `form:"mode" binding:"omitempty,oneof=reorder explore mixed"`
+	MixRatio         float64  `form:"mixRatio,default=0.5" binding:"gte=0,lte=1"`
+	priceParams
+}
+
+// bundleRequest is the request for complete-the-meal bundles
+type bundleRequest struct {
+	userPath
+	ItemInCart *int `form:"itemInCart" binding:"omitempty,min=1"`
+	Size       int  `form:"size,default=3" binding:"min=2,max=4"`
+	Limit      int  `form:"limit,default=5" binding:"min=1,max=50"`
+	priceParams
+}
+
+// reorderRequest is the request for past orders to place again
+type reorderRequest struct {
+	userPath
+	Limit int `form:"limit,default=5" binding:"min=1,max=50"`
+	priceParams
+}
+
+// categoryItemsRequest is the request for the items in one category
+type categoryItemsRequest struct {
+	Category string `uri:"category" binding:"required,max=100"`
+}
+
+// recommendationMeta is shared by every recommendation response: the strategy that
+// produced it, the cart item it was based on (null when none), the experiment variant
+// served (null when not enrolled) and the request ID that feedback events refer back to
+type recommendationMeta struct {
+	RequestID   string                       `json:"request_id"`
+	Strategy    string                       `json:"strategy"`
+	Description string                       `json:"description"`
+	ItemInCart  *int                         `json:"item_in_cart"`
+	Experiment  *models.ExperimentAssignment `json:"experiment"`
+}
+
+// recommendationListResponse is the body of every single-strategy recommendation
+// endpoint; user_id, item_id and days appear on the endpoints they apply to
+type recommendationListResponse struct {
+	recommendationMeta
+	UserID          *int                    `json:"user_id,omitempty"`
+	ItemID          *int                    `json:"item_id,omitempty"`
+	Days            int                     `json:"days,omitempty"`
+	PriceFilter     services.PriceFilter    `json:"price_filter"`
+	Recommendations []models.Recommendation `json:"recommendations"`
+}
+
+// hybridResponse is the body of hybrid recommendations, with the settings that produced them
+type hybridResponse struct {
+	recommendationMeta
+	UserID          int                         `json:"user_id"`
+	Segment         string                      `json:"segment"`
+	Profile         *models.WeightProfile       `json:"profile"`
+	Weights         models.HybridWeights        `json:"weights"`
+	Diversity       services.DiversityOptions   `json:"diversity"`
+	Mode            services.RecommendationMode `json:"mode"`
+	MixRatio        float64                     `json:"mix_ratio"`
+	PriceFilter     services.PriceFilter        `json:"price_filter"`
+	Recommendations []models.Recommendation     `json:"recommendations"`
+}
+
+// bundleResponse is the body of complete-the-meal bundles
+type bundleResponse struct {
+	recommendationMeta
+	UserID      int                  `json:"user_id"`
+	PriceFilter services.PriceFilter `json:"price_filter"`
+	Size        int                  `json:"size"`
+	Bundles     []models.Bundle      `json:"bundles"`
+}
+
+// reorderResponse is the body of past orders to place again
+type reorderResponse struct {
+	recommendationMeta
+	UserID           int                  `json:"user_id"`
+	PriceFilter      services.PriceFilter `json:"price_filter"`
+	RecentBaskets    []models.Basket      `json:"recent_baskets"`
+	RecurringBaskets []models.Basket      `json:"recurring_baskets"`
+}
+
+// itemListResponse is the body of a list of menu items, optionally of one category
+type itemListResponse struct {
+	Category string        `json:"category,omitempty"`
+	Items    []models.Item `json:"items"`
+	Count    int           `json:"count"`
+}
+
+// userListResponse is the body of the list of users
+type userListResponse struct {
+	Users []models.User `json:"users"`
+	Count int           `json:"count"`
+}
+
+// healthResponse is the body of the health check
+type healthResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
 // APIHandler handles all API requests
 type APIHandler struct {
 	recommendationService *services.RecommendationService
+	eventService          *services.EventService
+	graphQL               *graph.Schema
+	adminToken            string
+	openAPI               []byte
 }
 
 // NewAPIHandler creates a new API handler
-func NewAPIHandler(recommendationService *services.RecommendationService) *APIHandler {
+func NewAPIHandler(recommendationService *services.RecommendationService, eventService *services.EventService) *APIHandler {
 	return &APIHandler{
 		recommendationService: recommendationService,
+		eventService:          eventService,
+		graphQL:               graph.NewSchema(recommendationService),
 	}
 }
 
-// SetupRoutes configures all API routes
+// SetAdminToken sets the bearer token admin routes require; without one they are disabled
+func (h *APIHandler) SetAdminToken(token string) {
+	h.adminToken = token
+}
+
+// SetupRoutes configures all API routes. They are served under /api/v1, and the
+// unversioned /api paths they replaced remain as deprecated aliases.
 func (h *APIHandler) SetupRoutes(router *gin.Engine) {
-	api := router.Group("/api")
-	{
-		// Health check
-		api.GET("/health", h.GetHealth)
+	// API description
+	router.GET("/api/openapi.json", RequestID(), h.GetOpenAPI)
+	router.GET("/api/docs", RequestID(), h.GetAPIDocs)
 
-		// Users
-		api.GET("/users", h.GetAllUsers)
+	h.registerRoutes(router.Group(apiV1Prefix, RequestID()))
+	h.registerRoutes(router.Group(legacyAPIPrefix, RequestID(), deprecatedAlias(legacyAPIPrefix, apiV1Prefix, legacyAPIDeprecatedAt)))
 
-		// Menu items
-		api.GET("/items", h.GetAllItems)
-		api.GET("/items/category/:category", h.GetItemsByCategory)
+	// The document is checked against the routes above, so an undocumented route fails at startup
+	spec, err := buildOpenAPI(router.Routes())
+	if err != nil {
+		panic(err)
+	}
+	h.openAPI = spec
+}
+
+// registerRoutes adds every API route to the group
+func (h *APIHandler) registerRoutes(api *gin.RouterGroup) {
+	// Health check
+	api.GET("/health", h.GetHealth)
+
+	// Users
+	api.GET("/users", h.GetAllUsers)
+	api.POST("/users", h.CreateUser)
+	api.GET("/users/:userId", h.GetUserProfile)
+	api.PATCH("/users/:userId", h.UpdateUser)
+	api.GET("/users/:userId/orders", h.GetUserOrders)
+	api.GET("/users/:userId/suppressions", h.GetSuppressions)
+	api.POST("/users/:userId/suppressions", h.CreateSuppression)
+	api.DELETE("/users/:userId/suppressions/items/:itemId", h.DeleteItemSuppression)
+	api.DELETE("/users/:userId/suppressions/categories/:category", h.DeleteCategorySuppression)
+
+	// Menu items
+	api.GET("/items", h.GetAllItems)
+	api.GET("/items/category/:category", h.GetItemsByCategory)
+	api.GET("/items/:itemId/ratings", h.GetItemRatings)
+	api.POST("/items/:itemId/ratings", h.RateItem)
+
+	// Orders
+	api.POST("/orders", h.PlaceOrder)
+	api.PATCH("/orders/:orderId", h.AmendOrder)
+	api.DELETE("/orders/:orderId", h.CancelOrder)
+	api.GET("/orders/:orderId/changes", h.GetOrderChanges)
+
+	// Recommendations
+	api.GET("/recommendations/user-frequent/:userId", h.GetUserFrequentItems)
+	api.GET("/recommendations/user-co-orders/:userId/:itemId", h.GetUserCoOrderedItems)
+	api.GET("/recommendations/global-co-orders/:itemId", h.GetGlobalCoOrderedItems)
+	api.GET("/recommendations/trending", h.GetTrendingItems)
+	api.GET("/recommendations/ratings/:userId", h.GetRatingBasedItems)
+	api.GET("/recommendations/hybrid/:userId", h.GetHybridRecommendations)
+	api.GET("/recommendations/bundles/:userId", h.GetBundleRecommendations)
+	api.GET("/recommendations/reorder/:userId", h.GetReorderSuggestions)
+
+	// Feedback events
+	api.POST("/events", h.RecordEvents)
+	api.GET("/events/stats", h.GetEventStats)
+
+	// GraphQL
+	api.POST("/graphql", h.QueryGraphQL)
+
+	admin := api.Group("/admin", AdminAuth(h.adminToken))
+	{
+		// Menu items and categories
+		admin.GET("/items", h.ListMenuItems)
+		admin.POST("/items", h.CreateMenuItem)
+		admin.GET("/items/:itemId", h.GetMenuItem)
+		admin.PUT("/items/:itemId", h.ReplaceMenuItem)
+		admin.PATCH("/items/:itemId", h.UpdateMenuItem)
+		admin.DELETE("/items/:itemId", h.WithdrawMenuItem)
+		admin.GET("/categories", h.ListCategories)
+		admin.POST("/categories", h.CreateCategory)
+		admin.PATCH("/categories/:name", h.UpdateCategory)
+		admin.DELETE("/categories/:name", h.DeleteCategory)
+
+		// Right to be forgotten
+		admin.DELETE("/users/:userId", h.EraseUser)
+
+		// Weight profiles
+		admin.GET("/weight-profiles", h.ListWeightProfiles)
+		admin.POST("/weight-profiles", h.CreateWeightProfile)
+		admin.GET("/weight-profiles/:name", h.GetWeightProfile)
+		admin.GET("/weight-profiles/:name/versions", h.GetWeightProfileVersions)
+		admin.PUT("/weight-profiles/:name", h.UpdateWeightProfile)
+		admin.DELETE("/weight-profiles/:name", h.DeleteWeightProfile)
+
+		// Segment assignments
+		admin.GET("/weight-profile-assignments", h.GetSegmentAssignments)
+		admin.PUT("/weight-profile-assignments/:segment", h.AssignWeightProfile)
+		admin.DELETE("/weight-profile-assignments/:segment", h.UnassignWeightProfile)
+
+		// Experiments
+		admin.GET("/experiments", h.ListExperiments)
+		admin.POST("/experiments", h.CreateExperiment)
+		admin.GET("/experiments/:name", h.GetExperiment)
+		admin.GET("/experiments/:name/exposures", h.GetExperimentExposures)
+		admin.POST("/experiments/:name/start", h.StartExperiment)
+		admin.POST("/experiments/:name/stop", h.StopExperiment)
+		admin.DELETE("/experiments/:name", h.DeleteExperiment)
+
+		// Derived-relationship update queue
+		admin.GET("/update-queue", h.GetUpdateQueueStats)
+
+		// Derived-graph consistency
+		admin.GET("/graph-check", h.CheckDerivedGraph)
+		admin.POST("/graph-check/repair", h.RepairDerivedGraph)
+	}
+}
 
-		// Recommendations
-		api.GET("/recommendations/user-frequent/:userId", h.GetUserFrequentItems)
-		api.GET("/recommendations/user-co-orders/:userId/:itemId", h.GetUserCoOrderedItems)
-		api.GET("/recommendations/global-co-orders/:itemId", h.GetGlobalCoOrderedItems)
-		api.GET("/recommendations/trending", h.GetTrendingItems)
-		api.GET("/recommendations/hybrid/:userId", h.GetHybridRecommendations)
+// newRecommendationMeta describes the strategy behind a recommendation response
+func newRecommendationMeta(c *gin.Context, strategy, description string, itemInCart *int) recommendationMeta {
+	return recommendationMeta{
+		RequestID:   requestID(c),
+		Strategy:    strategy,
+		Description: description,
+		ItemInCart:  itemInCart,
 	}
 }
 
 // GetUserFrequentItems handles requests for a user's most frequently ordered items
 func (h *APIHandler) GetUserFrequentItems(c *gin.Context) {
-	userID, err := strconv.Atoi(c.Param("userId"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+	var req userFrequentRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, nil)
+	if !ok {
+		return
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get recommendations")
 		return
 	}
 
-	recommendations, err := h.recommendationService.GetUserFrequentItems(c.Request.Context(), userID)
+	recommendations, err := h.recommendationService.GetUserFrequentItems(c.Request.Context(), req.UserID)
 	if err != nil {
-		log.Printf("Error getting user frequent items: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
+		respondError(c, err, "Failed to get recommendations")
 		return
 	}
+	recommendations = h.recommendationService.FilterRecommendationsByPrice(recommendations, priceFilter)
 
-	c.JSON(http.StatusOK, gin.H{
-		"user_id":         userID,
-		"recommendations": recommendations,
-		"strategy":        "UserFrequency",
-		"description":     "Items you order most frequently",
+	c.JSON(http.StatusOK, recommendationListResponse{
+		recommendationMeta: newRecommendationMeta(c, "UserFrequency", "Items you order most frequently", nil),
+		UserID:             &req.UserID,
+		PriceFilter:        priceFilter,
+		Recommendations:    recommendations,
 	})
 }
 
 // GetUserCoOrderedItems handles requests for items a user frequently orders with a specific item
 func (h *APIHandler) GetUserCoOrderedItems(c *gin.Context) {
-	userID, err := strconv.Atoi(c.Param("userId"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+	var req userCoOrdersRequest
+	if !bindRequest(c, &req) {
 		return
 	}
-
-	itemID, err := strconv.Atoi(c.Param("itemId"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get recommendations")
+		return
+	}
+	item, ok := h.requireItem(c, req.ItemID)
+	if !ok {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, item)
+	if !ok {
 		return
 	}
 
-	recommendations, err := h.recommendationService.GetUserCoOrderedItems(c.Request.Context(), userID, itemID)
+	recommendations, err := h.recommendationService.GetUserCoOrderedItems(c.Request.Context(), req.UserID, req.ItemID)
 	if err != nil {
-		log.Printf("Error getting user co-ordered items: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
+		respondError(c, err, "Failed to get recommendations")
 		return
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"user_id":         userID,
-		"item_id":         itemID,
-		"recommendations": recommendations,
-		"strategy":        "UserCoOrders",
-		"description":     "Items you frequently order with this item",
+	recommendations = h.recommendationService.FilterRecommendationsByPrice(recommendations, priceFilter)
+
+	c.JSON(http.StatusOK, recommendationListResponse{
+		recommendationMeta: newRecommendationMeta(c, "UserCoOrders", "Items you frequently order with this item", &req.ItemID),
+		UserID:             &req.UserID,
+		ItemID:             &req.ItemID,
+		PriceFilter:        priceFilter,
+		Recommendations:    recommendations,
 	})
 }
 
 // GetGlobalCoOrderedItems handles requests for items frequently ordered with a specific item by all users
 func (h *APIHandler) GetGlobalCoOrderedItems(c *gin.Context) {
-	itemID, err := strconv.Atoi(c.Param("itemId"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+	var req globalCoOrdersRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	item, ok := h.requireItem(c, req.ItemID)
+	if !ok {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, item)
+	if !ok {
 		return
 	}
 
-	recommendations, err := h.recommendationService.GetGlobalCoOrderedItems(c.Request.Context(), itemID)
+	recommendations, err := h.recommendationService.GetGlobalCoOrderedItems(c.Request.Context(), req.ItemID)
 	if err != nil {
-		log.Printf("Error getting global co-ordered items: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
+		respondError(c, err, "Failed to get recommendations")
 		return
 	}
+	recommendations, ok = h.applySuppressions(c, req.suppressionParams, recommendations)
+	if !ok {
+		return
+	}
+	recommendations = h.recommendationService.FilterRecommendationsByPrice(recommendations, priceFilter)
 
-	c.JSON(http.StatusOK, gin.H{
-		"item_id":         itemID,
-		"recommendations": recommendations,
-		"strategy":        "GlobalCoOrders",
-		"description":     "Items frequently ordered with this item by all customers",
+	c.JSON(http.StatusOK, recommendationListResponse{
+		recommendationMeta: newRecommendationMeta(c, "GlobalCoOrders", "Items frequently ordered with this item by all customers", &req.ItemID),
+		ItemID:             &req.ItemID,
+		PriceFilter:        priceFilter,
+		Recommendations:    recommendations,
 	})
 }
 
 // GetTrendingItems handles requests for currently trending items
 func (h *APIHandler) GetTrendingItems(c *gin.Context) {
-	days := 7 // Default to 7 days
-	if daysParam := c.Query("days"); daysParam != "" {
-		if parsedDays, err := strconv.Atoi(daysParam); err == nil && parsedDays > 0 {
-			days = parsedDays
-		}
+	var req trendingRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, nil)
+	if !ok {
+		return
 	}
 
-	recommendations, err := h.recommendationService.GetTimeBasedTrendingItems(c.Request.Context(), days)
+	recommendations, err := h.recommendationService.GetTimeBasedTrendingItems(c.Request.Context(), req.Days)
 	if err != nil {
-		log.Printf("Error getting trending items: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
+		respondError(c, err, "Failed to get recommendations")
 		return
 	}
+	recommendations, ok = h.applySuppressions(c, req.suppressionParams, recommendations)
+	if !ok {
+		return
+	}
+	recommendations = h.recommendationService.FilterRecommendationsByPrice(recommendations, priceFilter)
 
-	c.JSON(http.StatusOK, gin.H{
-		"days":            days,
-		"recommendations": recommendations,
-		"strategy":        "TimeBasedTrend",
-		"description":     "Currently trending items",
+	c.JSON(http.StatusOK, recommendationListResponse{
+		recommendationMeta: newRecommendationMeta(c, "TimeBasedTrend", "Currently trending items", nil),
+		Days:               req.Days,
+		PriceFilter:        priceFilter,
+		Recommendations:    recommendations,
 	})
 }
 
 // GetHybridRecommendations handles requests for hybrid recommendations
 func (h *APIHandler) GetHybridRecommendations(c *gin.Context) {
-	userID, err := strconv.Atoi(c.Param("userId"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+	var req hybridRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get recommendations")
+		return
+	}
+	cartItem, ok := h.lookupCartItem(c, req.ItemInCart)
+	if !ok {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, cartItem)
+	if !ok {
 		return
 	}
 
-	// Optional item in cart
-	var itemInCartID *int
-	if itemIDParam := c.Query("itemInCart"); itemIDParam != "" {
-		if parsedItemID, err := strconv.Atoi(itemIDParam); err == nil {
-			itemInCartID = &parsedItemID
-		}
+	diversity := services.DiversityOptions{
+		Diversity:      req.Diversity,
+		MaxPerCategory: req.MaxPerCategory,
 	}
+	mode := services.RecommendationMode(req.Mode)
 
-	// Determine appropriate weights based on user experience
-	var weights models.HybridWeights
-	isNewUser, err := h.recommendationService.IsNewUser(c.Request.Context(), userID)
-	if err != nil {
-		log.Printf("Error checking user status: %v", err)
-		weights = h.recommendationService.GetDefaultWeights()
-	} else if isNewUser {
-		weights = h.recommendationService.GetWeightsForNewUser()
-	} else {
-		weights = h.recommendationService.GetWeightsForExperiencedUser()
-	}
-
-	// Allow weight customization via query params
-	if userFreq := c.Query("userFreq"); userFreq != "" {
-		if parsedWeight, err := strconv.ParseFloat(userFreq, 64); err == nil {
-			weights.UserFrequency = parsedWeight
-		}
-	}
-	if userCoOrders := c.Query("userCoOrders"); userCoOrders != "" {
-		if parsedWeight, err := strconv.ParseFloat(userCoOrders, 64); err == nil {
-			weights.UserCoOrders = parsedWeight
-		}
-	}
-	if globalCoOrders := c.Query("globalCoOrders"); globalCoOrders != "" {
-		if parsedWeight, err := strconv.ParseFloat(globalCoOrders, 64); err == nil {
-			weights.GlobalCoOrders = parsedWeight
-		}
-	}
-	if timeTrend := c.Query("timeTrend"); timeTrend != "" {
-		if parsedWeight, err := strconv.ParseFloat(timeTrend, 64); err == nil {
-			weights.TimeBasedTrend = parsedWeight
-		}
-	}
-
-	recommendations, err := h.recommendationService.HybridRecommendation(
-		c.Request.Context(),
-		userID,
-		itemInCartID,
-		weights,
-	)
+	version := 0
+	if req.ProfileVersion != nil {
+		version = *req.ProfileVersion
+	}
+
+	result, err := h.recommendationService.RecommendHybrid(c.Request.Context(), services.HybridQuery{
+		UserID:         req.UserID,
+		ItemInCart:     req.ItemInCart,
+		Profile:        req.Profile,
+		ProfileVersion: version,
+		Overrides:      req.weightOverrides(),
+		ExperimentUnit: c.GetHeader("X-Session-ID"),
+		Price:          priceFilter,
+		Diversity:      diversity,
+		Mode:           mode,
+		MixRatio:       req.MixRatio,
+	})
+	switch {
+	case req.Profile != "" && errors.Is(err, services.ErrWeightProfileNotFound):
+		respondInvalidFields(c, FieldError{Field: "profile", Message: "does not exist"})
+		return
+	case errors.Is(err, services.ErrInvalidWeights):
+		respondInvalidFields(c, FieldError{Field: "weights", Message: "at least one weight must be positive"})
+		return
+	case err != nil:
+		respondError(c, err, "Failed to get recommendations")
+		return
+	}
+	if result.Experiment != nil {
+		c.Header("X-Experiment", result.Experiment.Experiment+"/"+result.Experiment.Variant)
+	}
+
+	meta := newRecommendationMeta(c, "Hybrid", "Personalized recommendations based on multiple factors", req.ItemInCart)
+	meta.Experiment = result.Experiment
+	c.JSON(http.StatusOK, hybridResponse{
+		recommendationMeta: meta,
+		UserID:             req.UserID,
+		Segment:            result.Segment,
+		Profile:            result.Profile,
+		Weights:            result.Weights,
+		Diversity:          diversity,
+		Mode:               mode,
+		MixRatio:           req.MixRatio,
+		PriceFilter:        priceFilter,
+		Recommendations:    result.Recommendations,
+	})
+}
+
+// weightOverrides collects the weights the request sets itself
+func (r hybridRequest) weightOverrides() services.WeightOverrides {
+	return services.WeightOverrides{
+		UserFrequency:    r.UserFreq,
+		UserCoOrders:     r.UserCoOrders,
+		GlobalCoOrders:   r.GlobalCoOrders,
+		TimeBasedTrend:   r.TimeTrend,
+		PriceSensitivity: r.PriceSensitivity,
+		Ratings:          r.Ratings,
+	}
+}
+
+// GetBundleRecommendations handles requests for complete-the-meal bundles
+func (h *APIHandler) GetBundleRecommendations(c *gin.Context) {
+	var req bundleRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get bundles")
+		return
+	}
+	if _, ok := h.lookupCartItem(c, req.ItemInCart); !ok {
+		return
+	}
+	// The bundle total already includes the cart item, so it is not counted towards the budget twice
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, nil)
+	if !ok {
+		return
+	}
+
+	opts := services.BundleOptions{
+		ItemInCartID: req.ItemInCart,
+		Size:         req.Size,
+		Price:        priceFilter,
+		Limit:        req.Limit,
+	}
+
+	bundles, err := h.recommendationService.GetBundleRecommendations(c.Request.Context(), req.UserID, opts)
 	if err != nil {
-		log.Printf("Error getting hybrid recommendations: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
+		respondError(c, err, "Failed to get bundles")
 		return
 	}
 
-	// Limit results to top 10
-	if len(recommendations) > 10 {
-		recommendations = recommendations[:10]
+	c.JSON(http.StatusOK, bundleResponse{
+		recommendationMeta: newRecommendationMeta(c, "Bundles", "Combinations that complete your meal", opts.ItemInCartID),
+		UserID:             req.UserID,
+		PriceFilter:        opts.Price,
+		Size:               opts.Size,
+		Bundles:            bundles,
+	})
+}
+
+// GetReorderSuggestions handles requests for past orders the user can place again
+func (h *APIHandler) GetReorderSuggestions(c *gin.Context) {
+	var req reorderRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, nil)
+	if !ok {
+		return
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get reorder suggestions")
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"user_id":         userID,
-		"item_in_cart":    itemInCartID,
-		"weights":         weights,
-		"recommendations": recommendations,
-		"strategy":        "Hybrid",
-		"description":     "Personalized recommendations based on multiple factors",
+	suggestions, err := h.recommendationService.GetReorderSuggestions(c.Request.Context(), req.UserID, req.Limit, priceFilter)
+	if err != nil {
+		respondError(c, err, "Failed to get reorder suggestions")
+		return
+	}
+
+	c.JSON(http.StatusOK, reorderResponse{
+		recommendationMeta: newRecommendationMeta(c, "Reorder", "Your previous orders, ready to order again", nil),
+		UserID:             req.UserID,
+		PriceFilter:        priceFilter,
+		RecentBaskets:      suggestions.RecentBaskets,
+		RecurringBaskets:   suggestions.RecurringBaskets,
 	})
 }
 
@@ -226,36 +596,41 @@ func (h *APIHandler) GetHybridRecommendations(c *gin.Context) {
 func (h *APIHandler) GetAllItems(c *gin.Context) {
 	items, err := h.recommendationService.GetAllItems(c.Request.Context())
 	if err != nil {
-		log.Printf("Error getting all items: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get items"})
+		respondError(c, err, "Failed to get items")
+		return
+	}
+	if err := h.recommendationService.AttachRatingSummaries(c.Request.Context(), items); err != nil {
+		respondError(c, err, "Failed to get items")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"items": items,
-		"count": len(items),
+	c.JSON(http.StatusOK, itemListResponse{
+		Items: items,
+		Count: len(items),
 	})
 }
 
 // GetItemsByCategory handles requests for items in a specific category
 func (h *APIHandler) GetItemsByCategory(c *gin.Context) {
-	category := c.Param("category")
-	if category == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Category parameter is required"})
+	var req categoryItemsRequest
+	if !bindRequest(c, &req) {
 		return
 	}
 
-	items, err := h.recommendationService.GetItemsByCategory(c.Request.Context(), category)
+	items, err := h.recommendationService.GetItemsByCategory(c.Request.Context(), req.Category)
 	if err != nil {
-		log.Printf("Error getting items by category: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get items"})
+		respondError(c, err, "Failed to get items")
+		return
+	}
+	if err := h.recommendationService.AttachRatingSummaries(c.Request.Context(), items); err != nil {
+		respondError(c, err, "Failed to get items")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"category": category,
-		"items":    items,
-		"count":    len(items),
+	c.JSON(http.StatusOK, itemListResponse{
+		Category: req.Category,
+		Items:    items,
+		Count:    len(items),
 	})
 }
 
@@ -263,21 +638,89 @@ func (h *APIHandler) GetItemsByCategory(c *gin.Context) {
 func (h *APIHandler) GetAllUsers(c *gin.Context) {
 	users, err := h.recommendationService.GetAllUsers(c.Request.Context())
 	if err != nil {
-		log.Printf("Error getting all users: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get users"})
+		respondError(c, err, "Failed to get users")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"users": users,
-		"count": len(users),
+	c.JSON(http.StatusOK, userListResponse{
+		Users: users,
+		Count: len(users),
 	})
 }
 
 // GetHealth handles health check requests
 func (h *APIHandler) GetHealth(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"message": "Service is healthy",
+	c.JSON(http.StatusOK, healthResponse{
+		Status:  "ok",
+		Message: "Service is healthy",
 	})
 }
+
+// bindPriceFilter checks the price parameters against each other and turns them into a filter.
+// When a budget is given alongside an item in the cart, that item's price counts towards it.
+func bindPriceFilter(c *gin.Context, params priceParams, cartItem *models.Item) (services.PriceFilter, bool) {
+	if params.MinPrice > 0 && params.MaxPrice > 0 && params.MinPrice > params.MaxPrice {
+		respondInvalidFields(c, FieldError{Field: "maxPrice", Message: "must be at least minPrice"})
+		return services.PriceFilter{}, false
+	}
+
+	filter := services.PriceFilter{
+		MinPrice: params.MinPrice,
+		MaxPrice: params.MaxPrice,
+		Budget:   params.Budget,
+	}
+	if filter.Budget > 0 && cartItem != nil {
+		filter.CartTotal = cartItem.Price
+	}
+	return filter, true
+}
+
+// requireItem looks up the item named in the path, answering 404 when it does not exist
+func (h *APIHandler) requireItem(c *gin.Context, itemID int) (*models.Item, bool) {
+	item, err := h.recommendationService.GetItemByID(c.Request.Context(), itemID)
+	if err == nil && item == nil {
+		err = services.ErrItemNotFound
+	}
+	if err != nil {
+		respondError(c, err, "Failed to get item")
+		return nil, false
+	}
+	return item, true
+}
+
+// lookupCartItem looks up the optional item in the cart; one that does not exist is a bad request
+func (h *APIHandler) lookupCartItem(c *gin.Context, itemID *int) (*models.Item, bool) {
+	if itemID == nil {
+		return nil, true
+	}
+
+	item, err := h.recommendationService.GetItemByID(c.Request.Context(), *itemID)
+	if err != nil {
+		respondError(c, err, "Failed to get item")
+		return nil, false
+	}
+	if item == nil {
+		respondInvalidFields(c, FieldError{Field: "itemInCart", Message: "does not exist"})
+		return nil, false
+	}
+	return item, true
+}
+
+// applySuppressions drops what the given user suppressed. Without a user these strategies
+// are not personal, so suppressions only apply when one is given.
+func (h *APIHandler) applySuppressions(c *gin.Context, params suppressionParams, recommendations []models.Recommendation) ([]models.Recommendation, bool) {
+	if params.UserID == nil {
+		return recommendations, true
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), *params.UserID); err != nil {
+		respondError(c, err, "Failed to get recommendations")
+		return nil, false
+	}
+
+	recommendations, err := h.recommendationService.FilterSuppressed(c.Request.Context(), *params.UserID, recommendations)
+	if err != nil {
+		respondError(c, err, "Failed to get recommendations")
+		return nil, false
+	}
+	return recommendations, true
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// AdminAuth requires "Authorization: Bearer <token>" on admin routes.
+// With no token configured the admin API is disabled rather than left open.
+func AdminAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			abortWithError(c, http.StatusServiceUnavailable, string(services.KindUnavailable), "Admin API is disabled; set ADMIN_API_TOKEN to enable it", nil)
+			return
+		}
+
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="admin"`)
+			abortWithError(c, http.StatusUnauthorized, codeUnauthenticated, "Invalid or missing admin token", nil)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// codeUnauthenticated is the error code for admin requests without a valid token;
+// every other code is a services.ErrorKind
+const codeUnauthenticated = "unauthenticated"
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// statusByKind maps service error kinds onto HTTP statuses
+var statusByKind = map[services.ErrorKind]int{
+	services.KindInvalidArgument: http.StatusBadRequest,
+	services.KindNotFound:        http.StatusNotFound,
+	services.KindConflict:        http.StatusConflict,
+	services.KindUnsupported:     http.StatusNotImplemented,
+	services.KindUnavailable:     http.StatusServiceUnavailable,
+	services.KindTimeout:         http.StatusGatewayTimeout,
+	services.KindInternal:        http.StatusInternalServerError,
+}
+
+// respondError writes the error envelope for an error returned by a service.
+// Errors the caller can fix carry the service's message; unavailable, timeout and
+// internal errors are logged and answered with the given message instead, so
+// database details never reach clients.
+func respondError(c *gin.Context, err error, message string) {
+	kind := services.KindOf(err)
+	status, ok := statusByKind[kind]
+	if !ok {
+		kind, status = services.KindInternal, http.StatusInternalServerError
+	}
+
+	if status >= http.StatusInternalServerError && kind != services.KindUnsupported {
+		log.Printf("%s: %v", message, err)
+	} else {
+		message = err.Error()
+	}
+
+	abortWithError(c, status, string(kind), message, nil)
+}
+
+// RouteNotFound answers requests for unknown API paths with the error envelope
+func RouteNotFound(c *gin.Context) {
+	abortWithError(c, http.StatusNotFound, string(services.KindNotFound), "No route for "+c.Request.Method+" "+c.Request.URL.Path, nil)
+}
+
+// abortWithError writes the error envelope and stops the handler chain
+func abortWithError(c *gin.Context, status int, code, message string, details interface{}) {
+	c.AbortWithStatusJSON(status, ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: requestID(c),
+	})
+}
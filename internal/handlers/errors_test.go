@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serveError answers one request with respondError(err) behind the RequestID middleware
+func serveError(t *testing.T, err error, header http.Header) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/fail", func(c *gin.Context) {
+		respondError(c, err, "Failed to get recommendations")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q is not an error envelope: %v", w.Body.String(), err)
+	}
+	return w, body
+}
+
+func TestRespondError(t *testing.T) {
+	const generic = "Failed to get recommendations"
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    services.ErrorKind
+		wantMessage string
+	}{
+		{"not found", services.ErrUserNotFound, http.StatusNotFound, services.KindNotFound, "user not found"},
+		{"invalid", fmt.Errorf("%w: stars must be between 1 and 5", services.ErrInvalidRating), http.StatusBadRequest, services.KindInvalidArgument, "invalid rating: stars must be between 1 and 5"},
+		{"conflict", services.ErrEmailTaken, http.StatusConflict, services.KindConflict, "email is already in use"},
+		// Unsupported is a 5xx but says what is missing, since it holds no database details
+		{"unsupported", services.ErrStatsUnsupported, http.StatusNotImplemented, services.KindUnsupported, "event sink does not support statistics"},
+		{"timeout", fmt.Errorf("failed to query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, services.KindTimeout, generic},
+		{"unavailable", &neo4j.ConnectivityError{Inner: errors.New("dial tcp 10.0.0.7:7687: connection refused")}, http.StatusServiceUnavailable, services.KindUnavailable, generic},
+		{"internal", &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError", Msg: "Invalid input 'MATCH'"}, http.StatusInternalServerError, services.KindInternal, generic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, body := serveError(t, tt.err, nil)
+			if w.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, tt.wantStatus)
+			}
+			if body.Code != string(tt.wantCode) || body.Message != tt.wantMessage {
+				t.Errorf("got %+v, want code %q and message %q", body, tt.wantCode, tt.wantMessage)
+			}
+			if body.Details != nil {
+				t.Errorf("got details %v", body.Details)
+			}
+		})
+	}
+}
+
+func TestRespondErrorCarriesRequestID(t *testing.T) {
+	w, body := serveError(t, services.ErrUserNotFound, http.Header{requestIDHeader: {"req-42"}})
+	if body.RequestID != "req-42" || w.Header().Get(requestIDHeader) != "req-42" {
+		t.Errorf("got request ID %q and header %q, want the client's req-42", body.RequestID, w.Header().Get(requestIDHeader))
+	}
+
+	// Without one from the client, the generated ID is both echoed and in the envelope
+	w, body = serveError(t, services.ErrUserNotFound, nil)
+	if body.RequestID == "" || body.RequestID != w.Header().Get(requestIDHeader) {
+		t.Errorf("got request ID %q and header %q", body.RequestID, w.Header().Get(requestIDHeader))
+	}
+}
+
+func TestStatusByKindCoversEveryKind(t *testing.T) {
+	kinds := []services.ErrorKind{
+		services.KindInvalidArgument,
+		services.KindNotFound,
+		services.KindConflict,
+		services.KindUnsupported,
+		services.KindUnavailable,
+		services.KindTimeout,
+		services.KindInternal,
+	}
+	for _, kind := range kinds {
+		if _, ok := statusByKind[kind]; !ok {
+			t.Errorf("kind %q has no status", kind)
+		}
+	}
+}
+
+func TestRouteNotFound(t *testing.T) {
+	router := gin.New()
+	router.NoRoute(RequestID(), RouteNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/nope", nil))
+
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusNotFound || body.Code != string(services.KindNotFound) || body.Message != "No route for GET /api/v1/nope" || body.RequestID == "" {
+		t.Errorf("got %d %+v", w.Code, body)
+	}
+}
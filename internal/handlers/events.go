@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// requestIDHeader carries the ID clients quote when reporting feedback events
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey is the gin context key the request ID is stored under
+const requestIDKey = "request_id"
+
+// eventInput is one feedback event as clients report it
+type eventInput struct {
+	RequestID  string    `json:"request_id" binding:"required,max=64"`
+	Type       string    `json:"type" binding:"required,oneof=impression click add_to_cart dismiss"`
+	UserID     int       `json:"user_id" binding:"min=1"`
+	ItemID     int       `json:"item_id" binding:"min=1"`
+	Strategy   string    `json:"strategy" binding:"max=50"`
+	Position   int       `json:"position" binding:"gte=0"`
+	Experiment string    `json:"experiment" binding:"max=100"`
+	Variant    string    `json:"variant" binding:"max=100"`
+	At         time.Time `json:"at"`
+}
+
+// eventsRequest is the body accepted by the events endpoint
+type eventsRequest struct {
+	Events []eventInput `json:"events" binding:"required,min=1,max=500,dive"`
+}
+
+// eventStatsRequest is the request for click-through rates over the last few days
+type eventStatsRequest struct {
+	Days int `form:"days,default=7" binding:"min=1,max=365"`
+}
+
+// eventsRecordedResponse is the body confirming how many events were recorded; events
+// the sink skipped, such as those about unknown users or items, count as ignored
+type eventsRecordedResponse struct {
+	Recorded int `json:"recorded"`
+	Ignored  int `json:"ignored"`
+}
+
+// eventStatsResponse is the body of click-through rates per strategy
+type eventStatsResponse struct {
+	Days       int                    `json:"days"`
+	Since      time.Time              `json:"since"`
+	Strategies []models.StrategyStats `json:"strategies"`
+}
+
+// RequestID assigns every request an ID, reusing the caller's X-Request-ID when present,
+// and echoes it in the response header
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+// requestID returns the ID assigned to the current request
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// newRequestID returns a random 128-bit hex ID
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return hex.EncodeToString([]byte(time.Now().UTC().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// RecordEvents handles impression, click, add-to-cart and dismiss events for served recommendations
+func (h *APIHandler) RecordEvents(c *gin.Context) {
+	var req eventsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	events := make([]models.Event, len(req.Events))
+	for i, event := range req.Events {
+		events[i] = models.Event(event)
+	}
+
+	recorded, err := h.eventService.RecordEvents(c.Request.Context(), events)
+	if err != nil {
+		respondError(c, err, "Failed to record events")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, eventsRecordedResponse{
+		Recorded: recorded,
+		Ignored:  len(events) - recorded,
+	})
+}
+
+// GetEventStats handles requests for online click-through rates per strategy
+func (h *APIHandler) GetEventStats(c *gin.Context) {
+	var req eventStatsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	since := time.Now().UTC().AddDate(0, 0, -req.Days)
+
+	stats, err := h.eventService.GetStrategyStats(c.Request.Context(), since)
+	if err != nil {
+		respondError(c, err, "Failed to get event statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, eventStatsResponse{
+		Days:       req.Days,
+		Since:      since,
+		Strategies: stats,
+	})
+}
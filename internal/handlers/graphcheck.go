@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+)
+
+// graphCheckRequest is the request to check, and optionally repair, the derived relationships
+type graphCheckRequest struct {
+	Limit     int `form:"limit,default=100" binding:"gte=0"`
+	BatchSize int `form:"batchSize" binding:"omitempty,min=1"`
+}
+
+// graphCheckResponse is the body of a consistency check; discrepancies are capped at the limit
+type graphCheckResponse struct {
+	Report             database.GraphReport `json:"report"`
+	TotalDiscrepancies int                  `json:"total_discrepancies"`
+}
+
+// CheckDerivedGraph handles requests to diff the derived relationships against the raw orders
+func (h *APIHandler) CheckDerivedGraph(c *gin.Context) {
+	h.runGraphCheck(c, false)
+}
+
+// RepairDerivedGraph handles requests to diff the derived relationships and rewrite the ones that drifted
+func (h *APIHandler) RepairDerivedGraph(c *gin.Context) {
+	h.runGraphCheck(c, true)
+}
+
+// runGraphCheck runs the consistency check; ?limit caps the discrepancies listed (default 100)
+// and ?batchSize sets how many relationships each repair transaction rewrites
+func (h *APIHandler) runGraphCheck(c *gin.Context, repair bool) {
+	var req graphCheckRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	report, err := h.recommendationService.CheckDerivedGraph(c.Request.Context(), repair, req.BatchSize)
+	if err != nil {
+		respondError(c, err, "Failed to check derived graph")
+		return
+	}
+
+	total := len(report.Discrepancies)
+	if total > req.Limit {
+		report.Discrepancies = report.Discrepancies[:req.Limit]
+	}
+
+	c.JSON(http.StatusOK, graphCheckResponse{
+		Report:             report,
+		TotalDiscrepancies: total,
+	})
+}
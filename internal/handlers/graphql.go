@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/graph"
+)
+
+// graphQLRequest is a GraphQL query with its variables
+type graphQLRequest struct {
+	Query         string                 `json:"query" binding:"required,max=20000"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphQLResponse is the result of a GraphQL query. Errors in individual fields are
+// reported alongside whatever data resolved; a query that fails to parse or validate
+// has errors and no data.
+type graphQLResponse struct {
+	Data   interface{}    `json:"data,omitempty"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+// graphQLError is one error of a GraphQL query; the "code" extension carries the
+// same error kinds as the REST error envelope
+type graphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Locations  []graphQLLocation      `json:"locations,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// graphQLLocation is the position in the query an error refers to
+type graphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// QueryGraphQL handles GraphQL queries over the menu, users and recommendations
+func (h *APIHandler) QueryGraphQL(c *gin.Context) {
+	var req graphQLRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	result := h.graphQL.Exec(c.Request.Context(), graph.Request{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+		RequestID:     requestID(c),
+		SessionID:     c.GetHeader("X-Session-ID"),
+	})
+
+	var resp graphQLResponse
+	if result.Data != nil {
+		resp.Data = result.Data
+	}
+	for _, err := range result.Errors {
+		gqlErr := graphQLError{
+			Message:    err.Message,
+			Path:       err.Path,
+			Extensions: err.Extensions,
+		}
+		for _, loc := range err.Locations {
+			gqlErr.Locations = append(gqlErr.Locations, graphQLLocation{Line: loc.Line, Column: loc.Column})
+		}
+		resp.Errors = append(resp.Errors, gqlErr)
+	}
+	c.JSON(http.StatusOK, resp)
+}
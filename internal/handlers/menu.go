@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// itemRequest is the body accepted when creating or replacing a menu item
+type itemRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Price       float64 `json:"price" binding:"required,gt=0"`
+	Category    string  `json:"category" binding:"required,max=100"`
+	Description string  `json:"description" binding:"max=500"`
+	Available   *bool   `json:"available"`
+}
+
+// itemReplaceRequest is the request to replace every field of a menu item
+type itemReplaceRequest struct {
+	itemPath
+	itemRequest
+}
+
+// itemPatchRequest is the body accepted when changing some fields of a menu item
+type itemPatchRequest struct {
+	itemPath
+	Name        *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Price       *float64 `json:"price" binding:"omitempty,gt=0"`
+	Category    *string  `json:"category" binding:"omitempty,min=1,max=100"`
+	Description *string  `json:"description" binding:"omitempty,max=500"`
+	Available   *bool    `json:"available"`
+}
+
+// categoryPath identifies the category in paths with :name
+type categoryPath struct {
+	Category string `uri:"name" json:"-" binding:"required,max=100"`
+}
+
+// categoryRequest is the body accepted when creating a category
+type categoryRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// categoryPatchRequest is the body accepted when renaming or describing a category
+type categoryPatchRequest struct {
+	categoryPath
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+}
+
+// menuItemListResponse is the body of every menu item, withdrawn ones included
+type menuItemListResponse struct {
+	Items []models.MenuItem `json:"items"`
+	Count int               `json:"count"`
+}
+
+// itemAvailabilityResponse is the body confirming whether an item is on the menu
+type itemAvailabilityResponse struct {
+	ItemID    int  `json:"item_id"`
+	Available bool `json:"available"`
+}
+
+// categoryListResponse is the body of every category
+type categoryListResponse struct {
+	Categories []models.Category `json:"categories"`
+	Count      int               `json:"count"`
+}
+
+// ListMenuItems handles requests for every menu item, including withdrawn ones
+func (h *APIHandler) ListMenuItems(c *gin.Context) {
+	items, err := h.recommendationService.ListMenuItems(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to get items")
+		return
+	}
+
+	c.JSON(http.StatusOK, menuItemListResponse{
+		Items: items,
+		Count: len(items),
+	})
+}
+
+// GetMenuItem handles requests for one menu item, whether or not it is available
+func (h *APIHandler) GetMenuItem(c *gin.Context) {
+	var req itemPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	item, err := h.recommendationService.GetMenuItem(c.Request.Context(), req.ItemID)
+	if err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+	if item == nil {
+		respondError(c, services.ErrItemNotFound, "Failed to get menu item")
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// CreateMenuItem handles requests to add an item to the menu
+func (h *APIHandler) CreateMenuItem(c *gin.Context) {
+	var req itemRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	item, err := h.recommendationService.CreateMenuItem(c.Request.Context(), models.Item{
+		Name:        req.Name,
+		Price:       req.Price,
+		Category:    req.Category,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	if req.Available != nil && !*req.Available {
+		item, err = h.recommendationService.UpdateMenuItem(c.Request.Context(), item.DbID, services.ItemUpdate{Available: req.Available})
+		if err != nil {
+			respondError(c, err, "Failed to update menu")
+			return
+		}
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// ReplaceMenuItem handles requests to replace every field of a menu item
+func (h *APIHandler) ReplaceMenuItem(c *gin.Context) {
+	var req itemReplaceRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	available := true
+	if req.Available != nil {
+		available = *req.Available
+	}
+
+	item, err := h.recommendationService.UpdateMenuItem(c.Request.Context(), req.ItemID, services.ItemUpdate{
+		Name:        &req.Name,
+		Price:       &req.Price,
+		Category:    &req.Category,
+		Description: &req.Description,
+		Available:   &available,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// UpdateMenuItem handles requests to change some fields of a menu item
+func (h *APIHandler) UpdateMenuItem(c *gin.Context) {
+	var req itemPatchRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	item, err := h.recommendationService.UpdateMenuItem(c.Request.Context(), req.ItemID, services.ItemUpdate{
+		Name:        req.Name,
+		Price:       req.Price,
+		Category:    req.Category,
+		Description: req.Description,
+		Available:   req.Available,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// WithdrawMenuItem handles requests to take an item off the menu, keeping its order history
+func (h *APIHandler) WithdrawMenuItem(c *gin.Context) {
+	var req itemPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.WithdrawMenuItem(c.Request.Context(), req.ItemID); err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	c.JSON(http.StatusOK, itemAvailabilityResponse{
+		ItemID:    req.ItemID,
+		Available: false,
+	})
+}
+
+// ListCategories handles requests for every category with its item counts
+func (h *APIHandler) ListCategories(c *gin.Context) {
+	categories, err := h.recommendationService.ListCategories(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to get categories")
+		return
+	}
+
+	c.JSON(http.StatusOK, categoryListResponse{
+		Categories: categories,
+		Count:      len(categories),
+	})
+}
+
+// CreateCategory handles requests to add a category
+func (h *APIHandler) CreateCategory(c *gin.Context) {
+	var req categoryRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	category, err := h.recommendationService.CreateCategory(c.Request.Context(), models.Category{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory handles requests to rename a category or change its description
+func (h *APIHandler) UpdateCategory(c *gin.Context) {
+	var req categoryPatchRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	err := h.recommendationService.UpdateCategory(c.Request.Context(), req.Category, services.CategoryUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteCategory handles requests to remove an empty category
+func (h *APIHandler) DeleteCategory(c *gin.Context) {
+	var req categoryPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.DeleteCategory(c.Request.Context(), req.Category); err != nil {
+		respondError(c, err, "Failed to update menu")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
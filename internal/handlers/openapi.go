@@ -0,0 +1,631 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// Operation documents one API route. The OpenAPI document and the generated client in
+// pkg/client are both built from these, and SetupRoutes checks them against the routes
+// it registers, so neither can drift from the router.
+type Operation struct {
+	// ID names the operation in the document and the method in the client
+	ID     string
+	Method string
+	// Path uses gin's :param syntax
+	Path    string
+	Tag     string
+	Summary string
+	// Request is the struct the handler binds, or nil when it takes no input
+	Request reflect.Type
+	// Response is the success body, or nil when the handler answers without one
+	Response reflect.Type
+	Status   int
+	// Deprecated marks the unversioned alias of a v1 operation
+	Deprecated bool
+}
+
+// Admin reports whether the operation needs the admin bearer token
+func (op Operation) Admin() bool {
+	return strings.HasPrefix(op.Path, apiV1Prefix+"/admin/") || strings.HasPrefix(op.Path, legacyAPIPrefix+"/admin/")
+}
+
+// Operations lists every current API route; their deprecated aliases are left out
+func Operations() []Operation {
+	return slices.Clone(operations)
+}
+
+// deprecatedAliases lists the unversioned alias of every operation
+func deprecatedAliases() []Operation {
+	aliases := make([]Operation, 0, len(operations))
+	for _, op := range operations {
+		op.ID += "Deprecated"
+		op.Path = legacyAPIPrefix + strings.TrimPrefix(op.Path, apiV1Prefix)
+		op.Tag = "Deprecated aliases"
+		op.Deprecated = true
+		aliases = append(aliases, op)
+	}
+	return aliases
+}
+
+// undocumentedRoutes are registered by SetupRoutes but describe the API rather than belong to it
+var undocumentedRoutes = map[string]bool{
+	"GET /api/openapi.json": true,
+	"GET /api/docs":         true,
+}
+
+// operations documents the routes registered in registerRoutes, in the same order
+var operations = []Operation{
+	{ID: "GetHealth", Method: http.MethodGet, Path: "/api/v1/health", Tag: "Health", Summary: "Check service health", Response: reflect.TypeFor[healthResponse]()},
+
+	{ID: "GetAllUsers", Method: http.MethodGet, Path: "/api/v1/users", Tag: "Users", Summary: "List users", Response: reflect.TypeFor[userListResponse]()},
+	{ID: "CreateUser", Method: http.MethodPost, Path: "/api/v1/users", Tag: "Users", Summary: "Register a user; emails are unique", Request: reflect.TypeFor[userRequest](), Response: reflect.TypeFor[models.User](), Status: http.StatusCreated},
+	{ID: "GetUserProfile", Method: http.MethodGet, Path: "/api/v1/users/:userId", Tag: "Users", Summary: "Get a user with their order summary", Request: reflect.TypeFor[userPath](), Response: reflect.TypeFor[models.UserProfile]()},
+	{ID: "UpdateUser", Method: http.MethodPatch, Path: "/api/v1/users/:userId", Tag: "Users", Summary: "Change a user's name or email", Request: reflect.TypeFor[userPatchRequest](), Response: reflect.TypeFor[models.User]()},
+	{ID: "GetUserOrders", Method: http.MethodGet, Path: "/api/v1/users/:userId/orders", Tag: "Users", Summary: "Get a page of a user's orders, newest first, cancelled ones included", Request: reflect.TypeFor[userOrdersRequest](), Response: reflect.TypeFor[orderPageResponse]()},
+	{ID: "GetSuppressions", Method: http.MethodGet, Path: "/api/v1/users/:userId/suppressions", Tag: "Not interested", Summary: "List a user's active \"not interested\" marks", Request: reflect.TypeFor[userPath](), Response: reflect.TypeFor[suppressionListResponse]()},
+	{ID: "CreateSuppression", Method: http.MethodPost, Path: "/api/v1/users/:userId/suppressions", Tag: "Not interested", Summary: "Mark an item or a whole category \"not interested\"", Request: reflect.TypeFor[suppressionRequest](), Response: reflect.TypeFor[suppressionResponse](), Status: http.StatusCreated},
+	{ID: "DeleteItemSuppression", Method: http.MethodDelete, Path: "/api/v1/users/:userId/suppressions/items/:itemId", Tag: "Not interested", Summary: "Undo a \"not interested\" mark on an item", Request: reflect.TypeFor[itemSuppressionPath](), Status: http.StatusNoContent},
+	{ID: "DeleteCategorySuppression", Method: http.MethodDelete, Path: "/api/v1/users/:userId/suppressions/categories/:category", Tag: "Not interested", Summary: "Undo a \"not interested\" mark on a category", Request: reflect.TypeFor[categorySuppressionPath](), Status: http.StatusNoContent},
+
+	{ID: "GetAllItems", Method: http.MethodGet, Path: "/api/v1/items", Tag: "Items", Summary: "List menu items with their rating summaries", Response: reflect.TypeFor[itemListResponse]()},
+	{ID: "GetItemsByCategory", Method: http.MethodGet, Path: "/api/v1/items/category/:category", Tag: "Items", Summary: "List the menu items of one category", Request: reflect.TypeFor[categoryItemsRequest](), Response: reflect.TypeFor[itemListResponse]()},
+	{ID: "GetItemRatings", Method: http.MethodGet, Path: "/api/v1/items/:itemId/ratings", Tag: "Ratings", Summary: "Get an item's reviews and rating summary", Request: reflect.TypeFor[itemPath](), Response: reflect.TypeFor[itemRatingsResponse]()},
+	{ID: "RateItem", Method: http.MethodPost, Path: "/api/v1/items/:itemId/ratings", Tag: "Ratings", Summary: "Rate an item, replacing the user's earlier rating", Request: reflect.TypeFor[ratingRequest](), Response: reflect.TypeFor[models.Rating](), Status: http.StatusCreated},
+
+	{ID: "PlaceOrder", Method: http.MethodPost, Path: "/api/v1/orders", Tag: "Orders", Summary: "Place an order", Request: reflect.TypeFor[orderRequest](), Response: reflect.TypeFor[models.Order](), Status: http.StatusCreated},
+	{ID: "AmendOrder", Method: http.MethodPatch, Path: "/api/v1/orders/:orderId", Tag: "Orders", Summary: "Change item quantities of an order; quantity 0 removes an item", Request: reflect.TypeFor[amendOrderRequest](), Response: reflect.TypeFor[models.OrderChange]()},
+	{ID: "CancelOrder", Method: http.MethodDelete, Path: "/api/v1/orders/:orderId", Tag: "Orders", Summary: "Cancel an order", Request: reflect.TypeFor[cancelOrderRequest](), Response: reflect.TypeFor[models.OrderChange]()},
+	{ID: "GetOrderChanges", Method: http.MethodGet, Path: "/api/v1/orders/:orderId/changes", Tag: "Orders", Summary: "Get the audit trail of an order", Request: reflect.TypeFor[orderPath](), Response: reflect.TypeFor[orderChangesResponse]()},
+
+	{ID: "GetUserFrequentItems", Method: http.MethodGet, Path: "/api/v1/recommendations/user-frequent/:userId", Tag: "Recommendations", Summary: "Items the user orders most frequently", Request: reflect.TypeFor[userFrequentRequest](), Response: reflect.TypeFor[recommendationListResponse]()},
+	{ID: "GetUserCoOrderedItems", Method: http.MethodGet, Path: "/api/v1/recommendations/user-co-orders/:userId/:itemId", Tag: "Recommendations", Summary: "Items the user frequently orders with an item", Request: reflect.TypeFor[userCoOrdersRequest](), Response: reflect.TypeFor[recommendationListResponse]()},
+	{ID: "GetGlobalCoOrderedItems", Method: http.MethodGet, Path: "/api/v1/recommendations/global-co-orders/:itemId", Tag: "Recommendations", Summary: "Items all customers frequently order with an item", Request: reflect.TypeFor[globalCoOrdersRequest](), Response: reflect.TypeFor[recommendationListResponse]()},
+	{ID: "GetTrendingItems", Method: http.MethodGet, Path: "/api/v1/recommendations/trending", Tag: "Recommendations", Summary: "Currently trending items", Request: reflect.TypeFor[trendingRequest](), Response: reflect.TypeFor[recommendationListResponse]()},
+	{ID: "GetRatingBasedItems", Method: http.MethodGet, Path: "/api/v1/recommendations/ratings/:userId", Tag: "Recommendations", Summary: "Items rated highly by guests with similar taste", Request: reflect.TypeFor[ratingBasedRequest](), Response: reflect.TypeFor[recommendationListResponse]()},
+	{ID: "GetHybridRecommendations", Method: http.MethodGet, Path: "/api/v1/recommendations/hybrid/:userId", Tag: "Recommendations", Summary: "Personalised recommendations blending every strategy", Request: reflect.TypeFor[hybridRequest](), Response: reflect.TypeFor[hybridResponse]()},
+	{ID: "GetBundleRecommendations", Method: http.MethodGet, Path: "/api/v1/recommendations/bundles/:userId", Tag: "Recommendations", Summary: "Complete-the-meal bundles", Request: reflect.TypeFor[bundleRequest](), Response: reflect.TypeFor[bundleResponse]()},
+	{ID: "GetReorderSuggestions", Method: http.MethodGet, Path: "/api/v1/recommendations/reorder/:userId", Tag: "Recommendations", Summary: "Recent and recurring orders to place again", Request: reflect.TypeFor[reorderRequest](), Response: reflect.TypeFor[reorderResponse]()},
+
+	{ID: "RecordEvents", Method: http.MethodPost, Path: "/api/v1/events", Tag: "Feedback events", Summary: "Record what happened to served recommendations", Request: reflect.TypeFor[eventsRequest](), Response: reflect.TypeFor[eventsRecordedResponse](), Status: http.StatusAccepted},
+	{ID: "GetEventStats", Method: http.MethodGet, Path: "/api/v1/events/stats", Tag: "Feedback events", Summary: "Engagement and click-through rates per strategy", Request: reflect.TypeFor[eventStatsRequest](), Response: reflect.TypeFor[eventStatsResponse]()},
+
+	{ID: "QueryGraphQL", Method: http.MethodPost, Path: "/api/v1/graphql", Tag: "GraphQL", Summary: "Query the menu, users and recommendations in one request", Request: reflect.TypeFor[graphQLRequest](), Response: reflect.TypeFor[graphQLResponse]()},
+
+	{ID: "ListMenuItems", Method: http.MethodGet, Path: "/api/v1/admin/items", Tag: "Admin: menu", Summary: "List every menu item, withdrawn ones included", Response: reflect.TypeFor[menuItemListResponse]()},
+	{ID: "CreateMenuItem", Method: http.MethodPost, Path: "/api/v1/admin/items", Tag: "Admin: menu", Summary: "Add an item to the menu", Request: reflect.TypeFor[itemRequest](), Response: reflect.TypeFor[models.MenuItem](), Status: http.StatusCreated},
+	{ID: "GetMenuItem", Method: http.MethodGet, Path: "/api/v1/admin/items/:itemId", Tag: "Admin: menu", Summary: "Get a menu item, whether or not it is available", Request: reflect.TypeFor[itemPath](), Response: reflect.TypeFor[models.MenuItem]()},
+	{ID: "ReplaceMenuItem", Method: http.MethodPut, Path: "/api/v1/admin/items/:itemId", Tag: "Admin: menu", Summary: "Replace every field of a menu item", Request: reflect.TypeFor[itemReplaceRequest](), Response: reflect.TypeFor[models.MenuItem]()},
+	{ID: "UpdateMenuItem", Method: http.MethodPatch, Path: "/api/v1/admin/items/:itemId", Tag: "Admin: menu", Summary: "Change some fields of a menu item", Request: reflect.TypeFor[itemPatchRequest](), Response: reflect.TypeFor[models.MenuItem]()},
+	{ID: "WithdrawMenuItem", Method: http.MethodDelete, Path: "/api/v1/admin/items/:itemId", Tag: "Admin: menu", Summary: "Take an item off the menu, keeping its order history", Request: reflect.TypeFor[itemPath](), Response: reflect.TypeFor[itemAvailabilityResponse]()},
+	{ID: "ListCategories", Method: http.MethodGet, Path: "/api/v1/admin/categories", Tag: "Admin: menu", Summary: "List categories with their item counts", Response: reflect.TypeFor[categoryListResponse]()},
+	{ID: "CreateCategory", Method: http.MethodPost, Path: "/api/v1/admin/categories", Tag: "Admin: menu", Summary: "Add a category", Request: reflect.TypeFor[categoryRequest](), Response: reflect.TypeFor[models.Category](), Status: http.StatusCreated},
+	{ID: "UpdateCategory", Method: http.MethodPatch, Path: "/api/v1/admin/categories/:name", Tag: "Admin: menu", Summary: "Rename a category or change its description", Request: reflect.TypeFor[categoryPatchRequest](), Status: http.StatusNoContent},
+	{ID: "DeleteCategory", Method: http.MethodDelete, Path: "/api/v1/admin/categories/:name", Tag: "Admin: menu", Summary: "Delete a category that holds no items", Request: reflect.TypeFor[categoryPath](), Status: http.StatusNoContent},
+
+	{ID: "EraseUser", Method: http.MethodDelete, Path: "/api/v1/admin/users/:userId", Tag: "Admin: users", Summary: "Erase a user under the configured erasure policy", Request: reflect.TypeFor[userPath](), Response: reflect.TypeFor[models.ErasureReport]()},
+
+	{ID: "ListWeightProfiles", Method: http.MethodGet, Path: "/api/v1/admin/weight-profiles", Tag: "Admin: weight profiles", Summary: "List the latest version of every weight profile", Response: reflect.TypeFor[weightProfileListResponse]()},
+	{ID: "CreateWeightProfile", Method: http.MethodPost, Path: "/api/v1/admin/weight-profiles", Tag: "Admin: weight profiles", Summary: "Create a weight profile", Request: reflect.TypeFor[weightProfileRequest](), Response: reflect.TypeFor[models.WeightProfile](), Status: http.StatusCreated},
+	{ID: "GetWeightProfile", Method: http.MethodGet, Path: "/api/v1/admin/weight-profiles/:name", Tag: "Admin: weight profiles", Summary: "Get a weight profile, optionally at an older version", Request: reflect.TypeFor[weightProfileVersionRequest](), Response: reflect.TypeFor[models.WeightProfile]()},
+	{ID: "GetWeightProfileVersions", Method: http.MethodGet, Path: "/api/v1/admin/weight-profiles/:name/versions", Tag: "Admin: weight profiles", Summary: "Get every version of a weight profile", Request: reflect.TypeFor[namePath](), Response: reflect.TypeFor[weightProfileVersionsResponse]()},
+	{ID: "UpdateWeightProfile", Method: http.MethodPut, Path: "/api/v1/admin/weight-profiles/:name", Tag: "Admin: weight profiles", Summary: "Publish a new version of a weight profile", Request: reflect.TypeFor[weightProfileUpdateRequest](), Response: reflect.TypeFor[models.WeightProfile]()},
+	{ID: "DeleteWeightProfile", Method: http.MethodDelete, Path: "/api/v1/admin/weight-profiles/:name", Tag: "Admin: weight profiles", Summary: "Delete a weight profile that no segment uses", Request: reflect.TypeFor[namePath](), Status: http.StatusNoContent},
+	{ID: "GetSegmentAssignments", Method: http.MethodGet, Path: "/api/v1/admin/weight-profile-assignments", Tag: "Admin: weight profiles", Summary: "Show which profile each user segment uses", Response: reflect.TypeFor[segmentAssignmentsResponse]()},
+	{ID: "AssignWeightProfile", Method: http.MethodPut, Path: "/api/v1/admin/weight-profile-assignments/:segment", Tag: "Admin: weight profiles", Summary: "Make a user segment use a profile", Request: reflect.TypeFor[assignmentRequest](), Response: reflect.TypeFor[segmentAssignmentResponse]()},
+	{ID: "UnassignWeightProfile", Method: http.MethodDelete, Path: "/api/v1/admin/weight-profile-assignments/:segment", Tag: "Admin: weight profiles", Summary: "Return a user segment to its built-in weights", Request: reflect.TypeFor[segmentPath](), Status: http.StatusNoContent},
+
+	{ID: "ListExperiments", Method: http.MethodGet, Path: "/api/v1/admin/experiments", Tag: "Admin: experiments", Summary: "List experiments with their variants", Response: reflect.TypeFor[experimentListResponse]()},
+	{ID: "CreateExperiment", Method: http.MethodPost, Path: "/api/v1/admin/experiments", Tag: "Admin: experiments", Summary: "Define an experiment", Request: reflect.TypeFor[experimentRequest](), Response: reflect.TypeFor[models.Experiment](), Status: http.StatusCreated},
+	{ID: "GetExperiment", Method: http.MethodGet, Path: "/api/v1/admin/experiments/:name", Tag: "Admin: experiments", Summary: "Get an experiment", Request: reflect.TypeFor[namePath](), Response: reflect.TypeFor[models.Experiment]()},
+	{ID: "GetExperimentExposures", Method: http.MethodGet, Path: "/api/v1/admin/experiments/:name/exposures", Tag: "Admin: experiments", Summary: "Count how often each variant was served", Request: reflect.TypeFor[namePath](), Response: reflect.TypeFor[experimentExposuresResponse]()},
+	{ID: "StartExperiment", Method: http.MethodPost, Path: "/api/v1/admin/experiments/:name/start", Tag: "Admin: experiments", Summary: "Run an experiment, stopping any other", Request: reflect.TypeFor[namePath](), Response: reflect.TypeFor[experimentStateResponse]()},
+	{ID: "StopExperiment", Method: http.MethodPost, Path: "/api/v1/admin/experiments/:name/stop", Tag: "Admin: experiments", Summary: "Stop an experiment", Request: reflect.TypeFor[namePath](), Response: reflect.TypeFor[experimentStateResponse]()},
+	{ID: "DeleteExperiment", Method: http.MethodDelete, Path: "/api/v1/admin/experiments/:name", Tag: "Admin: experiments", Summary: "Delete an experiment", Request: reflect.TypeFor[namePath](), Status: http.StatusNoContent},
+
+	{ID: "GetUpdateQueueStats", Method: http.MethodGet, Path: "/api/v1/admin/update-queue", Tag: "Admin: derived graph", Summary: "Depth and lag of the derived-relationship update queue", Response: reflect.TypeFor[database.QueueStats]()},
+	{ID: "CheckDerivedGraph", Method: http.MethodGet, Path: "/api/v1/admin/graph-check", Tag: "Admin: derived graph", Summary: "Diff the derived relationships against the raw orders", Request: reflect.TypeFor[graphCheckRequest](), Response: reflect.TypeFor[graphCheckResponse]()},
+	{ID: "RepairDerivedGraph", Method: http.MethodPost, Path: "/api/v1/admin/graph-check/repair", Tag: "Admin: derived graph", Summary: "Diff the derived relationships and rewrite the ones that drifted", Request: reflect.TypeFor[graphCheckRequest](), Response: reflect.TypeFor[graphCheckResponse]()},
+}
+
+// openAPIDocument is the subset of OpenAPI 3.0 the API needs
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Tags       []openAPITag                            `json:"tags"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type openAPITag struct {
+	Name string `json:"name"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Tags        []string                   `json:"tags"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema        `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// openAPISchema is a JSON schema as OpenAPI 3.0 writes it
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Default              interface{}               `json:"default,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool                      `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool                      `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty"`
+	MinItems             *int                      `json:"minItems,omitempty"`
+	MaxItems             *int                      `json:"maxItems,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties interface{}               `json:"additionalProperties,omitempty"`
+}
+
+// buildOpenAPI documents the operations after checking that they match the registered
+// routes one for one
+func buildOpenAPI(routes gin.RoutesInfo) ([]byte, error) {
+	if err := verifyOperations(routes); err != nil {
+		return nil, err
+	}
+
+	schemas := openAPISchemas{components: map[string]*openAPISchema{}, types: map[string]reflect.Type{}}
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       "Restaurant Recommendation API",
+			Version:     "1.0.0",
+			Description: "Menu, orders and graph-based recommendations. Errors share one envelope; see ErrorResponse.",
+		},
+		Paths: map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: schemas.components,
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"adminToken": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	errorSchema := schemas.ref(reflect.TypeFor[ErrorResponse]())
+	schemas.components["ErrorResponse"].Properties["details"] = &openAPISchema{
+		Type:  "array",
+		Items: schemas.ref(reflect.TypeFor[FieldError]()),
+	}
+	errorResponse := func(description string) openAPIResponse {
+		return openAPIResponse{
+			Description: description,
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: errorSchema}},
+		}
+	}
+
+	var tags []string
+	for _, op := range append(Operations(), deprecatedAliases()...) {
+		if !slices.Contains(tags, op.Tag) {
+			tags = append(tags, op.Tag)
+		}
+
+		operation := &openAPIOperation{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Tags:        []string{op.Tag},
+			Deprecated:  op.Deprecated,
+			Responses:   map[string]openAPIResponse{},
+		}
+		if op.Deprecated {
+			operation.Description = fmt.Sprintf("Deprecated alias of %s %s; responses carry a Deprecation header and a successor-version Link to it.",
+				op.Method, openAPIPath(apiV1Prefix+strings.TrimPrefix(op.Path, legacyAPIPrefix)))
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := openAPIResponse{Description: http.StatusText(status)}
+		if op.Response != nil {
+			success.Content = map[string]openAPIMediaType{"application/json": {Schema: schemas.ref(op.Response)}}
+		}
+		operation.Responses[strconv.Itoa(status)] = success
+
+		if op.Request != nil {
+			operation.Parameters = schemas.parameters(op.Request)
+			if body := schemas.body(op.Request); body != nil {
+				operation.RequestBody = &openAPIRequestBody{
+					Required: len(body.Required) > 0,
+					Content:  map[string]openAPIMediaType{"application/json": {Schema: body}},
+				}
+			}
+			operation.Responses["400"] = errorResponse("The request failed validation; details lists the rejected fields")
+		}
+		if strings.Contains(op.Path, ":") {
+			operation.Responses["404"] = errorResponse("A resource named in the path does not exist")
+		}
+		if op.Admin() {
+			operation.Security = []map[string][]string{{"adminToken": {}}}
+			operation.Responses["401"] = errorResponse("Missing or wrong admin token")
+			operation.Responses["503"] = errorResponse("The admin API is disabled, or Neo4j is unavailable")
+		}
+		operation.Responses["default"] = errorResponse("Any other error")
+
+		path := openAPIPath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = operation
+	}
+	for _, tag := range tags {
+		doc.Tags = append(doc.Tags, openAPITag{Name: tag})
+	}
+
+	return json.Marshal(doc)
+}
+
+// verifyOperations fails unless every registered API route is documented and every
+// documented operation is registered
+func verifyOperations(routes gin.RoutesInfo) error {
+	registered := map[string]bool{}
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		if strings.HasPrefix(route.Path, "/api/") && !undocumentedRoutes[key] {
+			registered[key] = true
+		}
+	}
+
+	var problems []string
+	documented := map[string]bool{}
+	for _, op := range append(Operations(), deprecatedAliases()...) {
+		key := op.Method + " " + op.Path
+		if documented[key] {
+			problems = append(problems, "documented twice: "+key)
+		}
+		documented[key] = true
+		if !registered[key] {
+			problems = append(problems, "documented but not registered: "+key)
+		}
+	}
+	for key := range registered {
+		if !documented[key] {
+			problems = append(problems, "registered but not documented: "+key)
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("API operations do not match the routes: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// openAPIPath rewrites gin's :param segments as OpenAPI {param} segments
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// openAPISchemas turns Go types into schemas, collecting named structs as components
+type openAPISchemas struct {
+	components map[string]*openAPISchema
+	types      map[string]reflect.Type
+}
+
+// SchemaName is the name a named struct type goes by in the OpenAPI document and the
+// generated client: its own name, capitalised
+func SchemaName(t reflect.Type) string {
+	return strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+}
+
+// ref returns the schema of a type, registering named structs as components
+func (s openAPISchemas) ref(t reflect.Type) *openAPISchema {
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		schema := s.ref(t.Elem())
+		if schema.Ref != "" {
+			// $ref siblings are ignored in OpenAPI 3.0, so nullability is left to the reader
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case t.Kind() == reflect.Slice:
+		return &openAPISchema{Type: "array", Items: s.ref(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: s.ref(t.Elem())}
+	case t.Kind() == reflect.Interface:
+		return &openAPISchema{}
+	case t.Kind() != reflect.Struct:
+		return basicSchema(t)
+	}
+
+	name := SchemaName(t)
+	if known, ok := s.types[name]; ok && known != t {
+		panic(fmt.Sprintf("openapi: %s and %s both map to schema %s", known, t, name))
+	}
+	if _, ok := s.types[name]; !ok {
+		s.types[name] = t
+		s.components[name] = nil // reserved, so recursive types terminate
+		s.components[name] = s.object(t, func(reflect.StructField) bool { return true })
+	}
+	return &openAPISchema{Ref: "#/components/schemas/" + name}
+}
+
+// object describes the JSON fields of a struct that keep accepts
+func (s openAPISchemas) object(t reflect.Type, keep func(reflect.StructField) bool) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	for _, field := range JSONFields(t) {
+		if !keep(field) {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		property := s.ref(field.Type)
+		rules := field.Tag.Get("binding")
+		required := slices.Contains(strings.Split(rules, ","), "required")
+		if property.Ref == "" {
+			// Constraints beside a $ref are ignored, so only plain schemas take them
+			applyRules(property, rules)
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	return schema
+}
+
+// parameters describes the path (`uri`) and query (`form`) fields of a request struct
+func (s openAPISchemas) parameters(t reflect.Type) []openAPIParameter {
+	var params []openAPIParameter
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+
+		param := openAPIParameter{Schema: s.ref(field.Type)}
+		if name := field.Tag.Get("uri"); name != "" {
+			param.Name, param.In, param.Required = name, "path", true
+		} else if tag := field.Tag.Get("form"); tag != "" {
+			var fallback string
+			param.Name, fallback = formTag(tag)
+			param.In = "query"
+			param.Required = slices.Contains(strings.Split(field.Tag.Get("binding"), ","), "required")
+			if fallback != "" {
+				param.Schema.Default = typedDefault(param.Schema.Type, fallback)
+			}
+		} else {
+			continue
+		}
+		param.Schema.Nullable = false
+		applyRules(param.Schema, field.Tag.Get("binding"))
+		params = append(params, param)
+	}
+	return params
+}
+
+// body describes the JSON body of a request struct, or returns nil when it takes none
+func (s openAPISchemas) body(t reflect.Type) *openAPISchema {
+	schema := s.object(t, func(field reflect.StructField) bool {
+		return field.Tag.Get("uri") == "" && field.Tag.Get("form") == ""
+	})
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+	schema.AdditionalProperties = false
+	return schema
+}
+
+// JSONFields lists the fields encoding/json reads and writes for a struct, with the
+// fields of untagged embedded structs promoted
+func JSONFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, JSONFields(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if tag == "" {
+			field.Tag = reflect.StructTag(fmt.Sprintf(`json:"%s" %s`, field.Name, field.Tag))
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// basicSchema describes a string, number or boolean type
+func basicSchema(t reflect.Type) *openAPISchema {
+	switch t.Kind() {
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		format := "int64"
+		if t.Kind() == reflect.Int32 {
+			format = "int32"
+		}
+		return &openAPISchema{Type: "integer", Format: format}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	default:
+		panic(fmt.Sprintf("openapi: cannot describe %s", t))
+	}
+}
+
+// typedDefault converts a default from a `form` tag to the parameter's JSON type
+func typedDefault(schemaType, value string) interface{} {
+	switch schemaType {
+	case "integer":
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// applyRules copies the `binding` rules the document can express onto a schema; rules
+// after dive apply to the items
+func applyRules(schema *openAPISchema, rules string) {
+	target := schema
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if target.Items == nil || target.Items.Ref != "" {
+				return
+			}
+			target = target.Items
+		case "min", "gte":
+			setBound(target, param, true, false)
+		case "max", "lte":
+			setBound(target, param, false, false)
+		case "gt":
+			setBound(target, param, true, true)
+		case "lt":
+			setBound(target, param, false, true)
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		case "email":
+			target.Format = "email"
+		}
+	}
+}
+
+// setBound sets a lower or upper bound: a length for strings, a count for arrays and a
+// value for numbers
+func setBound(schema *openAPISchema, param string, lower, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	n := int(value)
+
+	switch schema.Type {
+	case "string":
+		if lower {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum, schema.ExclusiveMinimum = &value, exclusive
+		} else {
+			schema.Maximum, schema.ExclusiveMaximum = &value, exclusive
+		}
+	}
+}
+
+// GetOpenAPI serves the OpenAPI document
+func (h *APIHandler) GetOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", h.openAPI)
+}
+
+// GetAPIDocs serves an interactive viewer for the OpenAPI document
+func (h *APIHandler) GetAPIDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(apiDocsPage))
+}
+
+// apiDocsPage loads Swagger UI from a CDN and points it at the OpenAPI document
+const apiDocsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Restaurant Recommendation API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
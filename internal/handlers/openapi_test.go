@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetupRoutesServesOpenAPI(t *testing.T) {
+	// SetupRoutes panics if the routes and Operations disagree, so this also checks they match
+	router := gin.New()
+	NewAPIHandler(nil, nil).SetupRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string                     `json:"operationId"`
+			Deprecated  bool                       `json:"deprecated"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("got openapi %q", doc.OpenAPI)
+	}
+	if _, ok := doc.Components.Schemas["ErrorResponse"]; !ok {
+		t.Error("ErrorResponse is not a component schema")
+	}
+
+	for _, op := range append(Operations(), deprecatedAliases()...) {
+		got, ok := doc.Paths[openAPIPath(op.Path)][strings.ToLower(op.Method)]
+		if !ok {
+			t.Errorf("%s %s is not documented", op.Method, op.Path)
+			continue
+		}
+		if got.OperationID != op.ID || got.Deprecated != op.Deprecated {
+			t.Errorf("%s %s: got %+v", op.Method, op.Path, got)
+		}
+		if _, ok := got.Responses["default"]; !ok {
+			t.Errorf("%s %s has no default error response", op.Method, op.Path)
+		}
+	}
+}
+
+func TestVerifyOperations(t *testing.T) {
+	router := gin.New()
+	NewAPIHandler(nil, nil).registerRoutes(router.Group(apiV1Prefix))
+	router.GET(legacyAPIPrefix+"/extra", func(*gin.Context) {})
+
+	err := verifyOperations(router.Routes())
+	if err == nil {
+		t.Fatal("routes without their deprecated aliases passed")
+	}
+	for _, want := range []string{
+		"registered but not documented: GET /api/extra",
+		"documented but not registered: GET /api/health",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "/api/v1/") {
+		t.Errorf("error %q complains about a registered versioned route", err)
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/users":         "/api/v1/users",
+		"/api/v1/users/:userId": "/api/v1/users/{userId}",
+		"/api/v1/users/:userId/suppressions/items/:itemId": "/api/v1/users/{userId}/suppressions/items/{itemId}",
+	}
+	for path, want := range tests {
+		if got := openAPIPath(path); got != want {
+			t.Errorf("openAPIPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// orderLine is one item and quantity of a new order
+type orderLine struct {
+	ItemID   int `json:"item_id" binding:"min=1"`
+	Quantity int `json:"quantity" binding:"min=1"`
+}
+
+// amendedLine is a new quantity for one item of an order; zero removes the item
+type amendedLine struct {
+	ItemID   int `json:"item_id" binding:"min=1"`
+	Quantity int `json:"quantity" binding:"gte=0"`
+}
+
+// orderRequest is the body accepted when placing an order
+type orderRequest struct {
+	UserID int         `json:"user_id" binding:"required,min=1"`
+	Items  []orderLine `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// amendOrderRequest is the body accepted when changing quantities of an order
+type amendOrderRequest struct {
+	orderPath
+	Items  []amendedLine `json:"items" binding:"required,min=1,max=100,dive"`
+	Reason string        `json:"reason" binding:"max=500"`
+}
+
+// cancelOrderRequest is the request to cancel an order, with an optional reason
+type cancelOrderRequest struct {
+	orderPath
+	Reason string `form:"reason" binding:"max=500"`
+}
+
+// orderChangesResponse is the body of an order's audit trail
+type orderChangesResponse struct {
+	OrderID int                  `json:"order_id"`
+	Changes []models.OrderChange `json:"changes"`
+	Count   int                  `json:"count"`
+}
+
+// PlaceOrder handles requests to place a new order
+func (h *APIHandler) PlaceOrder(c *gin.Context) {
+	var req orderRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	items := make([]models.OrderItem, len(req.Items))
+	for i, line := range req.Items {
+		items[i] = models.OrderItem{ItemID: line.ItemID, Quantity: line.Quantity}
+	}
+
+	order, err := h.recommendationService.PlaceOrder(c.Request.Context(), req.UserID, items)
+	if err != nil {
+		respondError(c, err, "Failed to process order")
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// CancelOrder handles requests to cancel an order; an optional reason is kept in the audit trail
+func (h *APIHandler) CancelOrder(c *gin.Context) {
+	var req cancelOrderRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	change, err := h.recommendationService.CancelOrder(c.Request.Context(), req.OrderID, req.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to process order")
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
+
+// AmendOrder handles requests to change item quantities of an order
+func (h *APIHandler) AmendOrder(c *gin.Context) {
+	var req amendOrderRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	items := make([]models.OrderItem, len(req.Items))
+	for i, line := range req.Items {
+		items[i] = models.OrderItem{ItemID: line.ItemID, Quantity: line.Quantity}
+	}
+
+	change, err := h.recommendationService.AmendOrder(c.Request.Context(), req.OrderID, items, req.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to process order")
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
+
+// GetOrderChanges handles requests for the audit trail of an order
+func (h *APIHandler) GetOrderChanges(c *gin.Context) {
+	var req orderPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	changes, err := h.recommendationService.GetOrderChanges(c.Request.Context(), req.OrderID)
+	if err != nil {
+		respondError(c, err, "Failed to process order")
+		return
+	}
+
+	c.JSON(http.StatusOK, orderChangesResponse{
+		OrderID: req.OrderID,
+		Changes: changes,
+		Count:   len(changes),
+	})
+}
+
+// GetUpdateQueueStats handles requests for the depth and lag of the derived-relationship queue
+func (h *APIHandler) GetUpdateQueueStats(c *gin.Context) {
+	stats, ok := h.recommendationService.GetUpdateQueueStats()
+	if !ok {
+		abortWithError(c, http.StatusNotFound, string(services.KindNotFound), "Orders are applied synchronously; no update queue is running", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// ratingRequest is the body accepted when rating an item
+type ratingRequest struct {
+	itemPath
+	UserID  int    `json:"user_id" binding:"required,min=1"`
+	Stars   int    `json:"stars" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+// ratingBasedRequest is the request for items rated highly by similar guests
+type ratingBasedRequest struct {
+	userPath
+	priceParams
+}
+
+// itemRatingsResponse is the body of an item's reviews and rating summary
+type itemRatingsResponse struct {
+	ItemID  int                   `json:"item_id"`
+	Summary *models.RatingSummary `json:"summary"`
+	Ratings []models.Rating       `json:"ratings"`
+	Count   int                   `json:"count"`
+}
+
+// RateItem handles requests to rate and review an item
+func (h *APIHandler) RateItem(c *gin.Context) {
+	var req ratingRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	rating, err := h.recommendationService.RateItem(c.Request.Context(), models.Rating{
+		UserID:  req.UserID,
+		ItemID:  req.ItemID,
+		Stars:   req.Stars,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to rate item")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rating)
+}
+
+// GetItemRatings handles requests for an item's reviews and rating aggregates
+func (h *APIHandler) GetItemRatings(c *gin.Context) {
+	var req itemPath
+	if !bindRequest(c, &req) {
+		return
+	}
+	if _, ok := h.requireItem(c, req.ItemID); !ok {
+		return
+	}
+
+	ratings, err := h.recommendationService.GetItemRatings(c.Request.Context(), req.ItemID)
+	if err != nil {
+		respondError(c, err, "Failed to get ratings")
+		return
+	}
+
+	summaries, err := h.recommendationService.GetRatingSummaries(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to get ratings")
+		return
+	}
+
+	var summary *models.RatingSummary
+	if itemSummary, ok := summaries[req.ItemID]; ok {
+		summary = &itemSummary
+	}
+
+	c.JSON(http.StatusOK, itemRatingsResponse{
+		ItemID:  req.ItemID,
+		Summary: summary,
+		Ratings: ratings,
+		Count:   len(ratings),
+	})
+}
+
+// GetRatingBasedItems handles requests for items rated highly by guests with similar taste
+func (h *APIHandler) GetRatingBasedItems(c *gin.Context) {
+	var req ratingBasedRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	priceFilter, ok := bindPriceFilter(c, req.priceParams, nil)
+	if !ok {
+		return
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get recommendations")
+		return
+	}
+
+	ratingRecs, err := h.recommendationService.GetRatingBasedItems(c.Request.Context(), req.UserID)
+	if err != nil {
+		respondError(c, err, "Failed to get recommendations")
+		return
+	}
+
+	// Negative scores only exist to demote items in the hybrid; they are not recommendations
+	var recommendations []models.Recommendation
+	for _, rec := range ratingRecs {
+		if rec.Score > 0 {
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	recommendations = h.recommendationService.FilterRecommendationsByPrice(recommendations, priceFilter)
+
+	c.JSON(http.StatusOK, recommendationListResponse{
+		recommendationMeta: newRecommendationMeta(c, "Ratings", "Items rated highly by guests with similar taste", nil),
+		UserID:             &req.UserID,
+		PriceFilter:        priceFilter,
+		Recommendations:    recommendations,
+	})
+}
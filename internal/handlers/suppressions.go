@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// suppressionRequest is the body accepted when marking an item or category "not interested";
+// exactly one of the fields must be set
+type suppressionRequest struct {
+	userPath
+	ItemID   *int   `json:"item_id" binding:"omitempty,min=1"`
+	Category string `json:"category" binding:"max=100"`
+}
+
+// itemSuppressionPath identifies an item suppression to undo
+type itemSuppressionPath struct {
+	userPath
+	itemPath
+}
+
+// categorySuppressionPath identifies a category suppression to undo
+type categorySuppressionPath struct {
+	userPath
+	Category string `uri:"category" binding:"required,max=100"`
+}
+
+// suppressionListResponse is the body of a user's active "not interested" marks
+type suppressionListResponse struct {
+	UserID       int                  `json:"user_id"`
+	Suppressions []models.Suppression `json:"suppressions"`
+	Count        int                  `json:"count"`
+}
+
+// suppressionResponse is the body echoing a new "not interested" mark
+type suppressionResponse struct {
+	UserID   int    `json:"user_id"`
+	ItemID   *int   `json:"item_id"`
+	Category string `json:"category"`
+}
+
+// GetSuppressions handles requests for a user's active "not interested" marks
+func (h *APIHandler) GetSuppressions(c *gin.Context) {
+	var req userPath
+	if !bindRequest(c, &req) {
+		return
+	}
+	if err := h.recommendationService.RequireUser(c.Request.Context(), req.UserID); err != nil {
+		respondError(c, err, "Failed to get suppressions")
+		return
+	}
+
+	suppressions, err := h.recommendationService.GetSuppressions(c.Request.Context(), req.UserID)
+	if err != nil {
+		respondError(c, err, "Failed to get suppressions")
+		return
+	}
+
+	c.JSON(http.StatusOK, suppressionListResponse{
+		UserID:       req.UserID,
+		Suppressions: suppressions,
+		Count:        len(suppressions),
+	})
+}
+
+// CreateSuppression handles requests to mark an item or a whole category "not interested"
+func (h *APIHandler) CreateSuppression(c *gin.Context) {
+	var req suppressionRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if (req.ItemID == nil) == (req.Category == "") {
+		respondInvalidFields(c, FieldError{Field: "item_id", Message: "provide either item_id or category"})
+		return
+	}
+
+	var err error
+	if req.ItemID != nil {
+		err = h.recommendationService.SuppressItem(c.Request.Context(), req.UserID, *req.ItemID)
+	} else {
+		err = h.recommendationService.SuppressCategory(c.Request.Context(), req.UserID, req.Category)
+	}
+	if err != nil {
+		respondError(c, err, "Failed to manage suppressions")
+		return
+	}
+
+	c.JSON(http.StatusCreated, suppressionResponse{
+		UserID:   req.UserID,
+		ItemID:   req.ItemID,
+		Category: req.Category,
+	})
+}
+
+// DeleteItemSuppression handles requests to undo a "not interested" mark on an item
+func (h *APIHandler) DeleteItemSuppression(c *gin.Context) {
+	var req itemSuppressionPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.UnsuppressItem(c.Request.Context(), req.UserID, req.ItemID); err != nil {
+		respondError(c, err, "Failed to manage suppressions")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteCategorySuppression handles requests to undo a "not interested" mark on a category
+func (h *APIHandler) DeleteCategorySuppression(c *gin.Context) {
+	var req categorySuppressionPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	if err := h.recommendationService.UnsuppressCategory(c.Request.Context(), req.UserID, req.Category); err != nil {
+		respondError(c, err, "Failed to manage suppressions")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// userRequest is the body accepted when creating a user
+type userRequest struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+// userPatchRequest is the body accepted when changing a user's name or email
+type userPatchRequest struct {
+	userPath
+	Name  *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Email *string `json:"email" binding:"omitempty,email"`
+}
+
+// userOrdersRequest is the request for a page of a user's order history
+type userOrdersRequest struct {
+	userPath
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"pageSize,default=20" binding:"min=1,max=100"`
+}
+
+// orderPageResponse is the body of one page of a user's order history
+type orderPageResponse struct {
+	UserID   int            `json:"user_id"`
+	Orders   []models.Order `json:"orders"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Total    int            `json:"total"`
+	HasMore  bool           `json:"has_more"`
+}
+
+// GetUserProfile handles requests for a user's profile and order summary
+func (h *APIHandler) GetUserProfile(c *gin.Context) {
+	var req userPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	profile, err := h.recommendationService.GetUserProfile(c.Request.Context(), req.UserID)
+	if err != nil {
+		respondError(c, err, "Failed to process user")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetUserOrders handles requests for a page of a user's order history (?page, default 1; ?pageSize, default 20)
+func (h *APIHandler) GetUserOrders(c *gin.Context) {
+	var req userOrdersRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	orders, total, err := h.recommendationService.GetUserOrders(c.Request.Context(), req.UserID, req.Page, req.PageSize)
+	if err != nil {
+		respondError(c, err, "Failed to process user")
+		return
+	}
+
+	c.JSON(http.StatusOK, orderPageResponse{
+		UserID:   req.UserID,
+		Orders:   orders,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		HasMore:  req.Page*req.PageSize < total,
+	})
+}
+
+// CreateUser handles requests to register a user
+func (h *APIHandler) CreateUser(c *gin.Context) {
+	var req userRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	user, err := h.recommendationService.CreateUser(c.Request.Context(), models.User{
+		Name:  req.Name,
+		Email: req.Email,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to process user")
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// UpdateUser handles requests to change a user's name or email
+func (h *APIHandler) UpdateUser(c *gin.Context) {
+	var req userPatchRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	user, err := h.recommendationService.UpdateUser(c.Request.Context(), req.UserID, services.UserUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to process user")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// EraseUser handles right-to-be-forgotten requests, applying the configured erasure policy
+func (h *APIHandler) EraseUser(c *gin.Context) {
+	var req userPath
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	report, err := h.recommendationService.EraseUser(c.Request.Context(), req.UserID)
+	if err != nil {
+		respondError(c, err, "Failed to process user")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+	"github.com/yishak-cs/Neo4j_DB/internal/services"
+)
+
+// FieldError is one rejected request field, listed in the details of a 400 response
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// embeddedFieldName stands in for embedded structs in validation namespaces so they can be
+// dropped; their fields are promoted and clients see them at the top level
+const embeddedFieldName = "~"
+
+// registerFieldNames makes validation errors name fields the way clients send them
+var registerFieldNames sync.Once
+
+// bindRequest fills req, a pointer to a request struct, from the path (`uri` tags), the
+// query string (`form` tags, which may carry a default) and, for POST, PUT and PATCH, a JSON body (`json` tags)
+// that may not contain unknown fields; it then checks the `binding` rules. On failure
+// it answers 400 with one FieldError per bad field and returns false.
+func bindRequest(c *gin.Context, req interface{}) bool {
+	fields := bindParams(c, reflect.ValueOf(req).Elem())
+
+	switch c.Request.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		if fieldErr := bindBody(c, req); fieldErr != nil {
+			fields = append(fields, *fieldErr)
+		}
+	}
+
+	// Rules are only worth checking on values that parsed
+	if len(fields) == 0 {
+		fields = validateRequest(req)
+	}
+	if len(fields) > 0 {
+		respondInvalidFields(c, fields...)
+		return false
+	}
+	return true
+}
+
+// respondInvalidFields answers 400 listing the rejected fields
+func respondInvalidFields(c *gin.Context, fields ...FieldError) {
+	abortWithError(c, http.StatusBadRequest, string(services.KindInvalidArgument), "Request validation failed", fields)
+}
+
+// bindParams parses the path and query parameters of a request into the tagged fields of out
+func bindParams(c *gin.Context, out reflect.Value) []FieldError {
+	var fields []FieldError
+	for _, field := range reflect.VisibleFields(out.Type()) {
+		if !field.IsExported() {
+			continue
+		}
+
+		var name, raw string
+		var present bool
+		if name = field.Tag.Get("uri"); name != "" {
+			raw = c.Param(name)
+			present = raw != ""
+		} else if tag := field.Tag.Get("form"); tag != "" {
+			var fallback string
+			name, fallback = formTag(tag)
+			if raw, present = c.GetQuery(name); !present && fallback != "" {
+				raw, present = fallback, true
+			}
+		} else {
+			continue
+		}
+		if !present {
+			continue
+		}
+
+		if err := setParam(out.FieldByIndex(field.Index), raw); err != nil {
+			fields = append(fields, FieldError{Field: name, Message: err.Error()})
+		}
+	}
+	return fields
+}
+
+// formTag splits a `form` tag into the parameter name and its default, written gin's way
+// as `form:"days,default=7"`
+func formTag(tag string) (name, fallback string) {
+	name, options, _ := strings.Cut(tag, ",")
+	fallback, _ = strings.CutPrefix(options, "default=")
+	return name, fallback
+}
+
+// setParam parses one parameter into a string, integer, float or bool field, or a pointer to one
+func setParam(dst reflect.Value, raw string) error {
+	if dst.Kind() == reflect.Pointer {
+		elem := reflect.New(dst.Type().Elem())
+		if err := setParam(elem.Elem(), raw); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		dst.SetInt(i)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		dst.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		dst.SetBool(b)
+	default:
+		return fmt.Errorf("cannot be bound to %s", dst.Type())
+	}
+	return nil
+}
+
+// bindBody decodes a JSON body into req, treating an empty body as an empty object
+func bindBody(c *gin.Context, req interface{}) *FieldError {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return &FieldError{Field: "body", Message: "could not be read"}
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(req)
+	if err == nil && decoder.More() {
+		return &FieldError{Field: "body", Message: "must hold a single JSON object"}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return &FieldError{Field: typeErr.Field, Message: "must be " + jsonTypeName(typeErr.Type)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return &FieldError{Field: field, Message: "is not a known field"}
+	default:
+		return &FieldError{Field: "body", Message: "must be a JSON object"}
+	}
+}
+
+// jsonTypeName describes a Go type the way a JSON client thinks of it
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	default:
+		return "an object"
+	}
+}
+
+// validateRequest checks the `binding` rules of req with gin's validator
+func validateRequest(req interface{}) []FieldError {
+	registerFieldNames.Do(func() {
+		if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+			engine.RegisterTagNameFunc(requestFieldName)
+		}
+	})
+
+	err := binding.Validator.ValidateStruct(req)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return []FieldError{{Field: "body", Message: err.Error()}}
+	}
+
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		// The namespace starts with the request type's name, which means nothing to clients
+		_, name, _ := strings.Cut(fieldErr.Namespace(), ".")
+		name = strings.ReplaceAll(name, embeddedFieldName+".", "")
+		fields = append(fields, FieldError{Field: name, Message: ruleMessage(fieldErr)})
+	}
+	return fields
+}
+
+// requestFieldName names a struct field by its uri, form or json tag
+func requestFieldName(field reflect.StructField) string {
+	if field.Anonymous {
+		return embeddedFieldName
+	}
+	for _, key := range []string{"uri", "form", "json"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			continue
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// ruleMessage explains a failed validation rule
+func ruleMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	verb, unit := "must be", ""
+	switch fieldErr.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Map:
+		verb, unit = "must have", " entries"
+		if param == "1" {
+			unit = " entry"
+		}
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		return verb + " at least " + param + unit
+	case "max", "lte":
+		return verb + " at most " + param + unit
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(param, " ", ", ")
+	case "email":
+		return "must be an email address"
+	default:
+		return "fails the " + fieldErr.Tag() + " rule"
+	}
+}
+
+// userPath identifies the user in paths with :userId
+type userPath struct {
+	UserID int `uri:"userId" json:"-" binding:"min=1"`
+}
+
+// itemPath identifies the item in paths with :itemId
+type itemPath struct {
+	ItemID int `uri:"itemId" json:"-" binding:"min=1"`
+}
+
+// orderPath identifies the order in paths with :orderId
+type orderPath struct {
+	OrderID int `uri:"orderId" json:"-" binding:"min=1"`
+}
+
+// namePath identifies a weight profile or experiment in paths with :name
+type namePath struct {
+	Name string `uri:"name" json:"-" binding:"required,max=100"`
+}
+
+// requirePositiveWeight rejects hybrid weights that are all zero; negative weights
+// are already caught by the binding rules
+func requirePositiveWeight(c *gin.Context, weights models.HybridWeights) bool {
+	if err := services.ValidateWeights(weights); err != nil {
+		respondInvalidFields(c, FieldError{Field: "weights", Message: "at least one weight must be positive"})
+		return false
+	}
+	return true
+}
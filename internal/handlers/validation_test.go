@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// bindingPaging is embedded like the request structs embed their shared parameters
+type bindingPaging struct {
+	Limit int `form:"limit,default=20" json:"-" binding:"min=1,max=100"`
+}
+
+// bindingRequest exercises every kind of field bindRequest handles
+type bindingRequest struct {
+	userPath
+	bindingPaging
+	Days *int     `form:"days" json:"-" binding:"omitempty,min=1"`
+	Name string   `json:"name" binding:"required,max=5"`
+	Tags []string `json:"tags" binding:"max=2"`
+	Mode string   `json:"mode" binding:"omitempty,oneof=fast slow"`
+}
+
+// bindingResponse is what the test route echoes back after a successful bind
+type bindingResponse struct {
+	UserID int      `json:"user_id"`
+	Limit  int      `json:"limit"`
+	Days   *int     `json:"days"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags"`
+}
+
+// serveBinding sends one request to a route that binds a bindingRequest
+func serveBinding(t *testing.T, method, target, body string) (int, bindingResponse, []FieldError) {
+	t.Helper()
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		var req bindingRequest
+		if !bindRequest(c, &req) {
+			return
+		}
+		c.JSON(http.StatusOK, bindingResponse{req.UserID, req.Limit, req.Days, req.Name, req.Tags})
+	}
+	router.GET("/users/:userId", handler)
+	router.POST("/users/:userId", handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		var resp bindingResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("body %q: %v", w.Body.String(), err)
+		}
+		return w.Code, resp, nil
+	}
+
+	var envelope struct {
+		Code    string       `json:"code"`
+		Details []FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("body %q: %v", w.Body.String(), err)
+	}
+	if envelope.Code != "invalid_argument" {
+		t.Errorf("got code %q, want invalid_argument", envelope.Code)
+	}
+	return w.Code, bindingResponse{}, envelope.Details
+}
+
+func TestBindRequestParams(t *testing.T) {
+	code, resp, _ := serveBinding(t, http.MethodPost, "/users/5", `{"name": "Abebe"}`)
+	if code != http.StatusOK || resp.UserID != 5 || resp.Limit != 20 || resp.Days != nil {
+		t.Errorf("defaults: got %d %+v, want the default limit and no days", code, resp)
+	}
+
+	code, resp, _ = serveBinding(t, http.MethodPost, "/users/5?limit=7&days=3", `{"name": "Abebe"}`)
+	if code != http.StatusOK || resp.Limit != 7 || resp.Days == nil || *resp.Days != 3 {
+		t.Errorf("query: got %d %+v, want limit 7 and days 3", code, resp)
+	}
+}
+
+func TestBindRequestRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   []FieldError
+	}{
+		{
+			name:   "unparsable query",
+			method: http.MethodGet,
+			target: "/users/5?limit=ten&days=2.5",
+			want:   []FieldError{{"limit", "must be an integer"}, {"days", "must be an integer"}},
+		},
+		{
+			// Embedded structs are stripped from the name, so clients see userId and limit
+			name:   "embedded fields",
+			method: http.MethodPost,
+			target: "/users/0?limit=500",
+			body:   `{"name": "Abebe"}`,
+			want:   []FieldError{{"userId", "must be at least 1"}, {"limit", "must be at most 100"}},
+		},
+		{
+			name:   "pointer rule",
+			method: http.MethodPost,
+			target: "/users/5?days=0",
+			body:   `{"name": "Abebe"}`,
+			want:   []FieldError{{"days", "must be at least 1"}},
+		},
+		{
+			name:   "empty body is an empty object",
+			method: http.MethodPost,
+			target: "/users/5",
+			want:   []FieldError{{"name", "is required"}},
+		},
+		{
+			name:   "rules",
+			method: http.MethodPost,
+			target: "/users/5",
+			body:   `{"name": "Abebech", "tags": ["a", "b", "c"], "mode": "medium"}`,
+			want: []FieldError{
+				{"name", "must be at most 5 characters"},
+				{"tags", "must have at most 2 entries"},
+				{"mode", "must be one of fast, slow"},
+			},
+		},
+		{
+			name:   "unknown field",
+			method: http.MethodPost,
+			target: "/users/5",
+			body:   `{"name": "Abebe", "nmae": "Abebe"}`,
+			want:   []FieldError{{"nmae", "is not a known field"}},
+		},
+		{
+			name:   "wrong type",
+			method: http.MethodPost,
+			target: "/users/5",
+			body:   `{"name": 5}`,
+			want:   []FieldError{{"name", "must be a string"}},
+		},
+		{
+			name:   "two objects",
+			method: http.MethodPost,
+			target: "/users/5",
+			body:   `{"name": "Abebe"} {"name": "Kebede"}`,
+			want:   []FieldError{{"body", "must hold a single JSON object"}},
+		},
+		{
+			name:   "not an object",
+			method: http.MethodPost,
+			target: "/users/5",
+			body:   `["Abebe"]`,
+			want:   []FieldError{{"body", "must be a JSON object"}},
+		},
+		{
+			// Parse errors are reported together; rules only run once everything parsed
+			name:   "parse errors first",
+			method: http.MethodPost,
+			target: "/users/5?limit=x",
+			body:   `{"nmae": ""}`,
+			want:   []FieldError{{"limit", "must be an integer"}, {"nmae", "is not a known field"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, details := serveBinding(t, tt.method, tt.target, tt.body)
+			if code != http.StatusBadRequest {
+				t.Fatalf("got status %d, want 400", code)
+			}
+			if !slices.Equal(details, tt.want) {
+				t.Errorf("got details %v, want %v", details, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormTag(t *testing.T) {
+	tests := []struct {
+		tag, name, fallback string
+	}{
+		{"days", "days", ""},
+		{"days,default=7", "days", "7"},
+		{"mode,default=hybrid", "mode", "hybrid"},
+	}
+
+	for _, tt := range tests {
+		if name, fallback := formTag(tt.tag); name != tt.name || fallback != tt.fallback {
+			t.Errorf("formTag(%q) = %q, %q", tt.tag, name, fallback)
+		}
+	}
+}
+
+func TestRequirePositiveWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights models.HybridWeights
+		want    int
+	}{
+		{"all zero", models.HybridWeights{}, http.StatusBadRequest},
+		{"one positive", models.HybridWeights{Ratings: 0.2}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/weights", func(c *gin.Context) {
+				if requirePositiveWeight(c, tt.weights) {
+					c.Status(http.StatusOK)
+				}
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/weights", nil))
+			if w.Code != tt.want {
+				t.Fatalf("got status %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusBadRequest && !strings.Contains(w.Body.String(), `{"field":"weights","message":"at least one weight must be positive"}`) {
+				t.Errorf("got body %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// apiV1Prefix is where the current version of the API is served
+	apiV1Prefix = "/api/v1"
+	// legacyAPIPrefix served the API before it was versioned; its routes are deprecated aliases of v1
+	legacyAPIPrefix = "/api"
+)
+
+// legacyAPIDeprecatedAt is when the unversioned paths were deprecated in favour of /api/v1
+var legacyAPIDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// deprecatedAlias marks every response under prefix as deprecated (RFC 9745) and links
+// it to the same route under successor, so clients can find where to move
+func deprecatedAlias(prefix, successor string, since time.Time) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	return func(c *gin.Context) {
+		path := successor + strings.TrimPrefix(c.Request.URL.Path, prefix)
+		c.Header("Deprecation", deprecation)
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, path))
+		c.Next()
+	}
+}
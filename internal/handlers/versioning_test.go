@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeprecatedAlias(t *testing.T) {
+	since := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	router := gin.New()
+	router.GET("/api/users/:userId/orders", deprecatedAlias("/api", "/api/v1", since), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/7/orders?limit=5", nil))
+
+	if got := w.Header().Get("Deprecation"); got != "@1792281600" {
+		t.Errorf("got Deprecation %q, want @1792281600", got)
+	}
+	// The link names the concrete path that was asked for, without the query
+	if got := w.Header().Get("Link"); got != `</api/v1/users/7/orders>; rel="successor-version"` {
+		t.Errorf("got Link %q", got)
+	}
+}
+
+func TestSetupRoutesMarksOnlyLegacyPaths(t *testing.T) {
+	router := gin.New()
+	NewAPIHandler(nil, nil).SetupRoutes(router)
+
+	// A rejected request never reaches the service, and errors must carry the headers too
+	tests := []struct {
+		path           string
+		wantDeprecated bool
+	}{
+		{"/api/users/0", true},
+		{"/api/v1/users/0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("got status %d, want 400", w.Code)
+			}
+
+			deprecation, link := w.Header().Get("Deprecation"), w.Header().Get("Link")
+			if !tt.wantDeprecated {
+				if deprecation != "" || link != "" {
+					t.Errorf("got Deprecation %q and Link %q on a current path", deprecation, link)
+				}
+				return
+			}
+			if deprecation == "" || link != `</api/v1/users/0>; rel="successor-version"` {
+				t.Errorf("got Deprecation %q and Link %q", deprecation, link)
+			}
+		})
+	}
+}
@@ -10,28 +10,128 @@ type User struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// UserStats summarises a user's order history
+type UserStats struct {
+	OrderCount        int        `json:"order_count"`
+	LifetimeSpend     float64    `json:"lifetime_spend"`
+	FavouriteCategory string     `json:"favourite_category,omitempty"`
+	LastOrderAt       *time.Time `json:"last_order_at,omitempty"`
+}
+
+// UserProfile is a user together with their order summary
+type UserProfile struct {
+	User
+	Stats UserStats `json:"stats"`
+}
+
+// ErasureReport records what a right-to-be-forgotten request removed
+type ErasureReport struct {
+	UserID         int       `json:"user_id"`
+	Policy         string    `json:"policy"`
+	OrdersDeleted  int       `json:"orders_deleted"`
+	OrdersDetached int       `json:"orders_detached"`
+	ErasedAt       time.Time `json:"erased_at"`
+}
+
 // Item represents a menu item
 type Item struct {
-	DbID        int     `json:"db_id"`
-	Name        string  `json:"name"`
-	Price       float64 `json:"price"`
-	Category    string  `json:"category"`
-	Description string  `json:"description,omitempty"`
+	DbID        int            `json:"db_id"`
+	Name        string         `json:"name"`
+	Price       float64        `json:"price"`
+	Category    string         `json:"category"`
+	Description string         `json:"description,omitempty"`
+	Ratings     *RatingSummary `json:"ratings,omitempty"`
+}
+
+// MenuItem is an item as the admin API manages it, including items withdrawn from the menu
+type MenuItem struct {
+	Item
+	Available bool `json:"available"`
+}
+
+// Category is a menu category with how many items it holds
+type Category struct {
+	Name           string `json:"name"`
+	Description    string `json:"description,omitempty"`
+	Items          int    `json:"items"`
+	AvailableItems int    `json:"available_items"`
+}
+
+// RatingSummary aggregates the star ratings an item has received
+type RatingSummary struct {
+	Mean            float64 `json:"mean"`
+	Count           int     `json:"count"`
+	BayesianAverage float64 `json:"bayesian_average"`
+}
+
+// Rating is one user's star rating and optional review of an item
+type Rating struct {
+	UserID  int       `json:"user_id"`
+	ItemID  int       `json:"item_id"`
+	Stars   int       `json:"stars"`
+	Comment string    `json:"comment,omitempty"`
+	At      time.Time `json:"at"`
 }
 
 // Order represents a customer order
 type Order struct {
-	DbID        int       `json:"db_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	TotalAmount float64   `json:"total_amount"`
+	DbID        int         `json:"db_id"`
+	UserID      int         `json:"user_id,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	TotalAmount float64     `json:"total_amount"`
+	Status      string      `json:"status,omitempty"`
+	Items       []OrderItem `json:"items,omitempty"`
 }
 
 // HybridWeights represents the weights for different recommendation strategies
 type HybridWeights struct {
-	UserFrequency  float64 `json:"user_frequency"`
-	UserCoOrders   float64 `json:"user_co_orders"`
-	GlobalCoOrders float64 `json:"global_co_orders"`
-	TimeBasedTrend float64 `json:"time_based_trend"`
+	UserFrequency    float64 `json:"user_frequency"`
+	UserCoOrders     float64 `json:"user_co_orders"`
+	GlobalCoOrders   float64 `json:"global_co_orders"`
+	TimeBasedTrend   float64 `json:"time_based_trend"`
+	PriceSensitivity float64 `json:"price_sensitivity"`
+	Ratings          float64 `json:"ratings"`
+}
+
+// SegmentWeights holds the hybrid weights used for each user segment
+type SegmentWeights struct {
+	Default         HybridWeights `json:"default"`
+	NewUser         HybridWeights `json:"new_user"`
+	ExperiencedUser HybridWeights `json:"experienced_user"`
+}
+
+// WeightProfile is a named, versioned set of hybrid weights managed at runtime
+type WeightProfile struct {
+	Name        string        `json:"name"`
+	Version     int           `json:"version"`
+	Description string        `json:"description"`
+	Weights     HybridWeights `json:"weights"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// Experiment is an A/B test that splits hybrid recommendation traffic between variants
+type Experiment struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Active      bool                `json:"active"`
+	Variants    []ExperimentVariant `json:"variants"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// ExperimentVariant is one arm of an experiment. It uses a weight profile and/or
+// restricts the hybrid to a set of strategies; a variant with neither is the control.
+type ExperimentVariant struct {
+	Name       string   `json:"name"`
+	Allocation int      `json:"allocation"`
+	Profile    string   `json:"profile,omitempty"`
+	Strategies []string `json:"strategies,omitempty"`
+	Exposures  int64    `json:"exposures"`
+}
+
+// ExperimentAssignment tags a response with the experiment variant that produced it
+type ExperimentAssignment struct {
+	Experiment string `json:"experiment"`
+	Variant    string `json:"variant"`
 }
 
 // Recommendation represents a recommended item with its score and explanation
@@ -57,3 +157,103 @@ type CoOccurrence struct {
 	Correlation   float64   `json:"correlation"`
 	LastUpdatedAt time.Time `json:"last_updated_at"`
 }
+
+// Bundle represents a group of items recommended together as one meal
+type Bundle struct {
+	Items       []Item   `json:"items"`
+	Roles       []string `json:"roles"`
+	TotalPrice  float64  `json:"total_price"`
+	Score       float64  `json:"score"`
+	Explanation string   `json:"explanation"`
+}
+
+// PriceProfile summarises how much a user typically spends
+type PriceProfile struct {
+	UserID        int     `json:"user_id"`
+	OrderCount    int     `json:"order_count"`
+	AvgOrderTotal float64 `json:"avg_order_total"`
+	AvgItemPrice  float64 `json:"avg_item_price"`
+	PriceStdDev   float64 `json:"price_std_dev"`
+}
+
+// BasketLine is one item of a reorderable basket, priced at today's menu price
+type BasketLine struct {
+	Item      Item    `json:"item"`
+	Quantity  int     `json:"quantity"`
+	Available bool    `json:"available"`
+	LineTotal float64 `json:"line_total"`
+}
+
+// Basket represents a past order that can be placed again in one tap
+type Basket struct {
+	OrderIDs      []int        `json:"order_ids"`
+	Lines         []BasketLine `json:"lines"`
+	LastOrderedAt time.Time    `json:"last_ordered_at"`
+	TimesOrdered  int          `json:"times_ordered"`
+	OriginalTotal float64      `json:"original_total"`
+	CurrentTotal  float64      `json:"current_total"`
+	AllAvailable  bool         `json:"all_available"`
+	Weekday       string       `json:"weekday,omitempty"`
+	Explanation   string       `json:"explanation"`
+}
+
+// ReorderSuggestions groups a user's recent and recurring baskets
+type ReorderSuggestions struct {
+	UserID           int      `json:"user_id"`
+	RecentBaskets    []Basket `json:"recent_baskets"`
+	RecurringBaskets []Basket `json:"recurring_baskets"`
+}
+
+// Event records how a guest interacted with a recommended item
+type Event struct {
+	RequestID  string    `json:"request_id"`
+	Type       string    `json:"type"`
+	UserID     int       `json:"user_id"`
+	ItemID     int       `json:"item_id"`
+	Strategy   string    `json:"strategy,omitempty"`
+	Position   int       `json:"position,omitempty"`
+	Experiment string    `json:"experiment,omitempty"`
+	Variant    string    `json:"variant,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// StrategyStats summarises online engagement with one strategy's recommendations
+type StrategyStats struct {
+	Strategy    string  `json:"strategy"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	AddsToCart  int64   `json:"adds_to_cart"`
+	Dismissals  int64   `json:"dismissals"`
+	CTR         float64 `json:"ctr"`
+	AddRate     float64 `json:"add_to_cart_rate"`
+}
+
+// Suppression records that a user is not interested in an item or a whole category.
+// Penalty is how strongly the mark still counts, from 1 when made to 0 when it expires.
+type Suppression struct {
+	Kind      string    `json:"kind"`
+	Item      *Item     `json:"item,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Penalty   float64   `json:"penalty"`
+}
+
+// OrderLineChange is the before and after quantity of one item in an amended order
+type OrderLineChange struct {
+	ItemID      int `json:"item_id"`
+	OldQuantity int `json:"old_quantity"`
+	NewQuantity int `json:"new_quantity"`
+}
+
+// OrderChange is an audit record of a cancelled or amended order
+type OrderChange struct {
+	OrderID  int               `json:"order_id"`
+	Action   string            `json:"action"`
+	Reason   string            `json:"reason,omitempty"`
+	Lines    []OrderLineChange `json:"lines"`
+	OldTotal float64           `json:"old_total"`
+	NewTotal float64           `json:"new_total"`
+	Refund   float64           `json:"refund"`
+	At       time.Time         `json:"at"`
+}
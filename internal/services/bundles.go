@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+const (
+	// MinBundleSize and MaxBundleSize bound the number of items in a bundle, cart item included
+	MinBundleSize = 2
+	MaxBundleSize = 4
+
+	// bundleCandidatesPerRole limits how many items per role are combined into bundles
+	bundleCandidatesPerRole = 4
+)
+
+// categoryRoles maps menu categories onto the course they play in a meal.
+// Categories not listed here act as their own role.
+var categoryRoles = map[string]string{
+	"Appetizer": "starter",
+	"Salad":     "starter",
+	"Pizza":     "main",
+	"Pasta":     "main",
+	"Dessert":   "dessert",
+}
+
+// roleOrder is the order in which roles are listed inside a bundle
+var roleOrder = []string{"starter", "main", "dessert"}
+
+// BundleOptions controls how complete-the-meal bundles are assembled
+type BundleOptions struct {
+	ItemInCartID *int
+	Price        PriceFilter // Budget applies to the bundle total, min/max to each item
+	Size         int         // total items per bundle, cart item included
+	Limit        int
+}
+
+// itemRole returns the meal role of an item based on its category
+func itemRole(item models.Item) string {
+	if role, ok := categoryRoles[item.Category]; ok {
+		return role
+	}
+	return strings.ToLower(item.Category)
+}
+
+// GetBundleRecommendations answers: "Which starter, main and dessert complete this user's meal?"
+func (s *RecommendationService) GetBundleRecommendations(ctx context.Context, userID int, opts BundleOptions) ([]models.Bundle, error) {
+	if opts.Size < MinBundleSize || opts.Size > MaxBundleSize {
+		return nil, fmt.Errorf("bundle size must be between %d and %d", MinBundleSize, MaxBundleSize)
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 5
+	}
+
+	items, err := s.GetAllItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	itemsByID := make(map[int]models.Item, len(items))
+	for _, item := range items {
+		itemsByID[item.DbID] = item
+	}
+
+	var cartItem *models.Item
+	if opts.ItemInCartID != nil {
+		item, ok := itemsByID[*opts.ItemInCartID]
+		if !ok {
+			return nil, fmt.Errorf("item %d not found", *opts.ItemInCartID)
+		}
+		cartItem = &item
+	}
+
+	// Personal affinity from the user's order history
+	affinity := make(map[int]float64)
+	frequentRecs, err := s.GetUserFrequentItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range frequentRecs {
+		affinity[rec.Item.DbID] = rec.Score
+	}
+
+	popularity, err := s.getItemPopularity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relevance of every item to the cart (or to the menu overall without a cart)
+	relevance := make(map[int]float64)
+	if cartItem != nil {
+		coRecs, err := s.GetGlobalCoOrderedItems(ctx, cartItem.DbID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range coRecs {
+			relevance[rec.Item.DbID] = rec.Score
+		}
+	} else {
+		relevance = popularity
+	}
+
+	maxAffinity := maxValue(affinity)
+	maxRelevance := maxValue(relevance)
+	maxPopularity := maxValue(popularity)
+
+	suppressed, err := s.getSuppressionSet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemScore := func(itemID int) float64 {
+		// Affinity already fades with the user's marks; relevance and popularity do not know the user
+		decay := 1 - suppressed.penalty(itemsByID[itemID])
+		score := 0.0
+		if maxRelevance > 0 {
+			score += 0.5 * decay * relevance[itemID] / maxRelevance
+		}
+		if maxAffinity > 0 {
+			score += 0.3 * affinity[itemID] / maxAffinity
+		}
+		if maxPopularity > 0 {
+			score += 0.2 * decay * popularity[itemID] / maxPopularity
+		}
+		return score
+	}
+
+	// Pick the strongest candidates for every role the cart does not already cover
+	candidatesByRole := make(map[string][]models.Item)
+	for _, item := range items {
+		if cartItem != nil && (item.DbID == cartItem.DbID || itemRole(item) == itemRole(*cartItem)) {
+			continue
+		}
+		if suppressed.hides(item) {
+			continue
+		}
+		if !itemWithinRange(item, opts.Price) {
+			continue
+		}
+		role := itemRole(item)
+		candidatesByRole[role] = append(candidatesByRole[role], item)
+	}
+
+	var openRoles []string
+	for role, candidates := range candidatesByRole {
+		sort.Slice(candidates, func(i, j int) bool {
+			return itemScore(candidates[i].DbID) > itemScore(candidates[j].DbID)
+		})
+		if len(candidates) > bundleCandidatesPerRole {
+			candidates = candidates[:bundleCandidatesPerRole]
+		}
+		candidatesByRole[role] = candidates
+		openRoles = append(openRoles, role)
+	}
+	sortRoles(openRoles)
+
+	slots := opts.Size
+	if cartItem != nil {
+		slots--
+	}
+	if slots > len(openRoles) {
+		return nil, fmt.Errorf("not enough menu roles to build a bundle of %d items", opts.Size)
+	}
+
+	var candidateIDs []int
+	if cartItem != nil {
+		candidateIDs = append(candidateIDs, cartItem.DbID)
+	}
+	for _, candidates := range candidatesByRole {
+		for _, item := range candidates {
+			candidateIDs = append(candidateIDs, item.DbID)
+		}
+	}
+
+	pairStrength, err := s.getPairStrengths(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	maxPair := 0.0
+	for _, times := range pairStrength {
+		maxPair = math.Max(maxPair, times)
+	}
+
+	var bundles []models.Bundle
+	for _, roles := range roleCombinations(openRoles, slots) {
+		for _, combo := range itemCombinations(roles, candidatesByRole) {
+			bundleItems := combo
+			if cartItem != nil {
+				bundleItems = append([]models.Item{*cartItem}, combo...)
+			}
+
+			totalPrice := 0.0
+			for _, item := range bundleItems {
+				totalPrice += item.Price
+			}
+			if opts.Price.Budget > 0 && totalPrice > opts.Price.Budget {
+				continue
+			}
+
+			score := 0.0
+			for _, item := range combo {
+				score += itemScore(item.DbID)
+			}
+
+			// Reward items that are actually ordered together
+			togetherTimes := 0.0
+			for i := 0; i < len(bundleItems); i++ {
+				for j := i + 1; j < len(bundleItems); j++ {
+					times := pairStrength[pairKey(bundleItems[i].DbID, bundleItems[j].DbID)]
+					togetherTimes += times
+					if maxPair > 0 {
+						score += times / maxPair
+					}
+				}
+			}
+
+			bundleRoles := make([]string, len(bundleItems))
+			for i, item := range bundleItems {
+				bundleRoles[i] = itemRole(item)
+			}
+
+			bundles = append(bundles, models.Bundle{
+				Items:       bundleItems,
+				Roles:       bundleRoles,
+				TotalPrice:  math.Round(totalPrice*100) / 100,
+				Score:       score,
+				Explanation: bundleExplanation(bundleRoles, togetherTimes, cartItem),
+			})
+		}
+	}
+
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].Score > bundles[j].Score
+	})
+
+	if len(bundles) > opts.Limit {
+		bundles = bundles[:opts.Limit]
+	}
+
+	return bundles, nil
+}
+
+// getItemPopularity returns how many times each item has been ordered across all users
+func (s *RecommendationService) getItemPopularity(ctx context.Context) (map[int]float64, error) {
+	query := `
+		MATCH (:User)-[ho:HAS_ORDERED]->(i:Item)
+		RETURN i.db_id AS item_id, sum(ho.times) AS times
+	`
+
+	results, err := s.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item popularity: %w", err)
+	}
+
+	rows, err := database.DecodeAll[itemCountRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode item popularity: %w", err)
+	}
+
+	popularity := make(map[int]float64, len(rows))
+	for _, row := range rows {
+		popularity[row.ItemID] = row.Times
+	}
+
+	return popularity, nil
+}
+
+// getPairStrengths returns ORDERED_ALONG_WITH counts between the given items
+func (s *RecommendationService) getPairStrengths(ctx context.Context, itemIDs []int) (map[[2]int]float64, error) {
+	query := `
+		MATCH (a:Item)-[oaw:ORDERED_ALONG_WITH]->(b:Item)
+		WHERE a.db_id IN $itemIds AND b.db_id IN $itemIds AND a.db_id < b.db_id
+		RETURN a.db_id AS item_a, b.db_id AS item_b, oaw.times AS times
+	`
+
+	params := map[string]interface{}{
+		"itemIds": itemIDs,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item pair strengths: %w", err)
+	}
+
+	rows, err := database.DecodeAll[pairCountRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode item pair strengths: %w", err)
+	}
+
+	strengths := make(map[[2]int]float64, len(rows))
+	for _, row := range rows {
+		strengths[pairKey(row.ItemA, row.ItemB)] = row.Times
+	}
+
+	return strengths, nil
+}
+
+// itemWithinRange applies the per-item min and max price of a filter, ignoring the budget
+func itemWithinRange(item models.Item, filter PriceFilter) bool {
+	if filter.MinPrice > 0 && item.Price < filter.MinPrice {
+		return false
+	}
+	return filter.MaxPrice <= 0 || item.Price <= filter.MaxPrice
+}
+
+// pairKey returns an order-independent key for two item IDs
+func pairKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// maxValue returns the largest value in the map, or 0 when empty
+func maxValue(values map[int]float64) float64 {
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// sortRoles orders roles as courses are served, with unknown roles last
+func sortRoles(roles []string) {
+	rank := func(role string) int {
+		for i, r := range roleOrder {
+			if r == role {
+				return i
+			}
+		}
+		return len(roleOrder)
+	}
+	sort.SliceStable(roles, func(i, j int) bool {
+		if rank(roles[i]) != rank(roles[j]) {
+			return rank(roles[i]) < rank(roles[j])
+		}
+		return roles[i] < roles[j]
+	})
+}
+
+// roleCombinations returns every choice of k roles, preserving course order
+func roleCombinations(roles []string, k int) [][]string {
+	if k == 0 {
+		return [][]string{{}}
+	}
+	if len(roles) < k {
+		return nil
+	}
+
+	var combinations [][]string
+	for _, rest := range roleCombinations(roles[1:], k-1) {
+		combinations = append(combinations, append([]string{roles[0]}, rest...))
+	}
+	return append(combinations, roleCombinations(roles[1:], k)...)
+}
+
+// itemCombinations returns the cartesian product of candidates for the given roles
+func itemCombinations(roles []string, candidatesByRole map[string][]models.Item) [][]models.Item {
+	combinations := [][]models.Item{{}}
+	for _, role := range roles {
+		var next [][]models.Item
+		for _, combo := range combinations {
+			for _, item := range candidatesByRole[role] {
+				extended := make([]models.Item, len(combo), len(combo)+1)
+				copy(extended, combo)
+				next = append(next, append(extended, item))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// bundleExplanation describes a bundle in terms of the courses it covers
+func bundleExplanation(roles []string, togetherTimes float64, cartItem *models.Item) string {
+	var explanation string
+	if cartItem != nil {
+		explanation = fmt.Sprintf("Completes your %s with a %s", cartItem.Name, strings.Join(roles[1:], ", "))
+	} else {
+		explanation = fmt.Sprintf("A meal with a %s", strings.Join(roles, ", "))
+	}
+
+	if togetherTimes > 0 {
+		explanation += fmt.Sprintf("; these items were ordered together %d times", int(togetherTimes))
+	}
+
+	return explanation
+}
+
+// itemCountRow is how many times an item was ordered
+type itemCountRow struct {
+	ItemID int     `db:"item_id"`
+	Times  float64 `db:"times"`
+}
+
+// pairCountRow is how many times two items were ordered together
+type pairCountRow struct {
+	ItemA int     `db:"item_a"`
+	ItemB int     `db:"item_b"`
+	Times float64 `db:"times"`
+}
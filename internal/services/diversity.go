@@ -0,0 +1,105 @@
+package services
+
+import (
+	"math"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// DiversityOptions controls the optional re-ranking stage applied to hybrid results
+type DiversityOptions struct {
+	// Diversity trades relevance for variety: 0 keeps the original order, 1 maximises variety
+	Diversity float64 `json:"diversity"`
+	// MaxPerCategory caps how many items of the same category may appear (0 means no cap)
+	MaxPerCategory int `json:"max_per_category"`
+}
+
+// Enabled reports whether any re-ranking was requested
+func (o DiversityOptions) Enabled() bool {
+	return o.Diversity > 0 || o.MaxPerCategory > 0
+}
+
+// DiversifyRecommendations re-ranks recommendations using Maximal Marginal Relevance
+// and per-category caps so the top results cover more of the menu.
+// Items beyond the category cap are dropped, so the cap holds however many results
+// the caller keeps, even when that leaves fewer results than requested.
+func (s *RecommendationService) DiversifyRecommendations(recommendations []models.Recommendation, opts DiversityOptions) []models.Recommendation {
+	if !opts.Enabled() || len(recommendations) < 2 {
+		return recommendations
+	}
+
+	lambda := 1 - math.Min(math.Max(opts.Diversity, 0), 1)
+
+	// Normalise scores to [0, 1] so they are comparable with similarity values
+	maxScore := 0.0
+	for _, rec := range recommendations {
+		if rec.Score > maxScore {
+			maxScore = rec.Score
+		}
+	}
+
+	remaining := make([]models.Recommendation, len(recommendations))
+	copy(remaining, recommendations)
+
+	var selected []models.Recommendation
+	categoryCounts := make(map[string]int)
+
+	for len(remaining) > 0 {
+		bestIdx := -1
+		bestValue := math.Inf(-1)
+
+		for idx, candidate := range remaining {
+			relevance := 0.0
+			if maxScore > 0 {
+				relevance = candidate.Score / maxScore
+			}
+
+			// Penalise candidates that look like something already picked
+			maxSimilarity := 0.0
+			for _, picked := range selected {
+				if sim := itemSimilarity(candidate.Item, picked.Item); sim > maxSimilarity {
+					maxSimilarity = sim
+				}
+			}
+
+			value := lambda*relevance - (1-lambda)*maxSimilarity
+			if value > bestValue {
+				bestValue = value
+				bestIdx = idx
+			}
+		}
+
+		best := remaining[bestIdx]
+		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
+
+		if opts.MaxPerCategory > 0 && categoryCounts[best.Item.Category] >= opts.MaxPerCategory {
+			continue
+		}
+
+		categoryCounts[best.Item.Category]++
+		selected = append(selected, best)
+	}
+
+	return selected
+}
+
+// itemSimilarity scores how interchangeable two menu items are on a 0..1 scale.
+// Items in the same category are considered close substitutes, and the price
+// gap refines the score so a cheap side and a premium main are not treated alike.
+func itemSimilarity(a, b models.Item) float64 {
+	if a.DbID == b.DbID {
+		return 1
+	}
+
+	similarity := 0.0
+	if a.Category != "" && a.Category == b.Category {
+		similarity += 0.8
+	}
+
+	if a.Price > 0 && b.Price > 0 {
+		priceRatio := math.Min(a.Price, b.Price) / math.Max(a.Price, b.Price)
+		similarity += 0.2 * priceRatio
+	}
+
+	return similarity
+}
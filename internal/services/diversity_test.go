@@ -0,0 +1,102 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func recommendationIDs(recommendations []models.Recommendation) []int {
+	ids := make([]int, len(recommendations))
+	for i, rec := range recommendations {
+		ids[i] = rec.Item.DbID
+	}
+	return ids
+}
+
+func TestDiversifyRecommendations(t *testing.T) {
+	margherita := models.Recommendation{Item: models.Item{DbID: 1, Category: "Pizza", Price: 10}, Score: 10}
+	pepperoni := models.Recommendation{Item: models.Item{DbID: 2, Category: "Pizza", Price: 10}, Score: 9}
+	caesar := models.Recommendation{Item: models.Item{DbID: 3, Category: "Salad", Price: 5}, Score: 8}
+	tiramisu := models.Recommendation{Item: models.Item{DbID: 4, Category: "Dessert", Price: 6}, Score: 1}
+	candidates := []models.Recommendation{margherita, pepperoni, caesar}
+
+	tests := []struct {
+		name            string
+		recommendations []models.Recommendation
+		opts            DiversityOptions
+		want            []int
+	}{
+		{"disabled keeps the order", candidates, DiversityOptions{}, []int{1, 2, 3}},
+		{"single candidate", candidates[:1], DiversityOptions{Diversity: 1}, []int{1}},
+		{"light diversity keeps relevance order", candidates, DiversityOptions{Diversity: 0.05}, []int{1, 2, 3}},
+		{"diversity moves a substitute down", candidates, DiversityOptions{Diversity: 0.5}, []int{1, 3, 2}},
+		{"full diversity ignores scores after the first pick", []models.Recommendation{margherita, pepperoni, caesar, tiramisu}, DiversityOptions{Diversity: 1}, []int{1, 3, 4, 2}},
+		{"category cap drops the overflow", candidates, DiversityOptions{MaxPerCategory: 1}, []int{1, 3}},
+		{"category cap with diversity", []models.Recommendation{margherita, pepperoni, tiramisu}, DiversityOptions{Diversity: 0.05, MaxPerCategory: 1}, []int{1, 4}},
+	}
+
+	service := NewRecommendationService(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recommendationIDs(service.DiversifyRecommendations(tt.recommendations, tt.opts))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiversifyRecommendationsCapsShortLists(t *testing.T) {
+	// Twelve pizzas and two salads: fewer than hybridResultLimit candidates fit a cap of two,
+	// and the result must not be topped up with pizzas beyond the cap
+	var candidates []models.Recommendation
+	for i := 1; i <= 12; i++ {
+		candidates = append(candidates, models.Recommendation{Item: models.Item{DbID: i, Category: "Pizza", Price: 10}, Score: float64(100 - i)})
+	}
+	candidates = append(candidates,
+		models.Recommendation{Item: models.Item{DbID: 13, Category: "Salad", Price: 5}, Score: 2},
+		models.Recommendation{Item: models.Item{DbID: 14, Category: "Salad", Price: 5}, Score: 1},
+	)
+
+	got := NewRecommendationService(nil).DiversifyRecommendations(candidates, DiversityOptions{MaxPerCategory: 2})
+	if len(got) > hybridResultLimit {
+		got = got[:hybridResultLimit]
+	}
+	if ids := recommendationIDs(got); !slices.Equal(ids, []int{1, 2, 13, 14}) {
+		t.Errorf("got %v, want two pizzas and two salads", ids)
+	}
+}
+
+func TestDiversifyRecommendationsKeepsInput(t *testing.T) {
+	recommendations := []models.Recommendation{
+		{Item: models.Item{DbID: 1, Category: "Pizza"}, Score: 2},
+		{Item: models.Item{DbID: 2, Category: "Pizza"}, Score: 1},
+	}
+	NewRecommendationService(nil).DiversifyRecommendations(recommendations, DiversityOptions{MaxPerCategory: 1})
+	if got := recommendationIDs(recommendations); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("input reordered to %v", got)
+	}
+}
+
+func TestItemSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b models.Item
+		want float64
+	}{
+		{"same item", models.Item{DbID: 1}, models.Item{DbID: 1}, 1},
+		{"same category and price", models.Item{DbID: 1, Category: "Pizza", Price: 10}, models.Item{DbID: 2, Category: "Pizza", Price: 10}, 1},
+		{"same category, half the price", models.Item{DbID: 1, Category: "Pizza", Price: 10}, models.Item{DbID: 2, Category: "Pizza", Price: 5}, 0.9},
+		{"different category", models.Item{DbID: 1, Category: "Pizza", Price: 10}, models.Item{DbID: 2, Category: "Salad", Price: 5}, 0.1},
+		{"no category or price", models.Item{DbID: 1}, models.Item{DbID: 2}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := itemSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
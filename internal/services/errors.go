@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+)
+
+// ErrorKind says what went wrong in terms a caller can act on, independent of the cause
+type ErrorKind string
+
+// Kinds of service error
+const (
+	// KindInvalidArgument means the request itself is wrong and retrying it will not help
+	KindInvalidArgument ErrorKind = "invalid_argument"
+	// KindNotFound means a user, item or other named resource does not exist
+	KindNotFound ErrorKind = "not_found"
+	// KindConflict means the request clashes with the current state, such as a taken name
+	KindConflict ErrorKind = "conflict"
+	// KindUnsupported means the server is configured without the requested feature
+	KindUnsupported ErrorKind = "unsupported"
+	// KindUnavailable means Neo4j could not be reached or asked for a retry
+	KindUnavailable ErrorKind = "unavailable"
+	// KindTimeout means the request ran out of time before Neo4j answered
+	KindTimeout ErrorKind = "timeout"
+	// KindInternal is everything else, including results that could not be decoded
+	KindInternal ErrorKind = "internal"
+)
+
+// Error is a service error with a kind. The sentinel errors of this package are all
+// *Error, so callers can either match one with errors.Is or branch on KindOf.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// newError returns a sentinel error of the given kind
+func newError(kind ErrorKind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// KindOf classifies an error returned by this package. Service errors keep their own
+// kind; Neo4j driver and context errors wrapped on the way up are classified by cause;
+// anything else, such as a result that could not be decoded, is internal.
+func KindOf(err error) ErrorKind {
+	if err == nil {
+		return ""
+	}
+
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Kind
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindTimeout
+	case errors.Is(err, context.Canceled), errors.Is(err, database.ErrQueueClosed):
+		return KindUnavailable
+	}
+
+	var neo4jErr *neo4j.Neo4jError
+	if errors.As(err, &neo4jErr) {
+		switch {
+		case strings.HasPrefix(neo4jErr.Code, "Neo.ClientError.Transaction.TransactionTimedOut"):
+			return KindTimeout
+		case neo4jErr.Classification() == "TransientError", neo4jErr.HasSecurityCode():
+			// A wrong password or expired token is the server's problem, not the caller's
+			return KindUnavailable
+		}
+		return KindInternal
+	}
+
+	var connectivityErr *neo4j.ConnectivityError
+	var limitErr *neo4j.TransactionExecutionLimit
+	if errors.As(err, &connectivityErr) || errors.As(err, &limitErr) {
+		return KindUnavailable
+	}
+
+	return KindInternal
+}
+
+// isConstraintViolation reports whether a write was rejected by a uniqueness constraint
+func isConstraintViolation(err error) bool {
+	var neo4jErr *neo4j.Neo4jError
+	return errors.As(err, &neo4jErr) && neo4jErr.Code == "Neo.ClientError.Schema.ConstraintValidationFailed"
+}
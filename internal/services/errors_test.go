@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+)
+
+func TestKindOf(t *testing.T) {
+	wrap := func(err error) error {
+		return fmt.Errorf("failed to get user frequent items: %w", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"nil", nil, ""},
+		{"sentinel", ErrUserNotFound, KindNotFound},
+		{"wrapped sentinel", fmt.Errorf("%w: stars must be between 1 and 5", ErrInvalidRating), KindInvalidArgument},
+		{"deadline", wrap(context.DeadlineExceeded), KindTimeout},
+		{"cancelled", wrap(context.Canceled), KindUnavailable},
+		{"queue closed", wrap(database.ErrQueueClosed), KindUnavailable},
+		{"transaction timed out", wrap(&neo4j.Neo4jError{Code: "Neo.ClientError.Transaction.TransactionTimedOut"}), KindTimeout},
+		{"transaction timed out client side", wrap(&neo4j.Neo4jError{Code: "Neo.ClientError.Transaction.TransactionTimedOutClientConfiguration"}), KindTimeout},
+		{"transient", wrap(&neo4j.Neo4jError{Code: "Neo.TransientError.Transaction.DeadlockDetected"}), KindUnavailable},
+		{"unauthorized", wrap(&neo4j.Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"}), KindUnavailable},
+		{"token expired", wrap(&neo4j.Neo4jError{Code: "Neo.ClientError.Security.TokenExpired"}), KindUnavailable},
+		{"syntax error", wrap(&neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}), KindInternal},
+		{"database error", wrap(&neo4j.Neo4jError{Code: "Neo.DatabaseError.General.UnknownError"}), KindInternal},
+		{"connectivity", wrap(&neo4j.ConnectivityError{Inner: errors.New("connection refused")}), KindUnavailable},
+		{"retries exhausted", wrap(&neo4j.TransactionExecutionLimit{Cause: "timeout"}), KindUnavailable},
+		{"decode failure", wrap(errors.New("column times is not an integer")), KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
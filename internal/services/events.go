@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// Event types accepted by the feedback log
+const (
+	EventImpression = "impression"
+	EventClick      = "click"
+	EventAddToCart  = "add_to_cart"
+	EventDismiss    = "dismiss"
+)
+
+// eventRelationships maps event types onto the graph relationship that stores them
+var eventRelationships = map[string]string{
+	EventImpression: "IMPRESSED",
+	EventClick:      "CLICKED",
+	EventAddToCart:  "ADDED_TO_CART",
+	EventDismiss:    "DISMISSED",
+}
+
+var (
+	// ErrInvalidEvent is returned for events with a missing request ID or unknown type
+	ErrInvalidEvent = newError(KindInvalidArgument, "invalid event")
+	// ErrStatsUnsupported is returned when the configured sink cannot compute statistics
+	ErrStatsUnsupported = newError(KindUnsupported, "event sink does not support statistics")
+)
+
+// EventSink stores feedback events and reports how many it stored; a sink may skip
+// events it cannot store, such as events about unknown users or items
+type EventSink interface {
+	Record(ctx context.Context, events []models.Event) (int, error)
+}
+
+// EventStatsProvider is implemented by sinks that can aggregate what they stored
+type EventStatsProvider interface {
+	StrategyStats(ctx context.Context, since time.Time) ([]models.StrategyStats, error)
+}
+
+// DismissalSuppressor turns dismiss events into "not interested" marks
+type DismissalSuppressor interface {
+	SuppressDismissed(ctx context.Context, dismissals []models.Event) error
+}
+
+// EventService validates feedback events and hands them to a sink
+type EventService struct {
+	sink       EventSink
+	suppressor DismissalSuppressor
+}
+
+// NewEventService creates a new event service writing to sink
+func NewEventService(sink EventSink) *EventService {
+	return &EventService{sink: sink}
+}
+
+// SetDismissalSuppressor makes dismiss events suppress the dismissed items, whichever
+// sink stores the events themselves
+func (s *EventService) SetDismissalSuppressor(suppressor DismissalSuppressor) {
+	s.suppressor = suppressor
+}
+
+// RecordEvents validates and stores a batch of events and returns how many were stored
+func (s *EventService) RecordEvents(ctx context.Context, events []models.Event) (int, error) {
+	if len(events) == 0 {
+		return 0, fmt.Errorf("%w: no events", ErrInvalidEvent)
+	}
+
+	now := time.Now().UTC()
+	for i := range events {
+		if events[i].RequestID == "" {
+			return 0, fmt.Errorf("%w: event %d has no request_id", ErrInvalidEvent, i)
+		}
+		if _, ok := eventRelationships[events[i].Type]; !ok {
+			return 0, fmt.Errorf("%w: event %d has unknown type %q", ErrInvalidEvent, i, events[i].Type)
+		}
+		if events[i].At.IsZero() {
+			events[i].At = now
+		}
+	}
+
+	// Suppress first: marks are idempotent, so a client retrying after a failure here
+	// neither loses the suppression nor records its events twice
+	if s.suppressor != nil {
+		var dismissals []models.Event
+		for _, event := range events {
+			if event.Type == EventDismiss {
+				dismissals = append(dismissals, event)
+			}
+		}
+		if err := s.suppressor.SuppressDismissed(ctx, dismissals); err != nil {
+			return 0, err
+		}
+	}
+
+	return s.sink.Record(ctx, events)
+}
+
+// GetStrategyStats returns online CTR per strategy for events since the given time
+func (s *EventService) GetStrategyStats(ctx context.Context, since time.Time) ([]models.StrategyStats, error) {
+	provider, ok := s.sink.(EventStatsProvider)
+	if !ok {
+		return nil, ErrStatsUnsupported
+	}
+	return provider.StrategyStats(ctx, since)
+}
+
+// GraphEventSink stores events as relationships between users and items,
+// e.g. (:User)-[:DISMISSED {request_id, strategy, at}]->(:Item)
+type GraphEventSink struct {
+	client *database.Neo4jClient
+}
+
+// NewGraphEventSink creates a sink writing to the graph
+func NewGraphEventSink(client *database.Neo4jClient) *GraphEventSink {
+	return &GraphEventSink{client: client}
+}
+
+// Record writes one relationship per event; events for unknown users or items are skipped
+// and left out of the count
+func (g *GraphEventSink) Record(ctx context.Context, events []models.Event) (int, error) {
+	byType := make(map[string][]map[string]interface{})
+	for _, event := range events {
+		byType[event.Type] = append(byType[event.Type], map[string]interface{}{
+			"request_id": event.RequestID,
+			"user_id":    event.UserID,
+			"item_id":    event.ItemID,
+			"strategy":   event.Strategy,
+			"position":   event.Position,
+			"experiment": event.Experiment,
+			"variant":    event.Variant,
+			"at":         event.At,
+		})
+	}
+
+	recorded := 0
+	for eventType, rows := range byType {
+		// Relationship types cannot be parameters; they come from the fixed eventRelationships map
+		query := fmt.Sprintf(`
+			UNWIND $rows AS row
+			MATCH (u:User {db_id: row.user_id})
+			MATCH (i:Item {db_id: row.item_id})
+			CREATE (u)-[:%s {
+				request_id: row.request_id,
+				strategy: row.strategy,
+				position: row.position,
+				experiment: row.experiment,
+				variant: row.variant,
+				at: row.at
+			}]->(i)
+			RETURN count(*) AS recorded
+		`, eventRelationships[eventType])
+
+		params := map[string]interface{}{
+			"rows": rows,
+		}
+
+		results, err := g.client.ExecuteWriteWithResult(ctx, query, params)
+		if err != nil {
+			return recorded, fmt.Errorf("failed to record %s events: %w", eventType, err)
+		}
+		if len(results) > 0 {
+			count, err := database.Value[int](results[0], "recorded")
+			if err != nil {
+				return recorded, fmt.Errorf("failed to decode recorded %s events: %w", eventType, err)
+			}
+			recorded += count
+		}
+	}
+
+	return recorded, nil
+}
+
+// StrategyStats counts events per strategy and derives click-through and add-to-cart rates
+func (g *GraphEventSink) StrategyStats(ctx context.Context, since time.Time) ([]models.StrategyStats, error) {
+	query := `
+		MATCH (:User)-[e:IMPRESSED|CLICKED|ADDED_TO_CART|DISMISSED]->(:Item)
+		WHERE e.at >= $since
+		RETURN CASE WHEN e.strategy IS NULL OR e.strategy = "" THEN "unknown" ELSE e.strategy END AS strategy,
+			   type(e) AS relationship,
+			   count(*) AS events
+	`
+
+	params := map[string]interface{}{
+		"since": since,
+	}
+
+	results, err := g.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event statistics: %w", err)
+	}
+
+	rows, err := database.DecodeAll[eventCountRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode event statistics: %w", err)
+	}
+
+	byStrategy := make(map[string]*models.StrategyStats)
+	for _, row := range rows {
+		stats, ok := byStrategy[row.Strategy]
+		if !ok {
+			stats = &models.StrategyStats{Strategy: row.Strategy}
+			byStrategy[row.Strategy] = stats
+		}
+
+		count := row.Events
+		switch row.Relationship {
+		case "IMPRESSED":
+			stats.Impressions += count
+		case "CLICKED":
+			stats.Clicks += count
+		case "ADDED_TO_CART":
+			stats.AddsToCart += count
+		case "DISMISSED":
+			stats.Dismissals += count
+		}
+	}
+
+	return finaliseStats(byStrategy), nil
+}
+
+// eventCountRow is how many events of one type a strategy received
+type eventCountRow struct {
+	Strategy     string `db:"strategy"`
+	Relationship string `db:"relationship"`
+	Events       int64  `db:"events"`
+}
+
+// FileEventSink appends events as JSON lines to a file, for shipping to an external pipeline
+type FileEventSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileEventSink opens (or creates) the file events are appended to
+func NewFileEventSink(path string) (*FileEventSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log %s: %w", path, err)
+	}
+	return &FileEventSink{file: file}, nil
+}
+
+// Record appends one JSON line per event
+func (f *FileEventSink) Record(ctx context.Context, events []models.Event) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	encoder := json.NewEncoder(f.file)
+	for i, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return i, fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+	return len(events), nil
+}
+
+// Close closes the underlying file
+func (f *FileEventSink) Close() error {
+	return f.file.Close()
+}
+
+// finaliseStats computes rates and returns the stats sorted by strategy
+func finaliseStats(byStrategy map[string]*models.StrategyStats) []models.StrategyStats {
+	stats := make([]models.StrategyStats, 0, len(byStrategy))
+	for _, s := range byStrategy {
+		if s.Impressions > 0 {
+			s.CTR = float64(s.Clicks) / float64(s.Impressions)
+			s.AddRate = float64(s.AddsToCart) / float64(s.Impressions)
+		}
+		stats = append(stats, *s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Strategy < stats[j].Strategy
+	})
+
+	return stats
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// countingSink records events, skipping those about item 0 as the graph sink skips unknown items
+type countingSink struct {
+	events []models.Event
+}
+
+func (s *countingSink) Record(_ context.Context, events []models.Event) (int, error) {
+	recorded := 0
+	for _, event := range events {
+		if event.ItemID != 0 {
+			s.events = append(s.events, event)
+			recorded++
+		}
+	}
+	return recorded, nil
+}
+
+type fakeSuppressor struct {
+	dismissals []models.Event
+	err        error
+}
+
+func (f *fakeSuppressor) SuppressDismissed(_ context.Context, dismissals []models.Event) error {
+	f.dismissals = append(f.dismissals, dismissals...)
+	return f.err
+}
+
+func TestRecordEventsSuppressesDismissals(t *testing.T) {
+	sink := &countingSink{}
+	suppressor := &fakeSuppressor{}
+	service := NewEventService(sink)
+	service.SetDismissalSuppressor(suppressor)
+
+	recorded, err := service.RecordEvents(context.Background(), []models.Event{
+		{RequestID: "r1", Type: EventImpression, UserID: 1, ItemID: 10},
+		{RequestID: "r1", Type: EventDismiss, UserID: 1, ItemID: 11},
+		{RequestID: "r1", Type: EventDismiss, UserID: 1, ItemID: 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recorded != 2 {
+		t.Errorf("recorded = %d, want 2", recorded)
+	}
+	if len(suppressor.dismissals) != 2 || suppressor.dismissals[0].ItemID != 11 {
+		t.Errorf("suppressed %+v, want both dismissals", suppressor.dismissals)
+	}
+	for _, event := range sink.events {
+		if event.At.IsZero() {
+			t.Errorf("event %+v has no time", event)
+		}
+	}
+}
+
+func TestRecordEventsStopsWhenSuppressionFails(t *testing.T) {
+	sink := &countingSink{}
+	service := NewEventService(sink)
+	service.SetDismissalSuppressor(&fakeSuppressor{err: errors.New("neo4j unavailable")})
+
+	_, err := service.RecordEvents(context.Background(), []models.Event{
+		{RequestID: "r1", Type: EventDismiss, UserID: 1, ItemID: 11},
+	})
+	if err == nil {
+		t.Fatal("expected the suppression error")
+	}
+	if len(sink.events) != 0 {
+		t.Errorf("recorded %d events, want none so a retry does not duplicate them", len(sink.events))
+	}
+}
+
+func TestRecordEventsRejectsInvalidEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []models.Event
+	}{
+		{"no events", nil},
+		{"no request ID", []models.Event{{Type: EventClick, UserID: 1, ItemID: 1}}},
+		{"unknown type", []models.Event{{RequestID: "r1", Type: "hover", UserID: 1, ItemID: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEventService(&countingSink{}).RecordEvents(context.Background(), tt.events)
+			if !errors.Is(err, ErrInvalidEvent) {
+				t.Errorf("got %v, want ErrInvalidEvent", err)
+			}
+		})
+	}
+}
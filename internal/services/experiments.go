@@ -0,0 +1,429 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+var (
+	// ErrExperimentNotFound is returned when no experiment has the requested name
+	ErrExperimentNotFound = newError(KindNotFound, "experiment not found")
+	// ErrExperimentExists is returned when creating an experiment whose name is taken
+	ErrExperimentExists = newError(KindConflict, "experiment already exists")
+	// ErrInvalidExperiment is returned for experiments that cannot be run as defined
+	ErrInvalidExperiment = newError(KindInvalidArgument, "invalid experiment")
+)
+
+// hybridStrategies lists the strategies a variant may restrict the hybrid to
+var hybridStrategies = []string{"UserFrequency", "UserCoOrders", "GlobalCoOrders", "TimeBasedTrend", "PriceSensitivity", "Ratings"}
+
+// ListExperiments returns every experiment with its variants and exposure counts
+func (s *RecommendationService) ListExperiments(ctx context.Context) ([]models.Experiment, error) {
+	return s.loadExperiments(ctx, "", false)
+}
+
+// GetExperiment returns one experiment with its variants and exposure counts
+func (s *RecommendationService) GetExperiment(ctx context.Context, name string) (models.Experiment, error) {
+	experiments, err := s.loadExperiments(ctx, name, false)
+	if err != nil {
+		return models.Experiment{}, err
+	}
+	if len(experiments) == 0 {
+		return models.Experiment{}, ErrExperimentNotFound
+	}
+	return experiments[0], nil
+}
+
+// GetActiveExperiment returns the running experiment, or nil when none is running
+func (s *RecommendationService) GetActiveExperiment(ctx context.Context) (*models.Experiment, error) {
+	experiments, err := s.loadExperiments(ctx, "", true)
+	if err != nil {
+		return nil, err
+	}
+	if len(experiments) == 0 {
+		return nil, nil
+	}
+	return &experiments[0], nil
+}
+
+// CreateExperiment stores a new, inactive experiment
+func (s *RecommendationService) CreateExperiment(ctx context.Context, experiment models.Experiment) (models.Experiment, error) {
+	if err := s.validateExperiment(ctx, experiment); err != nil {
+		return models.Experiment{}, err
+	}
+	if _, err := s.GetExperiment(ctx, experiment.Name); err == nil {
+		return models.Experiment{}, ErrExperimentExists
+	} else if !errors.Is(err, ErrExperimentNotFound) {
+		return models.Experiment{}, err
+	}
+
+	variants := make([]map[string]interface{}, len(experiment.Variants))
+	for i, variant := range experiment.Variants {
+		strategies := variant.Strategies
+		if strategies == nil {
+			strategies = []string{}
+		}
+		variants[i] = map[string]interface{}{
+			"name":       variant.Name,
+			"allocation": variant.Allocation,
+			"profile":    variant.Profile,
+			"strategies": strategies,
+			"position":   i,
+		}
+	}
+
+	query := `
+		CREATE (e:Experiment {
+			name: $name,
+			description: $description,
+			active: false,
+			created_at: datetime()
+		})
+		WITH e
+		UNWIND $variants AS variant
+		CREATE (e)-[:HAS_VARIANT]->(:Variant {
+			name: variant.name,
+			allocation: variant.allocation,
+			profile: variant.profile,
+			strategies: variant.strategies,
+			position: variant.position,
+			exposures: 0
+		})
+	`
+
+	params := map[string]interface{}{
+		"name":        experiment.Name,
+		"description": experiment.Description,
+		"variants":    variants,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return models.Experiment{}, fmt.Errorf("failed to create experiment: %w", err)
+	}
+
+	return s.GetExperiment(ctx, experiment.Name)
+}
+
+// StartExperiment makes an experiment the running one, stopping any other
+func (s *RecommendationService) StartExperiment(ctx context.Context, name string) error {
+	if _, err := s.GetExperiment(ctx, name); err != nil {
+		return err
+	}
+
+	query := `
+		MATCH (e:Experiment)
+		SET e.active = (e.name = $name)
+	`
+
+	params := map[string]interface{}{
+		"name": name,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to start experiment: %w", err)
+	}
+
+	return nil
+}
+
+// StopExperiment stops an experiment; its exposure counts are kept
+func (s *RecommendationService) StopExperiment(ctx context.Context, name string) error {
+	if _, err := s.GetExperiment(ctx, name); err != nil {
+		return err
+	}
+
+	query := `
+		MATCH (e:Experiment {name: $name})
+		SET e.active = false
+	`
+
+	params := map[string]interface{}{
+		"name": name,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to stop experiment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExperiment removes an experiment and its variants
+func (s *RecommendationService) DeleteExperiment(ctx context.Context, name string) error {
+	if _, err := s.GetExperiment(ctx, name); err != nil {
+		return err
+	}
+
+	query := `
+		MATCH (e:Experiment {name: $name})
+		OPTIONAL MATCH (e)-[:HAS_VARIANT]->(v:Variant)
+		DETACH DELETE e, v
+	`
+
+	params := map[string]interface{}{
+		"name": name,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to delete experiment: %w", err)
+	}
+
+	return nil
+}
+
+// AssignVariant deterministically buckets a unit (user or session ID) into a variant,
+// so the same unit always sees the same variant of the same experiment. The experiment
+// name is hashed with the unit, so assignments in different experiments are independent.
+func AssignVariant(experiment models.Experiment, unit string) models.ExperimentVariant {
+	total := 0
+	for _, variant := range experiment.Variants {
+		total += variant.Allocation
+	}
+
+	// SHA-256 rather than FNV: FNV's low bits hardly depend on the experiment name,
+	// so small splits would put a unit in the same variant of every experiment
+	sum := sha256.Sum256([]byte(experiment.Name + ":" + unit))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+
+	for _, variant := range experiment.Variants {
+		if bucket < variant.Allocation {
+			return variant
+		}
+		bucket -= variant.Allocation
+	}
+
+	return experiment.Variants[len(experiment.Variants)-1]
+}
+
+// ExposureRecorder counts experiment exposures, typically buffering them between writes
+type ExposureRecorder interface {
+	Record(experiment, variant string)
+}
+
+// SetExposureRecorder makes served variants count their exposures through a buffer
+// instead of writing each one synchronously
+func (s *RecommendationService) SetExposureRecorder(recorder ExposureRecorder) {
+	s.exposureRecorder = recorder
+}
+
+// ApplyExperiment enrols a unit in the running experiment, if any, and returns the
+// weights its variant prescribes. Without a running experiment the inputs are returned
+// unchanged; so are they when the variant's strategies carry no weight for them, in
+// which case the unit is not enrolled. Call RecordExposure once the variant's results
+// have been served.
+func (s *RecommendationService) ApplyExperiment(ctx context.Context, unit string, weights models.HybridWeights, profile *models.WeightProfile) (models.HybridWeights, *models.WeightProfile, *models.ExperimentAssignment, error) {
+	experiment, err := s.GetActiveExperiment(ctx)
+	if err != nil || experiment == nil {
+		return weights, profile, nil, err
+	}
+
+	variant := AssignVariant(*experiment, unit)
+	variantWeights, variantProfile := weights, profile
+
+	if variant.Profile != "" {
+		selected, err := s.GetWeightProfile(ctx, variant.Profile, 0)
+		if err != nil {
+			return weights, profile, nil, fmt.Errorf("failed to load profile %q for variant %s: %w", variant.Profile, variant.Name, err)
+		}
+		variantWeights = selected.Weights
+		variantProfile = &selected
+	}
+
+	if len(variant.Strategies) > 0 {
+		variantWeights = restrictWeights(variantWeights, variant.Strategies)
+	}
+
+	// validateExperiment rejects such variants, but segment weights may have changed since
+	if err := ValidateWeights(variantWeights); err != nil {
+		log.Printf("Warning: variant %s of experiment %s leaves no weighted strategy; serving unit %s outside the experiment", variant.Name, experiment.Name, unit)
+		return weights, profile, nil, nil
+	}
+
+	return variantWeights, variantProfile, &models.ExperimentAssignment{
+		Experiment: experiment.Name,
+		Variant:    variant.Name,
+	}, nil
+}
+
+// RecordExposure counts one response served by a variant
+func (s *RecommendationService) RecordExposure(ctx context.Context, assignment models.ExperimentAssignment) error {
+	if s.exposureRecorder != nil {
+		s.exposureRecorder.Record(assignment.Experiment, assignment.Variant)
+		return nil
+	}
+	return database.AddExposures(ctx, s.client, map[database.ExposureKey]int{
+		{Experiment: assignment.Experiment, Variant: assignment.Variant}: 1,
+	})
+}
+
+// restrictWeights zeroes the weight of every strategy not in the set
+func restrictWeights(weights models.HybridWeights, strategies []string) models.HybridWeights {
+	enabled := make(map[string]bool, len(strategies))
+	for _, strategy := range strategies {
+		enabled[strategy] = true
+	}
+
+	if !enabled["UserFrequency"] {
+		weights.UserFrequency = 0
+	}
+	if !enabled["UserCoOrders"] {
+		weights.UserCoOrders = 0
+	}
+	if !enabled["GlobalCoOrders"] {
+		weights.GlobalCoOrders = 0
+	}
+	if !enabled["TimeBasedTrend"] {
+		weights.TimeBasedTrend = 0
+	}
+	if !enabled["PriceSensitivity"] {
+		weights.PriceSensitivity = 0
+	}
+	if !enabled["Ratings"] {
+		weights.Ratings = 0
+	}
+
+	return weights
+}
+
+// validateExperiment checks variant names, allocations, profiles and strategies
+func (s *RecommendationService) validateExperiment(ctx context.Context, experiment models.Experiment) error {
+	if experiment.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidExperiment)
+	}
+	if len(experiment.Variants) < 2 {
+		return fmt.Errorf("%w: at least two variants are required", ErrInvalidExperiment)
+	}
+
+	known := make(map[string]bool, len(hybridStrategies))
+	for _, strategy := range hybridStrategies {
+		known[strategy] = true
+	}
+
+	names := make(map[string]bool)
+	for _, variant := range experiment.Variants {
+		if variant.Name == "" || names[variant.Name] {
+			return fmt.Errorf("%w: variant names must be unique and non-empty", ErrInvalidExperiment)
+		}
+		names[variant.Name] = true
+
+		if variant.Allocation <= 0 {
+			return fmt.Errorf("%w: variant %s needs a positive allocation", ErrInvalidExperiment, variant.Name)
+		}
+		for _, strategy := range variant.Strategies {
+			if !known[strategy] {
+				return fmt.Errorf("%w: variant %s uses unknown strategy %q", ErrInvalidExperiment, variant.Name, strategy)
+			}
+		}
+
+		// The variant's weights are its profile's, or else those of each user's segment
+		base := make(map[string]models.HybridWeights)
+		if variant.Profile != "" {
+			profile, err := s.GetWeightProfile(ctx, variant.Profile, 0)
+			if errors.Is(err, ErrWeightProfileNotFound) {
+				return fmt.Errorf("%w: variant %s uses unknown profile %q", ErrInvalidExperiment, variant.Name, variant.Profile)
+			} else if err != nil {
+				return err
+			}
+			base["profile "+variant.Profile] = profile.Weights
+		} else {
+			for _, segment := range []string{SegmentDefault, SegmentNewUser, SegmentExperiencedUser} {
+				base[segment+" segment"], _ = s.GetWeightsForSegment(ctx, segment)
+			}
+		}
+		if len(variant.Strategies) > 0 {
+			for source, weights := range base {
+				if ValidateWeights(restrictWeights(weights, variant.Strategies)) != nil {
+					return fmt.Errorf("%w: variant %s has no strategy with weight in the %s", ErrInvalidExperiment, variant.Name, source)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadExperiments reads experiments with their variants, optionally by name or only the active one
+func (s *RecommendationService) loadExperiments(ctx context.Context, name string, activeOnly bool) ([]models.Experiment, error) {
+	query := `
+		MATCH (e:Experiment)
+		WHERE ($name = "" OR e.name = $name) AND (NOT $activeOnly OR e.active)
+		OPTIONAL MATCH (e)-[:HAS_VARIANT]->(v:Variant)
+		WITH e, v
+		ORDER BY e.created_at, v.position
+		RETURN e.name AS name,
+			   e.description AS description,
+			   e.active AS active,
+			   e.created_at AS created_at,
+			   collect({
+				   name: v.name,
+				   allocation: v.allocation,
+				   profile: v.profile,
+				   strategies: v.strategies,
+				   exposures: v.exposures
+			   }) AS variants
+		ORDER BY created_at
+	`
+
+	params := map[string]interface{}{
+		"name":       name,
+		"activeOnly": activeOnly,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiments: %w", err)
+	}
+
+	rows, err := database.DecodeAll[experimentRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode experiments: %w", err)
+	}
+
+	experiments := make([]models.Experiment, 0, len(rows))
+	for _, row := range rows {
+		experiment := models.Experiment{
+			Name:        row.Name,
+			Description: row.Description,
+			Active:      row.Active,
+			CreatedAt:   row.CreatedAt,
+		}
+
+		for _, v := range row.Variants {
+			// An experiment without variants collects one all-null map
+			if v.Name == "" {
+				continue
+			}
+			experiment.Variants = append(experiment.Variants, models.ExperimentVariant(v))
+		}
+
+		experiments = append(experiments, experiment)
+	}
+
+	return experiments, nil
+}
+
+// experimentRow is an experiment with its variants collected in position order
+type experimentRow struct {
+	Name        string       `db:"name"`
+	Description string       `db:"description,optional"`
+	Active      bool         `db:"active"`
+	CreatedAt   time.Time    `db:"created_at,optional"`
+	Variants    []variantRow `db:"variants"`
+}
+
+// variantRow is one collected variant; every column is optional because of the OPTIONAL MATCH
+type variantRow struct {
+	Name       string   `db:"name,optional"`
+	Allocation int      `db:"allocation,optional"`
+	Profile    string   `db:"profile,optional"`
+	Strategies []string `db:"strategies,optional"`
+	Exposures  int64    `db:"exposures,optional"`
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func TestAssignVariantIsSticky(t *testing.T) {
+	experiment := models.Experiment{
+		Name: "ratings-boost",
+		Variants: []models.ExperimentVariant{
+			{Name: "control", Allocation: 50},
+			{Name: "treatment", Allocation: 50},
+		},
+	}
+
+	for i := 0; i < 100; i++ {
+		unit := "session-" + strconv.Itoa(i)
+		first := AssignVariant(experiment, unit).Name
+		for j := 0; j < 5; j++ {
+			if got := AssignVariant(experiment, unit).Name; got != first {
+				t.Fatalf("unit %s moved from %s to %s", unit, first, got)
+			}
+		}
+	}
+}
+
+func TestAssignVariantSplits(t *testing.T) {
+	tests := []struct {
+		name        string
+		allocations []int
+	}{
+		{"even", []int{50, 50}},
+		{"uneven", []int{90, 10}},
+		{"three ways", []int{1, 1, 2}},
+	}
+
+	const units = 20000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			experiment := models.Experiment{Name: "split-" + tt.name}
+			total := 0
+			for i, allocation := range tt.allocations {
+				experiment.Variants = append(experiment.Variants, models.ExperimentVariant{Name: strconv.Itoa(i), Allocation: allocation})
+				total += allocation
+			}
+
+			counts := make(map[string]int)
+			for i := 0; i < units; i++ {
+				counts[AssignVariant(experiment, strconv.Itoa(i)).Name]++
+			}
+
+			for i, allocation := range tt.allocations {
+				want := float64(allocation) / float64(total)
+				got := float64(counts[strconv.Itoa(i)]) / units
+				if math.Abs(got-want) > 0.02 {
+					t.Errorf("variant %d got %.3f of units, want %.3f", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignVariantDependsOnExperiment(t *testing.T) {
+	variants := []models.ExperimentVariant{{Name: "a", Allocation: 1}, {Name: "b", Allocation: 1}}
+	first := models.Experiment{Name: "first", Variants: variants}
+	second := models.Experiment{Name: "second", Variants: variants}
+
+	// Units must be reshuffled between experiments, not always land together
+	differ := 0
+	for i := 0; i < 1000; i++ {
+		unit := strconv.Itoa(i)
+		if AssignVariant(first, unit).Name != AssignVariant(second, unit).Name {
+			differ++
+		}
+	}
+	if differ < 400 || differ > 600 {
+		t.Errorf("%d of 1000 units changed variant between experiments, want about half", differ)
+	}
+}
+
+func TestRestrictWeights(t *testing.T) {
+	weights := models.HybridWeights{
+		UserFrequency:    0.1,
+		UserCoOrders:     0.2,
+		GlobalCoOrders:   0.3,
+		TimeBasedTrend:   0.4,
+		PriceSensitivity: 0,
+		Ratings:          0.5,
+	}
+
+	got := restrictWeights(weights, []string{"GlobalCoOrders", "Ratings"})
+	want := models.HybridWeights{GlobalCoOrders: 0.3, Ratings: 0.5}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// A strategy without base weight leaves nothing to blend
+	if err := ValidateWeights(restrictWeights(weights, []string{"PriceSensitivity"})); err == nil {
+		t.Error("expected all-zero weights to be invalid")
+	}
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+)
+
+// CheckDerivedGraph compares the stored HAS_ORDERED and ORDERED_ALONG_WITH relationships with
+// ones recomputed from the raw orders and, when repair is set, rewrites the ones that differ
+// and deletes expired "not interested" marks
+func (s *RecommendationService) CheckDerivedGraph(ctx context.Context, repair bool, batchSize int) (database.GraphReport, error) {
+	report, err := database.CheckDerivedGraph(ctx, s.client)
+	if err != nil {
+		return report, fmt.Errorf("failed to check derived graph: %w", err)
+	}
+
+	if repair && !report.Consistent {
+		if err := database.RepairDerivedGraph(ctx, s.client, &report, batchSize); err != nil {
+			return report, fmt.Errorf("failed to repair derived graph: %w", err)
+		}
+	}
+	if repair {
+		pruned, err := s.PruneExpiredSuppressions(ctx)
+		if err != nil {
+			return report, err
+		}
+		report.PrunedSuppressions = pruned
+	}
+
+	return report, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// hybridResultLimit caps how many hybrid recommendations are returned
+const hybridResultLimit = 10
+
+// HybridQuery is a request for hybrid recommendations as the APIs accept it
+type HybridQuery struct {
+	UserID     int
+	ItemInCart *int
+	// Profile selects a weight profile (ProfileVersion 0 is the latest) instead of the
+	// weights of the user's segment
+	Profile        string
+	ProfileVersion int
+	Overrides      WeightOverrides
+	// ExperimentUnit decides the experiment variant, e.g. a session ID; the user ID is used when empty
+	ExperimentUnit string
+	Price          PriceFilter
+	Diversity      DiversityOptions
+	Mode           RecommendationMode
+	MixRatio       float64
+	// Exclude lists items that must not be recommended, such as the rest of the cart
+	Exclude []int
+}
+
+// WeightOverrides replaces individual hybrid weights; nil fields keep the chosen weights
+type WeightOverrides struct {
+	UserFrequency    *float64
+	UserCoOrders     *float64
+	GlobalCoOrders   *float64
+	TimeBasedTrend   *float64
+	PriceSensitivity *float64
+	Ratings          *float64
+}
+
+// Any reports whether any weight is overridden
+func (o WeightOverrides) Any() bool {
+	return o.UserFrequency != nil || o.UserCoOrders != nil || o.GlobalCoOrders != nil ||
+		o.TimeBasedTrend != nil || o.PriceSensitivity != nil || o.Ratings != nil
+}
+
+// apply replaces the overridden weights
+func (o WeightOverrides) apply(weights *models.HybridWeights) {
+	overrides := []struct {
+		value  *float64
+		weight *float64
+	}{
+		{o.UserFrequency, &weights.UserFrequency},
+		{o.UserCoOrders, &weights.UserCoOrders},
+		{o.GlobalCoOrders, &weights.GlobalCoOrders},
+		{o.TimeBasedTrend, &weights.TimeBasedTrend},
+		{o.PriceSensitivity, &weights.PriceSensitivity},
+		{o.Ratings, &weights.Ratings},
+	}
+	for _, override := range overrides {
+		if override.value != nil {
+			*override.weight = *override.value
+		}
+	}
+}
+
+// HybridResult is a set of hybrid recommendations with the settings that produced it
+type HybridResult struct {
+	Segment         string
+	Profile         *models.WeightProfile
+	Experiment      *models.ExperimentAssignment
+	Weights         models.HybridWeights
+	Recommendations []models.Recommendation
+}
+
+// RecommendHybrid runs the full hybrid pipeline. An explicitly requested profile wins
+// over the profile or built-in weights of the user's segment, and callers that choose
+// neither a profile nor weight overrides are enrolled in the running experiment. The
+// blended results are then price filtered, diversified, shaped by the mode and cut to
+// the top ten. A requested profile that does not exist is ErrWeightProfileNotFound, and
+// weights that end up all zero are ErrInvalidWeights.
+func (s *RecommendationService) RecommendHybrid(ctx context.Context, query HybridQuery) (HybridResult, error) {
+	result := HybridResult{Segment: s.GetUserSegment(ctx, query.UserID)}
+
+	if query.Profile != "" {
+		profile, err := s.GetWeightProfile(ctx, query.Profile, query.ProfileVersion)
+		if err != nil {
+			return HybridResult{}, err
+		}
+		result.Weights, result.Profile = profile.Weights, &profile
+	} else {
+		result.Weights, result.Profile = s.GetWeightsForSegment(ctx, result.Segment)
+	}
+
+	if query.Profile == "" && !query.Overrides.Any() {
+		unit := query.ExperimentUnit
+		if unit == "" {
+			unit = strconv.Itoa(query.UserID)
+		}
+
+		var err error
+		result.Weights, result.Profile, result.Experiment, err = s.ApplyExperiment(ctx, unit, result.Weights, result.Profile)
+		if err != nil {
+			return HybridResult{}, err
+		}
+	}
+
+	query.Overrides.apply(&result.Weights)
+	if err := ValidateWeights(result.Weights); err != nil {
+		return HybridResult{}, err
+	}
+
+	recommendations, err := s.HybridRecommendation(ctx, query.UserID, query.ItemInCart, result.Weights)
+	if err != nil {
+		return HybridResult{}, err
+	}
+
+	recommendations = excludeItems(recommendations, query.Exclude)
+	recommendations = s.FilterRecommendationsByPrice(recommendations, query.Price)
+	recommendations = s.DiversifyRecommendations(recommendations, query.Diversity)
+
+	recommendations, err = s.ApplyRecommendationMode(ctx, query.UserID, recommendations, query.Mode, query.MixRatio)
+	if err != nil {
+		return HybridResult{}, err
+	}
+
+	if len(recommendations) > hybridResultLimit {
+		recommendations = recommendations[:hybridResultLimit]
+	}
+	result.Recommendations = recommendations
+
+	// Only responses that were actually served count as exposures
+	if result.Experiment != nil {
+		if err := s.RecordExposure(ctx, *result.Experiment); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// excludeItems drops recommendations of the given items
+func excludeItems(recommendations []models.Recommendation, itemIDs []int) []models.Recommendation {
+	if len(itemIDs) == 0 {
+		return recommendations
+	}
+
+	excluded := make(map[int]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		excluded[id] = true
+	}
+
+	kept := recommendations[:0]
+	for _, rec := range recommendations {
+		if !excluded[rec.Item.DbID] {
+			kept = append(kept, rec)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+var (
+	// ErrInvalidItem is returned for items without a name or category, or with a non-positive price
+	ErrInvalidItem = newError(KindInvalidArgument, "invalid item")
+	// ErrItemUnavailable is returned when ordering an item that was withdrawn from the menu
+	ErrItemUnavailable = newError(KindConflict, "item is no longer available")
+	// ErrInvalidCategory is returned for categories without a name
+	ErrInvalidCategory = newError(KindInvalidArgument, "invalid category")
+	// ErrCategoryExists is returned when creating or renaming onto a category name that is taken
+	ErrCategoryExists = newError(KindConflict, "category already exists")
+	// ErrCategoryNotEmpty is returned when deleting a category that still holds items
+	ErrCategoryNotEmpty = newError(KindConflict, "category still has items")
+)
+
+// ItemUpdate lists the item fields to change; nil fields are left as they are
+type ItemUpdate struct {
+	Name        *string
+	Price       *float64
+	Category    *string
+	Description *string
+	Available   *bool
+}
+
+// CategoryUpdate lists the category fields to change; nil fields are left as they are
+type CategoryUpdate struct {
+	Name        *string
+	Description *string
+}
+
+// ListMenuItems returns every item, including withdrawn ones, ordered by category and name
+func (s *RecommendationService) ListMenuItems(ctx context.Context) ([]models.MenuItem, error) {
+	query := `
+		MATCH (i:Item)
+		RETURN i.db_id AS item_id,
+			   i.name AS name,
+			   i.price AS price,
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   i.description AS description,
+			   coalesce(i.available, true) AS available
+		ORDER BY category, name
+	`
+
+	results, err := s.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list menu items: %w", err)
+	}
+
+	rows, err := database.DecodeAll[menuItemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode menu items: %w", err)
+	}
+
+	items := make([]models.MenuItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.menuItem())
+	}
+
+	return items, nil
+}
+
+// GetMenuItem returns one item whether or not it is available, or nil when it does not exist
+func (s *RecommendationService) GetMenuItem(ctx context.Context, itemID int) (*models.MenuItem, error) {
+	query := `
+		MATCH (i:Item {db_id: $itemId})
+		RETURN i.db_id AS item_id,
+			   i.name AS name,
+			   i.price AS price,
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   i.description AS description,
+			   coalesce(i.available, true) AS available
+	`
+
+	params := map[string]interface{}{
+		"itemId": itemID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu item: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	row, err := database.Decode[menuItemRow](results[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode menu item: %w", err)
+	}
+
+	item := row.menuItem()
+	return &item, nil
+}
+
+// CreateMenuItem adds an available item to an existing category
+func (s *RecommendationService) CreateMenuItem(ctx context.Context, item models.Item) (models.MenuItem, error) {
+	item.Name = strings.TrimSpace(item.Name)
+	item.Category = strings.TrimSpace(item.Category)
+	if item.Name == "" || item.Category == "" {
+		return models.MenuItem{}, fmt.Errorf("%w: name and category are required", ErrInvalidItem)
+	}
+	if item.Price <= 0 {
+		return models.MenuItem{}, fmt.Errorf("%w: price must be positive", ErrInvalidItem)
+	}
+
+	// Item IDs come from a sequence node, like order IDs, so concurrent creates never share one
+	query := `
+		MATCH (c:Category {name: $category})
+		OPTIONAL MATCH (existing:Item)
+		WITH c, coalesce(max(existing.db_id), 0) AS highest
+		MERGE (seq:Sequence {name: "item"})
+		ON CREATE SET seq.value = highest
+		SET seq.value = CASE WHEN seq.value < highest THEN highest ELSE seq.value END + 1
+		CREATE (i:Item {
+			db_id: seq.value,
+			name: $name,
+			price: $price,
+			description: $description,
+			available: true,
+			created_at: $now
+		})-[:IN_CATEGORY]->(c)
+		RETURN i.db_id AS item_id
+	`
+
+	params := map[string]interface{}{
+		"category":    item.Category,
+		"name":        item.Name,
+		"price":       item.Price,
+		"description": item.Description,
+		"now":         s.now().UTC(),
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return models.MenuItem{}, fmt.Errorf("failed to create menu item: %w", err)
+	}
+	if len(results) == 0 {
+		return models.MenuItem{}, ErrCategoryNotFound
+	}
+
+	item.DbID, err = database.Value[int](results[0], "item_id")
+	if err != nil {
+		return models.MenuItem{}, fmt.Errorf("failed to decode menu item: %w", err)
+	}
+	return models.MenuItem{Item: item, Available: true}, nil
+}
+
+// UpdateMenuItem changes the given fields of an item; moving it to another category
+// replaces its IN_CATEGORY edge. Order history and derived relationships are untouched.
+func (s *RecommendationService) UpdateMenuItem(ctx context.Context, itemID int, update ItemUpdate) (models.MenuItem, error) {
+	params := map[string]interface{}{
+		"itemId":      itemID,
+		"name":        nil,
+		"price":       nil,
+		"category":    nil,
+		"description": nil,
+		"available":   nil,
+		"now":         s.now().UTC(),
+	}
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return models.MenuItem{}, fmt.Errorf("%w: name cannot be empty", ErrInvalidItem)
+		}
+		params["name"] = name
+	}
+	if update.Price != nil {
+		if *update.Price <= 0 {
+			return models.MenuItem{}, fmt.Errorf("%w: price must be positive", ErrInvalidItem)
+		}
+		params["price"] = *update.Price
+	}
+	if update.Category != nil {
+		category := strings.TrimSpace(*update.Category)
+		if category == "" {
+			return models.MenuItem{}, fmt.Errorf("%w: category cannot be empty", ErrInvalidItem)
+		}
+		params["category"] = category
+	}
+	if update.Description != nil {
+		params["description"] = *update.Description
+	}
+	if update.Available != nil {
+		params["available"] = *update.Available
+	}
+
+	err := s.client.ExecuteWriteTransactionSimple(ctx, func(tx neo4j.ManagedTransaction) error {
+		results, err := database.RunInTransaction(ctx, tx, `
+			MATCH (i:Item {db_id: $itemId})
+			SET i.name = coalesce($name, i.name),
+				i.price = coalesce($price, i.price),
+				i.description = coalesce($description, i.description),
+				i.available = coalesce($available, i.available, true),
+				i.updated_at = $now
+			RETURN i.db_id AS item_id
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to update menu item: %w", err)
+		}
+		if len(results) == 0 {
+			return ErrItemNotFound
+		}
+
+		if params["category"] == nil {
+			return nil
+		}
+		results, err = database.RunInTransaction(ctx, tx, `
+			MATCH (i:Item {db_id: $itemId})
+			MATCH (c:Category {name: $category})
+			OPTIONAL MATCH (i)-[old:IN_CATEGORY]->(:Category)
+			DELETE old
+			WITH DISTINCT i, c
+			MERGE (i)-[:IN_CATEGORY]->(c)
+			RETURN c.name AS category
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to move menu item: %w", err)
+		}
+		if len(results) == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return models.MenuItem{}, err
+	}
+
+	item, err := s.GetMenuItem(ctx, itemID)
+	if err != nil {
+		return models.MenuItem{}, err
+	}
+	if item == nil {
+		return models.MenuItem{}, ErrItemNotFound
+	}
+	return *item, nil
+}
+
+// WithdrawMenuItem soft-deletes an item: it leaves the menu and recommendations,
+// but its HAS_ITEM edges and derived relationships stay so order history is intact
+func (s *RecommendationService) WithdrawMenuItem(ctx context.Context, itemID int) error {
+	available := false
+	_, err := s.UpdateMenuItem(ctx, itemID, ItemUpdate{Available: &available})
+	return err
+}
+
+// ListCategories returns every category with its item counts
+func (s *RecommendationService) ListCategories(ctx context.Context) ([]models.Category, error) {
+	query := `
+		MATCH (c:Category)
+		OPTIONAL MATCH (i:Item)-[:IN_CATEGORY]->(c)
+		RETURN c.name AS name,
+			   c.description AS description,
+			   count(i) AS items,
+			   count(CASE WHEN coalesce(i.available, true) THEN i END) AS available_items
+		ORDER BY name
+	`
+
+	results, err := s.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	rows, err := database.DecodeAll[categoryRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode categories: %w", err)
+	}
+
+	categories := make([]models.Category, 0, len(rows))
+	for _, row := range rows {
+		categories = append(categories, models.Category(row))
+	}
+
+	return categories, nil
+}
+
+// CreateCategory adds an empty category
+func (s *RecommendationService) CreateCategory(ctx context.Context, category models.Category) (models.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return models.Category{}, fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+
+	query := `
+		OPTIONAL MATCH (existing:Category {name: $name})
+		WITH existing
+		WHERE existing IS NULL
+		CREATE (c:Category {name: $name, description: $description})
+		RETURN c.name AS name
+	`
+
+	params := map[string]interface{}{
+		"name":        category.Name,
+		"description": category.Description,
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("failed to create category: %w", err)
+	}
+	if len(results) == 0 {
+		return models.Category{}, ErrCategoryExists
+	}
+
+	return models.Category{Name: category.Name, Description: category.Description}, nil
+}
+
+// UpdateCategory renames a category or changes its description. Items follow the
+// rename automatically since they point at the node rather than at its name.
+func (s *RecommendationService) UpdateCategory(ctx context.Context, name string, update CategoryUpdate) error {
+	params := map[string]interface{}{
+		"name":        name,
+		"newName":     nil,
+		"description": nil,
+	}
+	if update.Name != nil {
+		newName := strings.TrimSpace(*update.Name)
+		if newName == "" {
+			return fmt.Errorf("%w: name cannot be empty", ErrInvalidCategory)
+		}
+		params["newName"] = newName
+	}
+	if update.Description != nil {
+		params["description"] = *update.Description
+	}
+
+	return s.client.ExecuteWriteTransactionSimple(ctx, func(tx neo4j.ManagedTransaction) error {
+		results, err := database.RunInTransaction(ctx, tx, `
+			MATCH (c:Category {name: $name})
+			OPTIONAL MATCH (other:Category {name: $newName})
+			WHERE other <> c
+			RETURN other IS NOT NULL AS taken
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to get category: %w", err)
+		}
+		if len(results) == 0 {
+			return ErrCategoryNotFound
+		}
+		taken, err := database.Value[bool](results[0], "taken")
+		if err != nil {
+			return fmt.Errorf("failed to decode category: %w", err)
+		}
+		if taken {
+			return ErrCategoryExists
+		}
+
+		_, err = database.RunInTransaction(ctx, tx, `
+			MATCH (c:Category {name: $name})
+			SET c.name = coalesce($newName, c.name),
+				c.description = coalesce($description, c.description)
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to update category: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteCategory removes a category that no item, available or withdrawn, belongs to
+func (s *RecommendationService) DeleteCategory(ctx context.Context, name string) error {
+	query := `
+		MATCH (c:Category {name: $name})
+		OPTIONAL MATCH (i:Item)-[:IN_CATEGORY]->(c)
+		WITH c, count(i) AS items
+		FOREACH (_ IN CASE WHEN items = 0 THEN [1] ELSE [] END |
+			DETACH DELETE c
+		)
+		RETURN items
+	`
+
+	params := map[string]interface{}{
+		"name": name,
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	if len(results) == 0 {
+		return ErrCategoryNotFound
+	}
+	items, err := database.Value[int](results[0], "items")
+	if err != nil {
+		return fmt.Errorf("failed to decode category: %w", err)
+	}
+	if items > 0 {
+		return ErrCategoryNotEmpty
+	}
+
+	return nil
+}
+
+// menuItemRow is an item row with its availability
+type menuItemRow struct {
+	itemRow
+	Available bool `db:"available"`
+}
+
+// menuItem converts the row into a model
+func (r menuItemRow) menuItem() models.MenuItem {
+	return models.MenuItem{Item: r.item(), Available: r.Available}
+}
+
+// categoryRow is a category with its item counts
+type categoryRow struct {
+	Name           string `db:"name"`
+	Description    string `db:"description,optional"`
+	Items          int    `db:"items"`
+	AvailableItems int    `db:"available_items"`
+}
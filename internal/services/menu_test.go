@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// The cases below are all rejected before Neo4j is asked anything, so the service needs no client
+
+func TestCreateMenuItemValidates(t *testing.T) {
+	s := NewRecommendationService(nil)
+	tests := []struct {
+		name string
+		item models.Item
+	}{
+		{"no name", models.Item{Name: "  ", Category: "Mains", Price: 9}},
+		{"no category", models.Item{Name: "Margherita", Category: "", Price: 9}},
+		{"free", models.Item{Name: "Margherita", Category: "Mains", Price: 0}},
+		{"negative price", models.Item{Name: "Margherita", Category: "Mains", Price: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateMenuItem(context.Background(), tt.item)
+			if !errors.Is(err, ErrInvalidItem) || KindOf(err) != KindInvalidArgument {
+				t.Errorf("got %v, want ErrInvalidItem", err)
+			}
+		})
+	}
+}
+
+func TestUpdateMenuItemValidates(t *testing.T) {
+	s := NewRecommendationService(nil)
+	blank, zero := " ", 0.0
+	tests := []struct {
+		name   string
+		update ItemUpdate
+	}{
+		{"blank name", ItemUpdate{Name: &blank}},
+		{"zero price", ItemUpdate{Price: &zero}},
+		{"blank category", ItemUpdate{Category: &blank}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.UpdateMenuItem(context.Background(), 1, tt.update); !errors.Is(err, ErrInvalidItem) {
+				t.Errorf("got %v, want ErrInvalidItem", err)
+			}
+		})
+	}
+}
+
+func TestCategoryValidates(t *testing.T) {
+	s := NewRecommendationService(nil)
+	blank := "\t"
+
+	if _, err := s.CreateCategory(context.Background(), models.Category{Name: " "}); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("CreateCategory got %v, want ErrInvalidCategory", err)
+	}
+	if err := s.UpdateCategory(context.Background(), "Mains", CategoryUpdate{Name: &blank}); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("UpdateCategory got %v, want ErrInvalidCategory", err)
+	}
+}
+
+func TestMenuErrorKinds(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{ErrInvalidItem, KindInvalidArgument},
+		{ErrItemUnavailable, KindConflict},
+		{ErrInvalidCategory, KindInvalidArgument},
+		{ErrCategoryExists, KindConflict},
+		{ErrCategoryNotEmpty, KindConflict},
+		{ErrCategoryNotFound, KindNotFound},
+	}
+
+	for _, tt := range tests {
+		if got := KindOf(tt.err); got != tt.want {
+			t.Errorf("KindOf(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestMenuItemRow(t *testing.T) {
+	row := menuItemRow{
+		itemRow:   itemRow{ItemID: 4, Name: "Tiramisu", Price: 6.5, Category: "Desserts"},
+		Available: false,
+	}
+
+	want := models.MenuItem{
+		Item:      models.Item{DbID: 4, Name: "Tiramisu", Price: 6.5, Category: "Desserts"},
+		Available: false,
+	}
+	if got := row.menuItem(); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// RecommendationMode selects between familiar items and new discoveries
+type RecommendationMode string
+
+const (
+	// ModeReorder keeps only items the user has ordered before ("your usuals")
+	ModeReorder RecommendationMode = "reorder"
+	// ModeExplore drops items the user has already ordered ("try something new")
+	ModeExplore RecommendationMode = "explore"
+	// ModeMixed interleaves usuals and discoveries
+	ModeMixed RecommendationMode = "mixed"
+
+	// DefaultMixRatio is the share of usuals in mixed mode
+	DefaultMixRatio = 0.5
+)
+
+// GetUserOrderedItemIDs returns the set of items the user has ordered at least once
+func (s *RecommendationService) GetUserOrderedItemIDs(ctx context.Context, userID int) (map[int]bool, error) {
+	query := `
+		MATCH (u:User {db_id: $userId})-[:HAS_ORDERED]->(i:Item)
+		RETURN i.db_id AS item_id
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ordered items: %w", err)
+	}
+
+	ordered := make(map[int]bool, len(results))
+	for _, result := range results {
+		itemID, err := database.Value[int](result, "item_id")
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode user ordered items: %w", err)
+		}
+		ordered[itemID] = true
+	}
+
+	return ordered, nil
+}
+
+// ApplyRecommendationMode splits recommendations into usuals and discoveries and
+// returns the set requested by mode. In mixed mode mixRatio is the share of usuals.
+func (s *RecommendationService) ApplyRecommendationMode(ctx context.Context, userID int, recommendations []models.Recommendation, mode RecommendationMode, mixRatio float64) ([]models.Recommendation, error) {
+	if mode == "" {
+		return recommendations, nil
+	}
+
+	ordered, err := s.GetUserOrderedItemIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var usuals, discoveries []models.Recommendation
+	for _, rec := range recommendations {
+		if ordered[rec.Item.DbID] {
+			usuals = append(usuals, rec)
+		} else {
+			discoveries = append(discoveries, rec)
+		}
+	}
+
+	switch mode {
+	case ModeReorder:
+		return usuals, nil
+	case ModeExplore:
+		return discoveries, nil
+	case ModeMixed:
+		return interleaveRecommendations(usuals, discoveries, mixRatio), nil
+	default:
+		return nil, fmt.Errorf("unknown recommendation mode %q", mode)
+	}
+}
+
+// interleaveRecommendations merges two ranked lists so that roughly ratio of
+// every prefix comes from first. Leftovers are appended once either list runs out.
+func interleaveRecommendations(first, second []models.Recommendation, ratio float64) []models.Recommendation {
+	merged := make([]models.Recommendation, 0, len(first)+len(second))
+	takenFirst := 0
+
+	for len(first) > 0 || len(second) > 0 {
+		wantFirst := float64(takenFirst) < ratio*float64(len(merged)+1)
+		if len(second) == 0 || (len(first) > 0 && wantFirst) {
+			merged = append(merged, first[0])
+			first = first[1:]
+			takenFirst++
+		} else {
+			merged = append(merged, second[0])
+			second = second[1:]
+		}
+	}
+
+	return merged
+}
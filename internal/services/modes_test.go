@@ -0,0 +1,42 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func recommendationsFor(ids ...int) []models.Recommendation {
+	recommendations := make([]models.Recommendation, len(ids))
+	for i, id := range ids {
+		recommendations[i] = models.Recommendation{Item: models.Item{DbID: id}}
+	}
+	return recommendations
+}
+
+func TestInterleaveRecommendations(t *testing.T) {
+	tests := []struct {
+		name          string
+		first, second []int
+		ratio         float64
+		want          []int
+	}{
+		{"even split alternates", []int{1, 2, 3}, []int{11, 12, 13}, 0.5, []int{1, 11, 2, 12, 3, 13}},
+		{"a third from first", []int{1, 2}, []int{11, 12, 13, 14}, 1.0 / 3, []int{1, 11, 12, 2, 13, 14}},
+		{"first runs out", []int{1}, []int{11, 12, 13}, 0.5, []int{1, 11, 12, 13}},
+		{"second runs out", []int{1, 2, 3}, []int{11}, 0.5, []int{1, 11, 2, 3}},
+		{"ratio 0 puts first last", []int{1, 2}, []int{11, 12}, 0, []int{11, 12, 1, 2}},
+		{"ratio 1 puts second last", []int{1, 2}, []int{11, 12}, 1, []int{1, 2, 11, 12}},
+		{"empty first", nil, []int{11, 12}, 0.5, []int{11, 12}},
+		{"both empty", nil, nil, 0.5, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := interleaveRecommendations(recommendationsFor(tt.first...), recommendationsFor(tt.second...), tt.ratio)
+			if got := recommendationIDs(merged); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,710 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// ErrInvalidOrder is returned for orders without items or with non-positive quantities
+var ErrInvalidOrder = newError(KindInvalidArgument, "invalid order")
+
+// OrderUpdater schedules the derived-relationship updates of new orders
+type OrderUpdater interface {
+	Enqueue(ctx context.Context, orderID int) error
+	Stats() database.QueueStats
+}
+
+// SetOrderUpdater makes new orders update derived relationships through a queue
+// instead of synchronously
+func (s *RecommendationService) SetOrderUpdater(updater OrderUpdater) {
+	s.orderUpdater = updater
+}
+
+// GetUpdateQueueStats returns the order update queue's stats, or false when orders update synchronously
+func (s *RecommendationService) GetUpdateQueueStats() (database.QueueStats, bool) {
+	if s.orderUpdater == nil {
+		return database.QueueStats{}, false
+	}
+	return s.orderUpdater.Stats(), true
+}
+
+// PlaceOrder records a new order and schedules the update of HAS_ORDERED and ORDERED_ALONG_WITH
+func (s *RecommendationService) PlaceOrder(ctx context.Context, userID int, lines []models.OrderItem) (models.Order, error) {
+	quantities, err := mergeOrderLines(lines)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	order := models.Order{
+		UserID:    userID,
+		CreatedAt: s.now().UTC(),
+	}
+
+	rows := make([]map[string]interface{}, 0, len(quantities))
+	for itemID, quantity := range quantities {
+		item, err := s.GetMenuItem(ctx, itemID)
+		if err != nil {
+			return models.Order{}, err
+		}
+		if item == nil {
+			return models.Order{}, fmt.Errorf("%w: %d", ErrItemNotFound, itemID)
+		}
+		if !item.Available {
+			return models.Order{}, fmt.Errorf("%w: %d", ErrItemUnavailable, itemID)
+		}
+
+		order.TotalAmount += item.Price * float64(quantity)
+		order.Items = append(order.Items, models.OrderItem{ItemID: itemID, Quantity: quantity})
+		rows = append(rows, map[string]interface{}{
+			"item_id":  itemID,
+			"quantity": quantity,
+		})
+	}
+	order.TotalAmount = math.Round(order.TotalAmount*100) / 100
+	sort.Slice(order.Items, func(i, j int) bool {
+		return order.Items[i].ItemID < order.Items[j].ItemID
+	})
+
+	// Order IDs come from a sequence node so concurrent orders never share an ID. The
+	// sequence survives a reload, and never falls behind the IDs of reimported orders.
+	query := `
+		MATCH (u:User {db_id: $userId})
+		OPTIONAL MATCH (existing)
+		WHERE existing:Order OR existing:CancelledOrder
+		WITH u, coalesce(max(existing.db_id), 0) AS highest
+		MERGE (seq:Sequence {name: "order"})
+		ON CREATE SET seq.value = highest
+		SET seq.value = CASE WHEN seq.value < highest THEN highest ELSE seq.value END + 1
+		CREATE (u)-[:HAS_MADE]->(o:Order {
+			db_id: seq.value,
+			created_at: $createdAt,
+			total_amount: $total
+		})
+		WITH o
+		UNWIND $lines AS line
+		MATCH (i:Item {db_id: line.item_id})
+		CREATE (o)-[:HAS_ITEM {quantity: line.quantity}]->(i)
+		RETURN DISTINCT o.db_id AS order_id
+	`
+
+	params := map[string]interface{}{
+		"userId":    userID,
+		"createdAt": order.CreatedAt,
+		"total":     order.TotalAmount,
+		"lines":     rows,
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return models.Order{}, fmt.Errorf("failed to place order: %w", err)
+	}
+	if len(results) == 0 {
+		return models.Order{}, ErrUserNotFound
+	}
+
+	order.DbID, err = database.Value[int](results[0], "order_id")
+	if err != nil {
+		return models.Order{}, fmt.Errorf("failed to decode order: %w", err)
+	}
+	for i := range order.Items {
+		order.Items[i].OrderID = order.DbID
+	}
+
+	s.scheduleOrderUpdate(ctx, order.DbID)
+
+	return order, nil
+}
+
+// scheduleOrderUpdate queues an order's derived-relationship update, applying it
+// synchronously when there is no queue or the queue cannot take it
+func (s *RecommendationService) scheduleOrderUpdate(ctx context.Context, orderID int) {
+	if s.orderUpdater != nil {
+		err := s.orderUpdater.Enqueue(ctx, orderID)
+		if err == nil {
+			return
+		}
+		log.Printf("Warning: Failed to queue update for order %d, applying it now: %v", orderID, err)
+	}
+
+	if _, err := database.ApplyOrderUpdates(context.WithoutCancel(ctx), s.client, []int{orderID}); err != nil {
+		log.Printf("Error updating relationships for order %d: %v", orderID, err)
+	}
+}
+
+// mergeOrderLines validates order lines and sums the quantities of repeated items
+func mergeOrderLines(lines []models.OrderItem) (map[int]int, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: an order needs at least one item", ErrInvalidOrder)
+	}
+
+	quantities := make(map[int]int, len(lines))
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of item %d must be positive", ErrInvalidOrder, line.ItemID)
+		}
+		quantities[line.ItemID] += line.Quantity
+	}
+
+	return quantities, nil
+}
+
+// Actions recorded in an order's audit trail
+const (
+	OrderChangeCancel = "cancel"
+	OrderChangeAmend  = "amend"
+)
+
+var (
+	// ErrOrderNotFound is returned when no order has the requested ID
+	ErrOrderNotFound = newError(KindNotFound, "order not found")
+	// ErrOrderCancelled is returned when changing an order that was already cancelled
+	ErrOrderCancelled = newError(KindConflict, "order is cancelled")
+)
+
+// CancelOrder cancels an order and takes it back out of the derived counts.
+// The order keeps its items for the audit trail but is relabelled CancelledOrder,
+// so no strategy counts it any more.
+func (s *RecommendationService) CancelOrder(ctx context.Context, orderID int, reason string) (models.OrderChange, error) {
+	return s.changeOrder(ctx, orderID, OrderChangeCancel, nil, reason)
+}
+
+// AmendOrder sets new quantities for some items of an order; quantity 0 removes an item
+// and items not yet in the order are added. Derived counts are adjusted by the difference.
+func (s *RecommendationService) AmendOrder(ctx context.Context, orderID int, lines []models.OrderItem, reason string) (models.OrderChange, error) {
+	if len(lines) == 0 {
+		return models.OrderChange{}, fmt.Errorf("%w: no quantities to change", ErrInvalidOrder)
+	}
+	for _, line := range lines {
+		if line.Quantity < 0 {
+			return models.OrderChange{}, fmt.Errorf("%w: quantity of item %d must not be negative", ErrInvalidOrder, line.ItemID)
+		}
+	}
+
+	return s.changeOrder(ctx, orderID, OrderChangeAmend, lines, reason)
+}
+
+// GetOrderChanges returns the audit trail of an order, oldest first
+func (s *RecommendationService) GetOrderChanges(ctx context.Context, orderID int) ([]models.OrderChange, error) {
+	query := `
+		MATCH (o {db_id: $orderId})
+		WHERE o:Order OR o:CancelledOrder
+		OPTIONAL MATCH (o)-[:HAS_CHANGE]->(c:OrderChange)
+		RETURN c.action AS action,
+			   c.reason AS reason,
+			   c.item_ids AS item_ids,
+			   c.old_quantities AS old_quantities,
+			   c.new_quantities AS new_quantities,
+			   c.old_total AS old_total,
+			   c.new_total AS new_total,
+			   c.refund AS refund,
+			   c.at AS at
+		ORDER BY at
+	`
+
+	params := map[string]interface{}{
+		"orderId": orderID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order changes: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, ErrOrderNotFound
+	}
+
+	rows, err := database.DecodeAll[orderChangeRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode order changes: %w", err)
+	}
+
+	changes := make([]models.OrderChange, 0, len(rows))
+	for _, row := range rows {
+		// An order that was never changed yields one all-null row
+		if row.Action == "" {
+			continue
+		}
+
+		change := models.OrderChange{
+			OrderID:  orderID,
+			Action:   row.Action,
+			Reason:   row.Reason,
+			OldTotal: row.OldTotal,
+			NewTotal: row.NewTotal,
+			Refund:   row.Refund,
+			At:       row.At,
+		}
+		if len(row.OldQuantities) != len(row.ItemIDs) || len(row.NewQuantities) != len(row.ItemIDs) {
+			return nil, fmt.Errorf("failed to decode order changes: %w: quantity lists do not match item_ids", database.ErrDecode)
+		}
+		for i, itemID := range row.ItemIDs {
+			change.Lines = append(change.Lines, models.OrderLineChange{
+				ItemID:      itemID,
+				OldQuantity: row.OldQuantities[i],
+				NewQuantity: row.NewQuantities[i],
+			})
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// orderChangeRow is one audit entry; every column is optional because of the OPTIONAL MATCH
+type orderChangeRow struct {
+	Action        string    `db:"action,optional"`
+	Reason        string    `db:"reason,optional"`
+	ItemIDs       []int     `db:"item_ids,optional"`
+	OldQuantities []int     `db:"old_quantities,optional"`
+	NewQuantities []int     `db:"new_quantities,optional"`
+	OldTotal      float64   `db:"old_total,optional"`
+	NewTotal      float64   `db:"new_total,optional"`
+	Refund        float64   `db:"refund,optional"`
+	At            time.Time `db:"at,optional"`
+}
+
+// changeOrder cancels or amends an order in one transaction: it locks the order, rewrites its
+// items, adjusts HAS_ORDERED and ORDERED_ALONG_WITH if the order was already counted
+// (deleting edges that reach zero), and records the change in the audit trail
+func (s *RecommendationService) changeOrder(ctx context.Context, orderID int, action string, lines []models.OrderItem, reason string) (models.OrderChange, error) {
+	at := s.now().UTC()
+
+	var change models.OrderChange
+	err := s.client.ExecuteWriteTransactionSimple(ctx, func(tx neo4j.ManagedTransaction) error {
+		current, err := lockOrder(ctx, tx, orderID, at)
+		if err != nil {
+			return err
+		}
+
+		newQuantities := newOrderQuantities(current.quantities, action, lines)
+
+		var added []int
+		for itemID := range newQuantities {
+			if _, ok := current.prices[itemID]; !ok {
+				added = append(added, itemID)
+			}
+		}
+		if err := loadItemPrices(ctx, tx, added, current.prices); err != nil {
+			return err
+		}
+
+		change = models.OrderChange{
+			OrderID:  orderID,
+			Action:   action,
+			Reason:   reason,
+			OldTotal: current.total,
+			At:       at,
+		}
+		if err := priceOrderChange(&change, current, newQuantities); err != nil {
+			return err
+		}
+
+		if action == OrderChangeAmend {
+			if err := rewriteOrderItems(ctx, tx, orderID, change.Lines, change.NewTotal); err != nil {
+				return err
+			}
+		}
+
+		if current.derived {
+			if err := adjustDerivedCounts(ctx, tx, current.userID, current.quantities, newQuantities); err != nil {
+				return err
+			}
+		}
+
+		if action == OrderChangeCancel {
+			cancelQuery := `
+				MATCH (o:Order {db_id: $orderId})
+				REMOVE o:Order
+				SET o:CancelledOrder, o.cancelled_at = $at
+			`
+			if _, err := database.RunInTransaction(ctx, tx, cancelQuery, map[string]interface{}{"orderId": orderID, "at": at}); err != nil {
+				return fmt.Errorf("failed to cancel order: %w", err)
+			}
+		}
+
+		return recordOrderChange(ctx, tx, change)
+	})
+	if err != nil {
+		return models.OrderChange{}, err
+	}
+
+	return change, nil
+}
+
+// newOrderQuantities is the quantity of every item after a change: nothing for a cancel,
+// the current quantities overwritten by the amended lines for an amend
+func newOrderQuantities(current map[int]int, action string, lines []models.OrderItem) map[int]int {
+	quantities := make(map[int]int, len(current))
+	if action != OrderChangeAmend {
+		return quantities
+	}
+	for itemID, quantity := range current {
+		quantities[itemID] = quantity
+	}
+	for _, line := range lines {
+		quantities[line.ItemID] = line.Quantity
+	}
+	return quantities
+}
+
+// priceOrderChange fills in the changed lines, new total and refund of a change, given the
+// order's state and prices of every item involved. Only changed lines are priced, so
+// unchanged lines keep what was originally charged.
+func priceOrderChange(change *models.OrderChange, current lockedOrder, newQuantities map[int]int) error {
+	newTotal := current.total
+	for itemID := range unionKeys(current.quantities, newQuantities) {
+		oldQuantity, newQuantity := current.quantities[itemID], newQuantities[itemID]
+		if oldQuantity == newQuantity {
+			continue
+		}
+		change.Lines = append(change.Lines, models.OrderLineChange{
+			ItemID:      itemID,
+			OldQuantity: oldQuantity,
+			NewQuantity: newQuantity,
+		})
+		newTotal += current.prices[itemID] * float64(newQuantity-oldQuantity)
+	}
+	sort.Slice(change.Lines, func(i, j int) bool {
+		return change.Lines[i].ItemID < change.Lines[j].ItemID
+	})
+
+	if change.Action == OrderChangeCancel {
+		newTotal = 0
+	} else {
+		if len(change.Lines) == 0 {
+			return fmt.Errorf("%w: no quantities changed", ErrInvalidOrder)
+		}
+		if countPositive(newQuantities) == 0 {
+			return fmt.Errorf("%w: an amended order needs at least one item; cancel it instead", ErrInvalidOrder)
+		}
+	}
+	change.NewTotal = math.Round(math.Max(newTotal, 0)*100) / 100
+	change.Refund = math.Round((change.OldTotal-change.NewTotal)*100) / 100
+	return nil
+}
+
+// lockedOrder is an order's state read at the start of a change
+type lockedOrder struct {
+	userID     int
+	derived    bool
+	total      float64
+	quantities map[int]int
+	prices     map[int]float64
+}
+
+// lockedOrderRow is the row lockOrder reads
+type lockedOrderRow struct {
+	UserID  int            `db:"user_id"`
+	Derived bool           `db:"derived"`
+	Total   float64        `db:"total"`
+	Lines   []orderLineRow `db:"lines"`
+}
+
+// orderLineRow is one collected item of a locked order; item_id is null when the order has none
+type orderLineRow struct {
+	ItemID   *int    `db:"item_id"`
+	Quantity int     `db:"quantity,optional"`
+	Price    float64 `db:"price,optional"`
+}
+
+// itemPriceRow is an item's price and whether it can still be ordered
+type itemPriceRow struct {
+	ItemID    int     `db:"item_id"`
+	Price     float64 `db:"price"`
+	Available bool    `db:"available"`
+}
+
+// lockOrder takes the order's write lock and reads its user, items and whether it was counted
+func lockOrder(ctx context.Context, tx neo4j.ManagedTransaction, orderID int, at time.Time) (lockedOrder, error) {
+	query := `
+		MATCH (u:User)-[:HAS_MADE]->(o:Order {db_id: $orderId})
+		SET o.updated_at = $at
+		WITH u, o
+		OPTIONAL MATCH (o)-[hi:HAS_ITEM]->(i:Item)
+		RETURN u.db_id AS user_id,
+			   o.derived_at IS NOT NULL AS derived,
+			   o.total_amount AS total,
+			   collect({item_id: i.db_id, quantity: hi.quantity, price: i.price}) AS lines
+	`
+
+	results, err := database.RunInTransaction(ctx, tx, query, map[string]interface{}{"orderId": orderID, "at": at})
+	if err != nil {
+		return lockedOrder{}, fmt.Errorf("failed to lock order: %w", err)
+	}
+	if len(results) == 0 {
+		cancelled, err := database.RunInTransaction(ctx, tx, `MATCH (o:CancelledOrder {db_id: $orderId}) RETURN o.db_id AS order_id`, map[string]interface{}{"orderId": orderID})
+		if err != nil {
+			return lockedOrder{}, fmt.Errorf("failed to look up order: %w", err)
+		}
+		if len(cancelled) > 0 {
+			return lockedOrder{}, ErrOrderCancelled
+		}
+		return lockedOrder{}, ErrOrderNotFound
+	}
+
+	row, err := database.Decode[lockedOrderRow](results[0])
+	if err != nil {
+		return lockedOrder{}, fmt.Errorf("failed to decode order: %w", err)
+	}
+
+	order := lockedOrder{
+		userID:     row.UserID,
+		derived:    row.Derived,
+		total:      row.Total,
+		quantities: make(map[int]int),
+		prices:     make(map[int]float64),
+	}
+	for _, line := range row.Lines {
+		// An order without items collects one all-null line
+		if line.ItemID == nil {
+			continue
+		}
+		order.quantities[*line.ItemID] += line.Quantity
+		order.prices[*line.ItemID] = line.Price
+	}
+
+	return order, nil
+}
+
+// loadItemPrices adds the prices of items being added to an order, failing if one does not exist or was withdrawn
+func loadItemPrices(ctx context.Context, tx neo4j.ManagedTransaction, itemIDs []int, prices map[int]float64) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		MATCH (i:Item)
+		WHERE i.db_id IN $itemIds
+		RETURN i.db_id AS item_id, i.price AS price, coalesce(i.available, true) AS available
+	`
+
+	results, err := database.RunInTransaction(ctx, tx, query, map[string]interface{}{"itemIds": itemIDs})
+	if err != nil {
+		return fmt.Errorf("failed to get item prices: %w", err)
+	}
+	rows, err := database.DecodeAll[itemPriceRow](results)
+	if err != nil {
+		return fmt.Errorf("failed to decode item prices: %w", err)
+	}
+	for _, row := range rows {
+		if !row.Available {
+			return fmt.Errorf("%w: %d", ErrItemUnavailable, row.ItemID)
+		}
+		prices[row.ItemID] = row.Price
+	}
+
+	for _, itemID := range itemIDs {
+		if _, ok := prices[itemID]; !ok {
+			return fmt.Errorf("%w: %d", ErrItemNotFound, itemID)
+		}
+	}
+	return nil
+}
+
+// rewriteOrderItems replaces the HAS_ITEM edges of changed lines and stores the new total
+func rewriteOrderItems(ctx context.Context, tx neo4j.ManagedTransaction, orderID int, lines []models.OrderLineChange, total float64) error {
+	rows := make([]map[string]interface{}, len(lines))
+	itemIDs := make([]int, len(lines))
+	for i, line := range lines {
+		rows[i] = map[string]interface{}{"item_id": line.ItemID, "quantity": line.NewQuantity}
+		itemIDs[i] = line.ItemID
+	}
+
+	deleteQuery := `
+		MATCH (o:Order {db_id: $orderId})-[hi:HAS_ITEM]->(i:Item)
+		WHERE i.db_id IN $itemIds
+		DELETE hi
+	`
+	if _, err := database.RunInTransaction(ctx, tx, deleteQuery, map[string]interface{}{"orderId": orderID, "itemIds": itemIDs}); err != nil {
+		return fmt.Errorf("failed to remove order items: %w", err)
+	}
+
+	createQuery := `
+		MATCH (o:Order {db_id: $orderId})
+		SET o.total_amount = $total
+		WITH o
+		UNWIND $lines AS line
+		WITH o, line
+		WHERE line.quantity > 0
+		MATCH (i:Item {db_id: line.item_id})
+		CREATE (o)-[:HAS_ITEM {quantity: line.quantity}]->(i)
+	`
+	params := map[string]interface{}{
+		"orderId": orderID,
+		"total":   total,
+		"lines":   rows,
+	}
+	if _, err := database.RunInTransaction(ctx, tx, createQuery, params); err != nil {
+		return fmt.Errorf("failed to write order items: %w", err)
+	}
+
+	return nil
+}
+
+// adjustDerivedCounts applies the difference between an order's old and new contents to
+// HAS_ORDERED and ORDERED_ALONG_WITH, deleting edges whose count reaches zero
+func adjustDerivedCounts(ctx context.Context, tx neo4j.ManagedTransaction, userID int, oldQuantities, newQuantities map[int]int) error {
+	itemChanges, pairChanges := derivedDeltas(oldQuantities, newQuantities)
+
+	itemDeltas := make([]map[string]interface{}, 0, len(itemChanges))
+	for itemID, delta := range itemChanges {
+		itemDeltas = append(itemDeltas, map[string]interface{}{"item_id": itemID, "delta": delta})
+	}
+	pairDeltas := make([]map[string]interface{}, 0, len(pairChanges))
+	for pair, delta := range pairChanges {
+		pairDeltas = append(pairDeltas, map[string]interface{}{"a": pair[0], "b": pair[1], "delta": delta})
+	}
+
+	hasOrderedQuery := `
+		MATCH (u:User {db_id: $userId})
+		UNWIND $deltas AS d
+		MATCH (i:Item {db_id: d.item_id})
+		MERGE (u)-[ho:HAS_ORDERED]->(i)
+		SET ho.times = coalesce(ho.times, 0) + d.delta
+		WITH ho
+		WHERE ho.times <= 0
+		DELETE ho
+	`
+	if _, err := database.RunInTransaction(ctx, tx, hasOrderedQuery, map[string]interface{}{"userId": userID, "deltas": itemDeltas}); err != nil {
+		return fmt.Errorf("failed to adjust HAS_ORDERED relationships: %w", err)
+	}
+
+	alongWithQuery := `
+		UNWIND $deltas AS d
+		MATCH (i1:Item {db_id: d.a})
+		MATCH (i2:Item {db_id: d.b})
+		MERGE (i1)-[oaw1:ORDERED_ALONG_WITH]->(i2)
+		SET oaw1.times = coalesce(oaw1.times, 0) + d.delta
+		MERGE (i2)-[oaw2:ORDERED_ALONG_WITH]->(i1)
+		SET oaw2.times = coalesce(oaw2.times, 0) + d.delta
+		WITH oaw1, oaw2
+		WHERE oaw1.times <= 0
+		DELETE oaw1, oaw2
+	`
+	if _, err := database.RunInTransaction(ctx, tx, alongWithQuery, map[string]interface{}{"deltas": pairDeltas}); err != nil {
+		return fmt.Errorf("failed to adjust ORDERED_ALONG_WITH relationships: %w", err)
+	}
+
+	return nil
+}
+
+// derivedDeltas is how an order's change moves HAS_ORDERED.times per item and
+// ORDERED_ALONG_WITH.times per pair; a pair counts once per order that contains both items
+func derivedDeltas(oldQuantities, newQuantities map[int]int) (map[int]int, map[[2]int]int) {
+	items := make(map[int]int)
+	for itemID := range unionKeys(oldQuantities, newQuantities) {
+		if delta := newQuantities[itemID] - oldQuantities[itemID]; delta != 0 {
+			items[itemID] = delta
+		}
+	}
+
+	pairs := make(map[[2]int]int)
+	oldPairs, newPairs := orderPairs(oldQuantities), orderPairs(newQuantities)
+	for pair := range oldPairs {
+		if !newPairs[pair] {
+			pairs[pair] = -1
+		}
+	}
+	for pair := range newPairs {
+		if !oldPairs[pair] {
+			pairs[pair] = 1
+		}
+	}
+	return items, pairs
+}
+
+// recordOrderChange appends a change to the order's audit trail
+func recordOrderChange(ctx context.Context, tx neo4j.ManagedTransaction, change models.OrderChange) error {
+	itemIDs := make([]int, len(change.Lines))
+	oldQuantities := make([]int, len(change.Lines))
+	newQuantities := make([]int, len(change.Lines))
+	for i, line := range change.Lines {
+		itemIDs[i] = line.ItemID
+		oldQuantities[i] = line.OldQuantity
+		newQuantities[i] = line.NewQuantity
+	}
+
+	query := `
+		MATCH (o {db_id: $orderId})
+		WHERE o:Order OR o:CancelledOrder
+		CREATE (o)-[:HAS_CHANGE]->(:OrderChange {
+			action: $action,
+			reason: $reason,
+			item_ids: $itemIds,
+			old_quantities: $oldQuantities,
+			new_quantities: $newQuantities,
+			old_total: $oldTotal,
+			new_total: $newTotal,
+			refund: $refund,
+			at: $at
+		})
+	`
+
+	params := map[string]interface{}{
+		"orderId":       change.OrderID,
+		"action":        change.Action,
+		"reason":        change.Reason,
+		"itemIds":       itemIDs,
+		"oldQuantities": oldQuantities,
+		"newQuantities": newQuantities,
+		"oldTotal":      change.OldTotal,
+		"newTotal":      change.NewTotal,
+		"refund":        change.Refund,
+		"at":            change.At,
+	}
+
+	if _, err := database.RunInTransaction(ctx, tx, query, params); err != nil {
+		return fmt.Errorf("failed to record order change: %w", err)
+	}
+	return nil
+}
+
+// orderPairs returns every pair of distinct items present in an order, smaller ID first
+func orderPairs(quantities map[int]int) map[[2]int]bool {
+	var present []int
+	for itemID, quantity := range quantities {
+		if quantity > 0 {
+			present = append(present, itemID)
+		}
+	}
+
+	pairs := make(map[[2]int]bool)
+	for i := 0; i < len(present); i++ {
+		for j := i + 1; j < len(present); j++ {
+			pairs[pairKey(present[i], present[j])] = true
+		}
+	}
+	return pairs
+}
+
+// unionKeys returns the item IDs present in either map
+func unionKeys(a, b map[int]int) map[int]bool {
+	keys := make(map[int]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+// countPositive counts items with a positive quantity
+func countPositive(quantities map[int]int) int {
+	count := 0
+	for _, quantity := range quantities {
+		if quantity > 0 {
+			count++
+		}
+	}
+	return count
+}
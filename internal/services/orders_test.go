@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func TestMergeOrderLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []models.OrderItem
+		want    map[int]int
+		wantErr bool
+	}{
+		{"repeated items are summed", []models.OrderItem{{ItemID: 1, Quantity: 2}, {ItemID: 2, Quantity: 1}, {ItemID: 1, Quantity: 3}}, map[int]int{1: 5, 2: 1}, false},
+		{"no lines", nil, nil, true},
+		{"zero quantity", []models.OrderItem{{ItemID: 1, Quantity: 0}}, nil, true},
+		{"negative quantity", []models.OrderItem{{ItemID: 1, Quantity: 2}, {ItemID: 2, Quantity: -1}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeOrderLines(tt.lines)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOrder) {
+					t.Errorf("got %v, want ErrInvalidOrder", err)
+				}
+				return
+			}
+			if err != nil || !maps.Equal(got, tt.want) {
+				t.Errorf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOrderQuantities(t *testing.T) {
+	current := map[int]int{1: 2, 2: 1}
+
+	if got := newOrderQuantities(current, OrderChangeCancel, nil); len(got) != 0 {
+		t.Errorf("cancel left %v", got)
+	}
+
+	got := newOrderQuantities(current, OrderChangeAmend, []models.OrderItem{{ItemID: 2, Quantity: 0}, {ItemID: 3, Quantity: 4}})
+	if want := map[int]int{1: 2, 2: 0, 3: 4}; !maps.Equal(got, want) {
+		t.Errorf("amend got %v, want %v", got, want)
+	}
+	if want := map[int]int{1: 2, 2: 1}; !maps.Equal(current, want) {
+		t.Errorf("amend changed the current quantities to %v", current)
+	}
+}
+
+func TestPriceOrderChange(t *testing.T) {
+	// Item 1 was charged 4.50 each; item 3 is being added at today's price
+	current := lockedOrder{
+		total:      12.00,
+		quantities: map[int]int{1: 2, 2: 1},
+		prices:     map[int]float64{1: 4.50, 2: 3.00, 3: 2.25},
+	}
+
+	tests := []struct {
+		name       string
+		action     string
+		quantities map[int]int
+		wantLines  []models.OrderLineChange
+		wantTotal  float64
+		wantRefund float64
+		wantErr    bool
+	}{
+		{
+			name:       "cancel refunds everything",
+			action:     OrderChangeCancel,
+			quantities: map[int]int{},
+			wantLines:  []models.OrderLineChange{{ItemID: 1, OldQuantity: 2}, {ItemID: 2, OldQuantity: 1}},
+			wantTotal:  0,
+			wantRefund: 12.00,
+		},
+		{
+			name:       "remove a line",
+			action:     OrderChangeAmend,
+			quantities: map[int]int{1: 2, 2: 0},
+			wantLines:  []models.OrderLineChange{{ItemID: 2, OldQuantity: 1}},
+			wantTotal:  9.00,
+			wantRefund: 3.00,
+		},
+		{
+			name:       "add a line costs extra",
+			action:     OrderChangeAmend,
+			quantities: map[int]int{1: 1, 2: 1, 3: 2},
+			wantLines:  []models.OrderLineChange{{ItemID: 1, OldQuantity: 2, NewQuantity: 1}, {ItemID: 3, NewQuantity: 2}},
+			wantTotal:  12.00,
+			wantRefund: 0,
+		},
+		{
+			name:       "nothing changed",
+			action:     OrderChangeAmend,
+			quantities: map[int]int{1: 2, 2: 1},
+			wantErr:    true,
+		},
+		{
+			name:       "amended to nothing",
+			action:     OrderChangeAmend,
+			quantities: map[int]int{1: 0, 2: 0},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := models.OrderChange{Action: tt.action, OldTotal: current.total}
+			err := priceOrderChange(&change, current, tt.quantities)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOrder) {
+					t.Errorf("got %v, want ErrInvalidOrder", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(change.Lines, tt.wantLines) {
+				t.Errorf("got lines %v, want %v", change.Lines, tt.wantLines)
+			}
+			if change.NewTotal != tt.wantTotal || change.Refund != tt.wantRefund {
+				t.Errorf("got total %v and refund %v, want %v and %v", change.NewTotal, change.Refund, tt.wantTotal, tt.wantRefund)
+			}
+		})
+	}
+}
+
+func TestDerivedDeltas(t *testing.T) {
+	tests := []struct {
+		name      string
+		old, new  map[int]int
+		wantItems map[int]int
+		wantPairs map[[2]int]int
+	}{
+		{
+			name:      "cancel takes every count back",
+			old:       map[int]int{1: 2, 2: 1, 3: 1},
+			new:       map[int]int{},
+			wantItems: map[int]int{1: -2, 2: -1, 3: -1},
+			wantPairs: map[[2]int]int{{1, 2}: -1, {1, 3}: -1, {2, 3}: -1},
+		},
+		{
+			// Quantities move HAS_ORDERED, but a pair counts once per order however many of each
+			name:      "quantity change keeps pairs",
+			old:       map[int]int{1: 2, 2: 1},
+			new:       map[int]int{1: 5, 2: 1},
+			wantItems: map[int]int{1: 3},
+			wantPairs: map[[2]int]int{},
+		},
+		{
+			name:      "swap an item",
+			old:       map[int]int{1: 1, 2: 1},
+			new:       map[int]int{1: 1, 2: 0, 3: 2},
+			wantItems: map[int]int{2: -1, 3: 2},
+			wantPairs: map[[2]int]int{{1, 2}: -1, {1, 3}: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, pairs := derivedDeltas(tt.old, tt.new)
+			if !maps.Equal(items, tt.wantItems) {
+				t.Errorf("got item deltas %v, want %v", items, tt.wantItems)
+			}
+			if !maps.Equal(pairs, tt.wantPairs) {
+				t.Errorf("got pair deltas %v, want %v", pairs, tt.wantPairs)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// minPriceSpreadRatio keeps the price window from collapsing for users who always order the same thing
+const minPriceSpreadRatio = 0.25
+
+// PriceFilter restricts recommendations to a price range
+type PriceFilter struct {
+	MinPrice float64 `json:"min_price,omitempty"`
+	MaxPrice float64 `json:"max_price,omitempty"`
+	// Budget caps the cart total: the item price plus CartTotal must fit within it
+	Budget float64 `json:"budget,omitempty"`
+	// CartTotal is the price of what is already in the cart
+	CartTotal float64 `json:"-"`
+}
+
+// Enabled reports whether any price constraint was requested
+func (f PriceFilter) Enabled() bool {
+	return f.MinPrice > 0 || f.MaxPrice > 0 || f.Budget > 0
+}
+
+// Allows reports whether an item with the given price passes the filter
+func (f PriceFilter) Allows(price float64) bool {
+	if f.MinPrice > 0 && price < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && price > f.MaxPrice {
+		return false
+	}
+	if f.Budget > 0 && price+f.CartTotal > f.Budget {
+		return false
+	}
+	return true
+}
+
+// priceProfileRow holds the order aggregates a price profile is learned from
+type priceProfileRow struct {
+	OrderCount    int     `db:"order_count"`
+	AvgOrderTotal float64 `db:"avg_order_total"`
+	TotalSpent    float64 `db:"total_spent"`
+	TotalUnits    int64   `db:"total_units"`
+	PriceStdDev   float64 `db:"price_std_dev"`
+}
+
+// FilterRecommendationsByPrice drops recommendations whose item falls outside the price filter
+func (s *RecommendationService) FilterRecommendationsByPrice(recommendations []models.Recommendation, filter PriceFilter) []models.Recommendation {
+	if !filter.Enabled() {
+		return recommendations
+	}
+
+	filtered := make([]models.Recommendation, 0, len(recommendations))
+	for _, rec := range recommendations {
+		if filter.Allows(rec.Item.Price) {
+			filtered = append(filtered, rec)
+		}
+	}
+
+	return filtered
+}
+
+// GetUserPriceProfile learns a user's typical spend from their order totals
+func (s *RecommendationService) GetUserPriceProfile(ctx context.Context, userID int) (models.PriceProfile, error) {
+	query := `
+		MATCH (u:User {db_id: $userId})-[:HAS_MADE]->(o:Order)
+		WITH u, count(o) AS order_count, avg(o.total_amount) AS avg_order_total, sum(o.total_amount) AS total_spent
+		OPTIONAL MATCH (u)-[:HAS_MADE]->(:Order)-[hi:HAS_ITEM]->(i:Item)
+		RETURN order_count,
+			   avg_order_total,
+			   total_spent,
+			   sum(hi.quantity) AS total_units,
+			   stDev(i.price) AS price_std_dev
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return models.PriceProfile{}, fmt.Errorf("failed to get user price profile: %w", err)
+	}
+
+	profile := models.PriceProfile{UserID: userID}
+	if len(results) == 0 {
+		return profile, nil
+	}
+
+	row, err := database.Decode[priceProfileRow](results[0])
+	if err != nil {
+		return models.PriceProfile{}, fmt.Errorf("failed to decode user price profile: %w", err)
+	}
+	profile.OrderCount = row.OrderCount
+	profile.AvgOrderTotal = row.AvgOrderTotal
+	profile.PriceStdDev = row.PriceStdDev
+
+	// Typical price per unit is what they spend divided by how many units they buy
+	if row.TotalUnits > 0 {
+		profile.AvgItemPrice = row.TotalSpent / float64(row.TotalUnits)
+	}
+
+	return profile, nil
+}
+
+// GetPriceSensitiveItems answers: "Which items are priced the way this user usually spends?"
+func (s *RecommendationService) GetPriceSensitiveItems(ctx context.Context, userID int) ([]models.Recommendation, error) {
+	profile, err := s.GetUserPriceProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without history there is no usual range to favour
+	if profile.OrderCount == 0 || profile.AvgItemPrice <= 0 {
+		return nil, nil
+	}
+
+	items, err := s.GetAllItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spread := math.Max(profile.PriceStdDev, profile.AvgItemPrice*minPriceSpreadRatio)
+
+	var recommendations []models.Recommendation
+	for _, item := range items {
+		// Gaussian fit: 1 at the user's usual price, falling off with distance
+		distance := (item.Price - profile.AvgItemPrice) / spread
+		fit := math.Exp(-0.5 * distance * distance)
+
+		recommendations = append(recommendations, models.Recommendation{
+			Item:        item,
+			Score:       fit,
+			Explanation: fmt.Sprintf("Priced close to your usual $%.2f per item", profile.AvgItemPrice),
+			Strategy:    "PriceSensitivity",
+		})
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	return s.FilterSuppressed(ctx, userID, recommendations)
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// User segments that weight profiles can be assigned to
+const (
+	SegmentDefault         = "default"
+	SegmentNewUser         = "new_user"
+	SegmentExperiencedUser = "experienced_user"
+)
+
+var (
+	// ErrWeightProfileNotFound is returned when no profile has the requested name or version
+	ErrWeightProfileNotFound = newError(KindNotFound, "weight profile not found")
+	// ErrWeightProfileExists is returned when creating a profile whose name is taken
+	ErrWeightProfileExists = newError(KindConflict, "weight profile already exists")
+	// ErrWeightProfileChanged is returned when another request saved the same version first
+	ErrWeightProfileChanged = newError(KindConflict, "weight profile was changed by another request")
+	// ErrWeightProfileInUse is returned when deleting a profile still assigned to a segment
+	ErrWeightProfileInUse = newError(KindConflict, "weight profile is assigned to a segment")
+	// ErrUnknownSegment is returned for segment names other than the known ones
+	ErrUnknownSegment = newError(KindInvalidArgument, "unknown user segment")
+)
+
+// IsValidSegment reports whether a segment name is one profiles can be assigned to
+func IsValidSegment(segment string) bool {
+	switch segment {
+	case SegmentDefault, SegmentNewUser, SegmentExperiencedUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// weightProfileReturn is the RETURN clause shared by every profile query
+const weightProfileReturn = `
+		RETURN p.name AS name,
+			   p.version AS version,
+			   p.description AS description,
+			   p.user_frequency AS user_frequency,
+			   p.user_co_orders AS user_co_orders,
+			   p.global_co_orders AS global_co_orders,
+			   p.time_based_trend AS time_based_trend,
+			   p.price_sensitivity AS price_sensitivity,
+			   coalesce(p.ratings, 0.0) AS ratings,
+			   p.created_at AS created_at
+`
+
+// ListWeightProfiles returns the latest version of every weight profile
+func (s *RecommendationService) ListWeightProfiles(ctx context.Context) ([]models.WeightProfile, error) {
+	query := `
+		MATCH (p:WeightProfile)
+		WITH p.name AS profile_name, max(p.version) AS latest
+		MATCH (p:WeightProfile {name: profile_name, version: latest})
+	` + weightProfileReturn + `
+		ORDER BY name
+	`
+
+	results, err := s.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weight profiles: %w", err)
+	}
+
+	rows, err := database.DecodeAll[weightProfileRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode weight profiles: %w", err)
+	}
+
+	profiles := make([]models.WeightProfile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, row.profile())
+	}
+
+	return profiles, nil
+}
+
+// GetWeightProfile returns one version of a profile; version 0 means the latest
+func (s *RecommendationService) GetWeightProfile(ctx context.Context, name string, version int) (models.WeightProfile, error) {
+	query := `
+		MATCH (p:WeightProfile {name: $name})
+		WHERE $version = 0 OR p.version = $version
+	` + weightProfileReturn + `
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	params := map[string]interface{}{
+		"name":    name,
+		"version": version,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return models.WeightProfile{}, fmt.Errorf("failed to get weight profile: %w", err)
+	}
+
+	if len(results) == 0 {
+		return models.WeightProfile{}, ErrWeightProfileNotFound
+	}
+
+	row, err := database.Decode[weightProfileRow](results[0])
+	if err != nil {
+		return models.WeightProfile{}, fmt.Errorf("failed to decode weight profile: %w", err)
+	}
+	return row.profile(), nil
+}
+
+// GetWeightProfileVersions returns every version of a profile, newest first
+func (s *RecommendationService) GetWeightProfileVersions(ctx context.Context, name string) ([]models.WeightProfile, error) {
+	query := `
+		MATCH (p:WeightProfile {name: $name})
+	` + weightProfileReturn + `
+		ORDER BY version DESC
+	`
+
+	params := map[string]interface{}{
+		"name": name,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weight profile versions: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrWeightProfileNotFound
+	}
+
+	rows, err := database.DecodeAll[weightProfileRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode weight profiles: %w", err)
+	}
+
+	profiles := make([]models.WeightProfile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, row.profile())
+	}
+
+	return profiles, nil
+}
+
+// CreateWeightProfile stores version 1 of a new profile
+func (s *RecommendationService) CreateWeightProfile(ctx context.Context, name, description string, weights models.HybridWeights) (models.WeightProfile, error) {
+	if _, err := s.GetWeightProfile(ctx, name, 0); err == nil {
+		return models.WeightProfile{}, ErrWeightProfileExists
+	} else if !errors.Is(err, ErrWeightProfileNotFound) {
+		return models.WeightProfile{}, err
+	}
+
+	// A concurrent create of the same name also writes version 1 and loses to the constraint
+	return s.saveWeightProfileVersion(ctx, name, description, weights, ErrWeightProfileExists)
+}
+
+// UpdateWeightProfile stores a new version of an existing profile; older versions are kept
+func (s *RecommendationService) UpdateWeightProfile(ctx context.Context, name, description string, weights models.HybridWeights) (models.WeightProfile, error) {
+	if _, err := s.GetWeightProfile(ctx, name, 0); err != nil {
+		return models.WeightProfile{}, err
+	}
+
+	return s.saveWeightProfileVersion(ctx, name, description, weights, ErrWeightProfileChanged)
+}
+
+// saveWeightProfileVersion writes the next version of a profile. The (name, version)
+// uniqueness constraint rejects a version another request wrote in the meantime,
+// which is reported as clash.
+func (s *RecommendationService) saveWeightProfileVersion(ctx context.Context, name, description string, weights models.HybridWeights, clash error) (models.WeightProfile, error) {
+	if err := ValidateWeights(weights); err != nil {
+		return models.WeightProfile{}, err
+	}
+
+	query := `
+		OPTIONAL MATCH (existing:WeightProfile {name: $name})
+		WITH coalesce(max(existing.version), 0) + 1 AS next_version
+		CREATE (p:WeightProfile {
+			name: $name,
+			version: next_version,
+			description: $description,
+			user_frequency: $userFrequency,
+			user_co_orders: $userCoOrders,
+			global_co_orders: $globalCoOrders,
+			time_based_trend: $timeBasedTrend,
+			price_sensitivity: $priceSensitivity,
+			ratings: $ratings,
+			created_at: datetime()
+		})
+	` + weightProfileReturn
+
+	params := map[string]interface{}{
+		"name":             name,
+		"description":      description,
+		"userFrequency":    weights.UserFrequency,
+		"userCoOrders":     weights.UserCoOrders,
+		"globalCoOrders":   weights.GlobalCoOrders,
+		"timeBasedTrend":   weights.TimeBasedTrend,
+		"priceSensitivity": weights.PriceSensitivity,
+		"ratings":          weights.Ratings,
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if isConstraintViolation(err) {
+		return models.WeightProfile{}, clash
+	}
+	if err != nil {
+		return models.WeightProfile{}, fmt.Errorf("failed to save weight profile: %w", err)
+	}
+	if len(results) == 0 {
+		return models.WeightProfile{}, fmt.Errorf("failed to save weight profile: no result returned")
+	}
+
+	row, err := database.Decode[weightProfileRow](results[0])
+	if err != nil {
+		return models.WeightProfile{}, fmt.Errorf("failed to decode weight profile: %w", err)
+	}
+	return row.profile(), nil
+}
+
+// DeleteWeightProfile removes every version of a profile that no segment uses
+func (s *RecommendationService) DeleteWeightProfile(ctx context.Context, name string) error {
+	if _, err := s.GetWeightProfile(ctx, name, 0); err != nil {
+		return err
+	}
+
+	assignments, err := s.GetSegmentAssignments(ctx)
+	if err != nil {
+		return err
+	}
+	for _, profileName := range assignments {
+		if profileName == name {
+			return ErrWeightProfileInUse
+		}
+	}
+
+	query := `
+		MATCH (p:WeightProfile {name: $name})
+		DETACH DELETE p
+	`
+
+	params := map[string]interface{}{
+		"name": name,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to delete weight profile: %w", err)
+	}
+
+	return nil
+}
+
+// GetSegmentAssignments returns which profile each segment uses
+func (s *RecommendationService) GetSegmentAssignments(ctx context.Context) (map[string]string, error) {
+	query := `
+		MATCH (a:SegmentAssignment)
+		RETURN a.segment AS segment, a.profile AS profile
+	`
+
+	results, err := s.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segment assignments: %w", err)
+	}
+
+	rows, err := database.DecodeAll[segmentAssignmentRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode segment assignments: %w", err)
+	}
+
+	assignments := make(map[string]string, len(rows))
+	for _, row := range rows {
+		assignments[row.Segment] = row.Profile
+	}
+
+	return assignments, nil
+}
+
+// AssignWeightProfile makes a segment use the latest version of a profile
+func (s *RecommendationService) AssignWeightProfile(ctx context.Context, segment, name string) error {
+	if !IsValidSegment(segment) {
+		return ErrUnknownSegment
+	}
+	if _, err := s.GetWeightProfile(ctx, name, 0); err != nil {
+		return err
+	}
+
+	query := `
+		MERGE (a:SegmentAssignment {segment: $segment})
+		SET a.profile = $name, a.assigned_at = datetime()
+	`
+
+	params := map[string]interface{}{
+		"segment": segment,
+		"name":    name,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to assign weight profile: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignWeightProfile returns a segment to its built-in weights
+func (s *RecommendationService) UnassignWeightProfile(ctx context.Context, segment string) error {
+	if !IsValidSegment(segment) {
+		return ErrUnknownSegment
+	}
+
+	query := `
+		MATCH (a:SegmentAssignment {segment: $segment})
+		DELETE a
+	`
+
+	params := map[string]interface{}{
+		"segment": segment,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to unassign weight profile: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSegment places a user in the new or experienced segment, or default when unknown
+func (s *RecommendationService) GetUserSegment(ctx context.Context, userID int) string {
+	isNewUser, err := s.IsNewUser(ctx, userID)
+	if err != nil {
+		log.Printf("Error checking user status: %v", err)
+		return SegmentDefault
+	}
+	if isNewUser {
+		return SegmentNewUser
+	}
+	return SegmentExperiencedUser
+}
+
+// GetWeightsForSegment returns the weights of the profile assigned to a segment,
+// or the built-in weights (possibly loaded from the weights file) when none is assigned.
+// The profile is nil when built-in weights are used.
+func (s *RecommendationService) GetWeightsForSegment(ctx context.Context, segment string) (models.HybridWeights, *models.WeightProfile) {
+	assignments, err := s.GetSegmentAssignments(ctx)
+	if err != nil {
+		log.Printf("Error getting segment assignments: %v", err)
+	} else if name, ok := assignments[segment]; ok {
+		profile, err := s.GetWeightProfile(ctx, name, 0)
+		if err == nil {
+			return profile.Weights, &profile
+		}
+		log.Printf("Error loading weight profile %q for segment %s: %v", name, segment, err)
+	}
+
+	switch segment {
+	case SegmentNewUser:
+		return s.GetWeightsForNewUser(), nil
+	case SegmentExperiencedUser:
+		return s.GetWeightsForExperiencedUser(), nil
+	default:
+		return s.GetDefaultWeights(), nil
+	}
+}
+
+// weightProfileRow is a row produced with weightProfileReturn
+type weightProfileRow struct {
+	Name             string    `db:"name"`
+	Description      string    `db:"description,optional"`
+	Version          int       `db:"version"`
+	CreatedAt        time.Time `db:"created_at,optional"`
+	UserFrequency    float64   `db:"user_frequency"`
+	UserCoOrders     float64   `db:"user_co_orders"`
+	GlobalCoOrders   float64   `db:"global_co_orders"`
+	TimeBasedTrend   float64   `db:"time_based_trend"`
+	PriceSensitivity float64   `db:"price_sensitivity"`
+	Ratings          float64   `db:"ratings"`
+}
+
+// profile converts the row into a model
+func (r weightProfileRow) profile() models.WeightProfile {
+	return models.WeightProfile{
+		Name:        r.Name,
+		Description: r.Description,
+		Version:     r.Version,
+		CreatedAt:   r.CreatedAt,
+		Weights: models.HybridWeights{
+			UserFrequency:    r.UserFrequency,
+			UserCoOrders:     r.UserCoOrders,
+			GlobalCoOrders:   r.GlobalCoOrders,
+			TimeBasedTrend:   r.TimeBasedTrend,
+			PriceSensitivity: r.PriceSensitivity,
+			Ratings:          r.Ratings,
+		},
+	}
+}
+
+// segmentAssignmentRow is the profile a segment uses
+type segmentAssignmentRow struct {
+	Segment string `db:"segment"`
+	Profile string `db:"profile"`
+}
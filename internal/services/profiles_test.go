@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func TestIsConstraintViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"constraint", &neo4j.Neo4jError{Code: "Neo.ClientError.Schema.ConstraintValidationFailed"}, true},
+		{"wrapped constraint", fmt.Errorf("failed to execute query: %w", &neo4j.Neo4jError{Code: "Neo.ClientError.Schema.ConstraintValidationFailed"}), true},
+		{"syntax", &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}, false},
+		{"other", errors.New("constraint"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConstraintViolation(tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightProfileClashesAreConflicts(t *testing.T) {
+	for _, err := range []error{ErrWeightProfileExists, ErrWeightProfileChanged} {
+		if got := KindOf(err); got != KindConflict {
+			t.Errorf("KindOf(%v) = %s, want %s", err, got, KindConflict)
+		}
+	}
+}
+
+func TestWeightProfileRow(t *testing.T) {
+	row, err := database.Decode[weightProfileRow](map[string]interface{}{
+		"name":              "summer",
+		"version":           int64(3),
+		"description":       nil,
+		"user_frequency":    0.4,
+		"user_co_orders":    0.2,
+		"global_co_orders":  0.1,
+		"time_based_trend":  0.1,
+		"price_sensitivity": 0.1,
+		"ratings":           0.1,
+		"created_at":        nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := models.WeightProfile{
+		Name:    "summer",
+		Version: 3,
+		Weights: models.HybridWeights{
+			UserFrequency:    0.4,
+			UserCoOrders:     0.2,
+			GlobalCoOrders:   0.1,
+			TimeBasedTrend:   0.1,
+			PriceSensitivity: 0.1,
+			Ratings:          0.1,
+		},
+	}
+	if got := row.profile(); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestIsValidSegment(t *testing.T) {
+	tests := []struct {
+		segment string
+		want    bool
+	}{
+		{SegmentDefault, true},
+		{SegmentNewUser, true},
+		{SegmentExperiencedUser, true},
+		{"", false},
+		{"New_User", false},
+		{"vip", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidSegment(tt.segment); got != tt.want {
+			t.Errorf("IsValidSegment(%q) = %v, want %v", tt.segment, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// Star ratings range from MinStars to MaxStars; neutralStars is neither liked nor disliked
+const (
+	MinStars     = 1
+	MaxStars     = 5
+	neutralStars = 3.0
+)
+
+// ratingPriorWeight is how many ratings' worth of the menu-wide mean the Bayesian
+// average starts from, so items with one or two ratings are not ranked on luck
+const ratingPriorWeight = 5.0
+
+// ErrInvalidRating is returned for ratings outside the star range
+var ErrInvalidRating = newError(KindInvalidArgument, "invalid rating")
+
+// ratingRow is one user's rating of an item
+type ratingRow struct {
+	UserID  int       `db:"user_id"`
+	Stars   int       `db:"stars"`
+	Comment string    `db:"comment,optional"`
+	At      time.Time `db:"at,optional"`
+}
+
+// ratingTotalsRow is the count and star total of an item's ratings
+type ratingTotalsRow struct {
+	ItemID int   `db:"item_id"`
+	Count  int64 `db:"count"`
+	Total  int64 `db:"total"`
+}
+
+// ratedItemRow is an item with the stars the user gave it
+type ratedItemRow struct {
+	itemRow
+	Stars int `db:"stars"`
+}
+
+// peerRatedItemRow is an item with its overlap-weighted ratings by similar users
+type peerRatedItemRow struct {
+	itemRow
+	WeightedStars int64 `db:"weighted_stars"`
+	Weight        int64 `db:"weight"`
+	Raters        int   `db:"raters"`
+}
+
+// RateItem stores a user's rating of an item, replacing any earlier rating by the same user
+func (s *RecommendationService) RateItem(ctx context.Context, rating models.Rating) (models.Rating, error) {
+	if rating.Stars < MinStars || rating.Stars > MaxStars {
+		return models.Rating{}, fmt.Errorf("%w: stars must be between %d and %d", ErrInvalidRating, MinStars, MaxStars)
+	}
+	rating.At = s.now().UTC()
+
+	query := `
+		MATCH (u:User {db_id: $userId})
+		OPTIONAL MATCH (i:Item {db_id: $itemId})
+		FOREACH (_ IN CASE WHEN i IS NULL THEN [] ELSE [1] END |
+			MERGE (u)-[r:RATED]->(i)
+			SET r.stars = $stars, r.comment = $comment, r.at = $at
+		)
+		RETURN i IS NOT NULL AS found
+	`
+
+	params := map[string]interface{}{
+		"userId":  rating.UserID,
+		"itemId":  rating.ItemID,
+		"stars":   rating.Stars,
+		"comment": rating.Comment,
+		"at":      rating.At,
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return models.Rating{}, fmt.Errorf("failed to rate item: %w", err)
+	}
+	if len(results) == 0 {
+		return models.Rating{}, ErrUserNotFound
+	}
+	found, err := database.Value[bool](results[0], "found")
+	if err != nil {
+		return models.Rating{}, fmt.Errorf("failed to decode rating: %w", err)
+	}
+	if !found {
+		return models.Rating{}, ErrItemNotFound
+	}
+
+	return rating, nil
+}
+
+// GetItemRatings returns an item's ratings, newest first
+func (s *RecommendationService) GetItemRatings(ctx context.Context, itemID int) ([]models.Rating, error) {
+	query := `
+		MATCH (u:User)-[r:RATED]->(i:Item {db_id: $itemId})
+		RETURN u.db_id AS user_id,
+			   r.stars AS stars,
+			   r.comment AS comment,
+			   r.at AS at
+		ORDER BY at DESC
+	`
+
+	params := map[string]interface{}{
+		"itemId": itemID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item ratings: %w", err)
+	}
+
+	rows, err := database.DecodeAll[ratingRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode item ratings: %w", err)
+	}
+
+	ratings := make([]models.Rating, 0, len(rows))
+	for _, row := range rows {
+		ratings = append(ratings, models.Rating{
+			UserID:  row.UserID,
+			ItemID:  itemID,
+			Stars:   row.Stars,
+			Comment: row.Comment,
+			At:      row.At,
+		})
+	}
+
+	return ratings, nil
+}
+
+// GetRatingSummaries returns the rating aggregates of every rated item
+func (s *RecommendationService) GetRatingSummaries(ctx context.Context) (map[int]models.RatingSummary, error) {
+	query := `
+		MATCH (:User)-[r:RATED]->(i:Item)
+		RETURN i.db_id AS item_id,
+			   count(r) AS count,
+			   sum(r.stars) AS total
+	`
+
+	results, err := s.client.ExecuteRead(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating summaries: %w", err)
+	}
+
+	rows, err := database.DecodeAll[ratingTotalsRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode rating summaries: %w", err)
+	}
+
+	return summarizeRatings(rows), nil
+}
+
+// summarizeRatings turns per-item star totals into summaries. The menu-wide mean is the
+// prior every item's Bayesian average is pulled towards, ratingPriorWeight ratings strong.
+func summarizeRatings(rows []ratingTotalsRow) map[int]models.RatingSummary {
+	var allCount, allTotal int64
+	for _, row := range rows {
+		allCount += row.Count
+		allTotal += row.Total
+	}
+	prior := neutralStars
+	if allCount > 0 {
+		prior = float64(allTotal) / float64(allCount)
+	}
+
+	summaries := make(map[int]models.RatingSummary, len(rows))
+	for _, row := range rows {
+		count, total := row.Count, row.Total
+
+		summaries[row.ItemID] = models.RatingSummary{
+			Mean:            float64(total) / float64(count),
+			Count:           int(count),
+			BayesianAverage: (ratingPriorWeight*prior + float64(total)) / (ratingPriorWeight + float64(count)),
+		}
+	}
+
+	return summaries
+}
+
+// AttachRatingSummaries fills in the rating aggregates of rated items
+func (s *RecommendationService) AttachRatingSummaries(ctx context.Context, items []models.Item) error {
+	summaries, err := s.GetRatingSummaries(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		if summary, ok := summaries[items[i].DbID]; ok {
+			items[i].Ratings = &summary
+		}
+	}
+
+	return nil
+}
+
+// GetRatingBasedItems answers: "What do guests with similar taste rate highly, and what did this user dislike?"
+// Scores run from -1 to 1: the user's own rating wins, otherwise ratings by users who ordered
+// the same items count in proportion to that overlap. Negative scores demote items in the hybrid.
+func (s *RecommendationService) GetRatingBasedItems(ctx context.Context, userID int) ([]models.Recommendation, error) {
+	ownQuery := `
+		MATCH (u:User {db_id: $userId})-[r:RATED]->(i:Item)
+		WHERE coalesce(i.available, true)
+		RETURN i.db_id AS item_id,
+			   i.name AS name,
+			   i.price AS price,
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   r.stars AS stars
+	`
+
+	peerQuery := `
+		MATCH (u:User {db_id: $userId})-[:HAS_ORDERED]->(:Item)<-[:HAS_ORDERED]-(peer:User)
+		WHERE peer <> u
+		WITH peer, count(*) AS overlap
+		MATCH (peer)-[r:RATED]->(i:Item)
+		WHERE coalesce(i.available, true)
+		RETURN i.db_id AS item_id,
+			   i.name AS name,
+			   i.price AS price,
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   sum(overlap * r.stars) AS weighted_stars,
+			   sum(overlap) AS weight,
+			   count(peer) AS raters
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+	}
+
+	ownResults, err := s.client.ExecuteRead(ctx, ownQuery, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ratings: %w", err)
+	}
+
+	peerResults, err := s.client.ExecuteRead(ctx, peerQuery, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similar users' ratings: %w", err)
+	}
+
+	ownRows, err := database.DecodeAll[ratedItemRow](ownResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user ratings: %w", err)
+	}
+
+	peerRows, err := database.DecodeAll[peerRatedItemRow](peerResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode similar users' ratings: %w", err)
+	}
+
+	ratedByUser := make(map[int]bool, len(ownRows))
+	var recommendations []models.Recommendation
+	for _, row := range ownRows {
+		item := row.item()
+		stars := row.Stars
+		ratedByUser[item.DbID] = true
+
+		recommendations = append(recommendations, models.Recommendation{
+			Item:        item,
+			Score:       (float64(stars) - neutralStars) / (MaxStars - neutralStars),
+			Explanation: fmt.Sprintf("You rated this %d stars", stars),
+			Strategy:    "Ratings",
+		})
+	}
+
+	for _, row := range peerRows {
+		item := row.item()
+		if ratedByUser[item.DbID] {
+			continue
+		}
+
+		avgStars := float64(row.WeightedStars) / float64(row.Weight)
+		raters := row.Raters
+
+		recommendations = append(recommendations, models.Recommendation{
+			Item:        item,
+			Score:       (avgStars - neutralStars) / (MaxStars - neutralStars),
+			Explanation: fmt.Sprintf("Rated %.1f stars by %d guests with similar taste", avgStars, raters),
+			Strategy:    "Ratings",
+		})
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	return s.FilterSuppressed(ctx, userID, recommendations)
+}
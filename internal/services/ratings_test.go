@@ -0,0 +1,65 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarizeRatings(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []ratingTotalsRow
+		want map[int]float64 // Bayesian average by item
+	}{
+		{
+			name: "none",
+			want: map[int]float64{},
+		},
+		{
+			// The prior is the menu-wide mean 3.2: one 5-star rating is pulled most of
+			// the way towards it, nine ratings averaging 3 barely move
+			name: "pulled towards the menu mean",
+			rows: []ratingTotalsRow{
+				{ItemID: 1, Count: 1, Total: 5},
+				{ItemID: 2, Count: 9, Total: 27},
+			},
+			want: map[int]float64{1: 21.0 / 6, 2: 43.0 / 14},
+		},
+		{
+			name: "a single item is its own prior",
+			rows: []ratingTotalsRow{{ItemID: 7, Count: 2, Total: 9}},
+			want: map[int]float64{7: 4.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeRatings(tt.rows)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %d summaries", got, len(tt.want))
+			}
+			for _, row := range tt.rows {
+				summary := got[row.ItemID]
+				if summary.Count != int(row.Count) || summary.Mean != float64(row.Total)/float64(row.Count) {
+					t.Errorf("item %d: got %+v", row.ItemID, summary)
+				}
+				if math.Abs(summary.BayesianAverage-tt.want[row.ItemID]) > 1e-9 {
+					t.Errorf("item %d: got Bayesian average %v, want %v", row.ItemID, summary.BayesianAverage, tt.want[row.ItemID])
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeRatingsRanksConfidentItemsHigher(t *testing.T) {
+	summaries := summarizeRatings([]ratingTotalsRow{
+		{ItemID: 1, Count: 1, Total: 5},
+		{ItemID: 2, Count: 40, Total: 180},
+		{ItemID: 3, Count: 20, Total: 40},
+	})
+
+	// A lone 5-star rating should not outrank forty ratings averaging 4.5
+	if summaries[1].BayesianAverage >= summaries[2].BayesianAverage {
+		t.Errorf("got %v for one 5-star rating, %v for forty averaging 4.5", summaries[1].BayesianAverage, summaries[2].BayesianAverage)
+	}
+}
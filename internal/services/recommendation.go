@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/yishak-cs/Neo4j_DB/internal/database"
 	"github.com/yishak-cs/Neo4j_DB/internal/models"
@@ -12,24 +14,43 @@ import (
 
 // RecommendationService handles all recommendation logic
 type RecommendationService struct {
-	client *database.Neo4jClient
+	client            *database.Neo4jClient
+	now               func() time.Time
+	suppressionPeriod time.Duration
+	erasurePolicy     string
+	orderUpdater      OrderUpdater
+	exposureRecorder  ExposureRecorder
+
+	weightsMu      sync.RWMutex
+	segmentWeights models.SegmentWeights
 }
 
 // NewRecommendationService creates a new recommendation service
 func NewRecommendationService(client *database.Neo4jClient) *RecommendationService {
 	return &RecommendationService{
-		client: client,
+		client:            client,
+		now:               time.Now,
+		suppressionPeriod: DefaultSuppressionPeriod,
+		erasurePolicy:     ErasureAnonymise,
+		segmentWeights:    DefaultSegmentWeights(),
 	}
 }
 
+// SetClock overrides the notion of "now" used by time-based strategies,
+// so offline evaluation can replay history as of a past date
+func (s *RecommendationService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
 // GetUserFrequentItems answers: "What does a user generally order most frequently?"
 func (s *RecommendationService) GetUserFrequentItems(ctx context.Context, userID int) ([]models.Recommendation, error) {
 	query := `
 		MATCH (u:User {db_id: $userId})-[ho:HAS_ORDERED]->(i:Item)
+		WHERE coalesce(i.available, true)
 		RETURN i.db_id AS item_id, 
 			   i.name AS name, 
 			   i.price AS price, 
-			   i.category AS category, 
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category, 
 			   ho.times AS times
 		ORDER BY ho.times DESC
 	`
@@ -43,26 +64,24 @@ func (s *RecommendationService) GetUserFrequentItems(ctx context.Context, userID
 		return nil, fmt.Errorf("failed to get user frequent items: %w", err)
 	}
 
-	var recommendations []models.Recommendation
-	for _, result := range results {
-		item := models.Item{
-			DbID:     int(result["item_id"].(int64)),
-			Name:     result["name"].(string),
-			Price:    result["price"].(float64),
-			Category: result["category"].(string),
-		}
+	rows, err := database.DecodeAll[countedItemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user frequent items: %w", err)
+	}
 
-		times := int(result["times"].(int64))
+	var recommendations []models.Recommendation
+	for _, row := range rows {
+		times := row.Times
 
 		recommendations = append(recommendations, models.Recommendation{
-			Item:        item,
+			Item:        row.item(),
 			Score:       float64(times),
 			Explanation: fmt.Sprintf("You've ordered this %d times", times),
 			Strategy:    "UserFrequency",
 		})
 	}
 
-	return recommendations, nil
+	return s.FilterSuppressed(ctx, userID, recommendations)
 }
 
 // GetUserCoOrderedItems answers: "Once item X is in cart, what did THIS user previously order with X?"
@@ -70,14 +89,14 @@ func (s *RecommendationService) GetUserCoOrderedItems(ctx context.Context, userI
 	query := `
 		MATCH (u:User {db_id: $userId})-[:HAS_MADE]->(o:Order)-[:HAS_ITEM]->(target:Item {db_id: $itemInCart})
 		MATCH (o)-[:HAS_ITEM]->(coItem:Item)
-		WHERE coItem.db_id <> $itemInCart
+		WHERE coItem.db_id <> $itemInCart AND coalesce(coItem.available, true)
 		WITH coItem, count(o) as coOccurrences
 		RETURN coItem.db_id AS item_id, 
 			   coItem.name AS name, 
 			   coItem.price AS price, 
-			   coItem.category AS category, 
-			   coOccurrences
-		ORDER BY coOccurrences DESC
+			   head([(coItem)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category, 
+			   coOccurrences AS times
+		ORDER BY times DESC
 	`
 
 	params := map[string]interface{}{
@@ -90,42 +109,53 @@ func (s *RecommendationService) GetUserCoOrderedItems(ctx context.Context, userI
 		return nil, fmt.Errorf("failed to get user co-ordered items: %w", err)
 	}
 
-	var recommendations []models.Recommendation
-	for _, result := range results {
-		item := models.Item{
-			DbID:     int(result["item_id"].(int64)),
-			Name:     result["name"].(string),
-			Price:    result["price"].(float64),
-			Category: result["category"].(string),
-		}
+	rows, err := database.DecodeAll[countedItemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user co-ordered items: %w", err)
+	}
 
-		coOccurrences := int(result["coOccurrences"].(int64))
+	var recommendations []models.Recommendation
+	for _, row := range rows {
+		coOccurrences := row.Times
 
 		recommendations = append(recommendations, models.Recommendation{
-			Item:        item,
+			Item:        row.item(),
 			Score:       float64(coOccurrences),
 			Explanation: fmt.Sprintf("You've ordered this %d times with %d", coOccurrences, itemInCartID),
 			Strategy:    "UserCoOrders",
 		})
 	}
 
-	return recommendations, nil
+	return s.FilterSuppressed(ctx, userID, recommendations)
 }
 
 // GetGlobalCoOrderedItems answers: "Once item X is in cart, what items are frequently ordered with X across ALL users?"
 func (s *RecommendationService) GetGlobalCoOrderedItems(ctx context.Context, itemInCartID int) ([]models.Recommendation, error) {
+	recommendations, err := s.GetGlobalCoOrderedItemsFor(ctx, []int{itemInCartID})
+	if err != nil {
+		return nil, err
+	}
+	return recommendations[itemInCartID], nil
+}
+
+// GetGlobalCoOrderedItemsFor answers the global co-order question for several items in
+// one query, keyed by item; items nothing is ordered with are left out
+func (s *RecommendationService) GetGlobalCoOrderedItemsFor(ctx context.Context, itemIDs []int) (map[int][]models.Recommendation, error) {
 	query := `
-		MATCH (target:Item {db_id: $itemInCart})-[oaw:ORDERED_ALONG_WITH]->(coItem:Item)
-		RETURN coItem.db_id AS item_id, 
+		UNWIND $itemIds AS itemId
+		MATCH (target:Item {db_id: itemId})-[oaw:ORDERED_ALONG_WITH]->(coItem:Item)
+		WHERE coalesce(coItem.available, true)
+		RETURN itemId AS target_id,
+			   coItem.db_id AS item_id, 
 			   coItem.name AS name, 
 			   coItem.price AS price, 
-			   coItem.category AS category, 
+			   head([(coItem)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category, 
 			   oaw.times AS times
-		ORDER BY oaw.times DESC
+		ORDER BY target_id, oaw.times DESC
 	`
 
 	params := map[string]interface{}{
-		"itemInCart": itemInCartID,
+		"itemIds": itemIDs,
 	}
 
 	results, err := s.client.ExecuteRead(ctx, query, params)
@@ -133,21 +163,19 @@ func (s *RecommendationService) GetGlobalCoOrderedItems(ctx context.Context, ite
 		return nil, fmt.Errorf("failed to get global co-ordered items: %w", err)
 	}
 
-	var recommendations []models.Recommendation
-	for _, result := range results {
-		item := models.Item{
-			DbID:     int(result["item_id"].(int64)),
-			Name:     result["name"].(string),
-			Price:    result["price"].(float64),
-			Category: result["category"].(string),
-		}
+	rows, err := database.DecodeAll[coOrderedItemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode global co-ordered items: %w", err)
+	}
 
-		times := int(result["times"].(int64))
+	recommendations := make(map[int][]models.Recommendation, len(itemIDs))
+	for _, row := range rows {
+		times := row.Times
 
-		recommendations = append(recommendations, models.Recommendation{
-			Item:        item,
+		recommendations[row.TargetID] = append(recommendations[row.TargetID], models.Recommendation{
+			Item:        row.item(),
 			Score:       float64(times),
-			Explanation: fmt.Sprintf("Customers who ordered item %d also ordered this %d times", itemInCartID, times),
+			Explanation: fmt.Sprintf("Customers who ordered item %d also ordered this %d times", row.TargetID, times),
 			Strategy:    "GlobalCoOrders",
 		})
 	}
@@ -159,18 +187,19 @@ func (s *RecommendationService) GetGlobalCoOrderedItems(ctx context.Context, ite
 func (s *RecommendationService) GetTimeBasedTrendingItems(ctx context.Context, days int) ([]models.Recommendation, error) {
 	query := `
 		MATCH (o:Order)-[:HAS_ITEM]->(i:Item)
-		WHERE date(o.created_at) > date() - duration({days: $days})
+		WHERE date(o.created_at) > date($asOf) - duration({days: $days}) AND coalesce(i.available, true)
 		WITH i, count(o) as recent_orders
 		RETURN i.db_id AS item_id, 
         i.name AS name, 
         i.price AS price, 
-        i.category AS category, 
-        recent_orders
-		ORDER BY recent_orders DESC
+        head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category, 
+        recent_orders AS times
+		ORDER BY times DESC
 	`
 
 	params := map[string]interface{}{
 		"days": days,
+		"asOf": s.now(),
 	}
 
 	results, err := s.client.ExecuteRead(ctx, query, params)
@@ -178,19 +207,17 @@ func (s *RecommendationService) GetTimeBasedTrendingItems(ctx context.Context, d
 		return nil, fmt.Errorf("failed to get trending items: %w", err)
 	}
 
-	var recommendations []models.Recommendation
-	for _, result := range results {
-		item := models.Item{
-			DbID:     int(result["item_id"].(int64)),
-			Name:     result["name"].(string),
-			Price:    result["price"].(float64),
-			Category: result["category"].(string),
-		}
+	rows, err := database.DecodeAll[countedItemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode trending items: %w", err)
+	}
 
-		recentOrders := int(result["recent_orders"].(int64))
+	var recommendations []models.Recommendation
+	for _, row := range rows {
+		recentOrders := row.Times
 
 		recommendations = append(recommendations, models.Recommendation{
-			Item:        item,
+			Item:        row.item(),
 			Score:       float64(recentOrders),
 			Explanation: fmt.Sprintf("Ordered %d times in the last %d days", recentOrders, days),
 			Strategy:    "TimeBasedTrend",
@@ -200,7 +227,9 @@ func (s *RecommendationService) GetTimeBasedTrendingItems(ctx context.Context, d
 	return recommendations, nil
 }
 
-// HybridRecommendation combines all recommendation strategies with weights
+// HybridRecommendation combines all recommendation strategies with weights. Count-based
+// strategies are divided by their largest count first, so every strategy scores on the
+// 0..1 scale of price fit (ratings run from -1 to 1) and the weights alone set their influence.
 func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID int, itemInCartID *int, weights models.HybridWeights) ([]models.Recommendation, error) {
 	log.Printf("Generating hybrid recommendations for user %d with item in cart %v", userID, itemInCartID)
 
@@ -214,9 +243,10 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 	if err != nil {
 		log.Printf("Warning: Failed to get user frequency recommendations: %v", err)
 	} else {
+		scale := countScale(userFreqRecs)
 		for _, rec := range userFreqRecs {
 			itemID := rec.Item.DbID
-			score := rec.Score * weights.UserFrequency
+			score := rec.Score / scale * weights.UserFrequency
 
 			itemScores[itemID] = itemScores[itemID] + score
 			itemDetails[itemID] = rec.Item
@@ -235,9 +265,10 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 		if err != nil {
 			log.Printf("Warning: Failed to get user co-ordered recommendations: %v", err)
 		} else {
+			scale := countScale(userCoRecs)
 			for _, rec := range userCoRecs {
 				itemID := rec.Item.DbID
-				score := rec.Score * weights.UserCoOrders
+				score := rec.Score / scale * weights.UserCoOrders
 
 				itemScores[itemID] = itemScores[itemID] + score
 				itemDetails[itemID] = rec.Item
@@ -254,9 +285,10 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 		if err != nil {
 			log.Printf("Warning: Failed to get global co-ordered recommendations: %v", err)
 		} else {
+			scale := countScale(globalCoRecs)
 			for _, rec := range globalCoRecs {
 				itemID := rec.Item.DbID
-				score := rec.Score * weights.GlobalCoOrders
+				score := rec.Score / scale * weights.GlobalCoOrders
 
 				itemScores[itemID] = itemScores[itemID] + score
 				itemDetails[itemID] = rec.Item
@@ -274,9 +306,10 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 	if err != nil {
 		log.Printf("Warning: Failed to get trending recommendations: %v", err)
 	} else {
+		scale := countScale(trendRecs)
 		for _, rec := range trendRecs {
 			itemID := rec.Item.DbID
-			score := rec.Score * weights.TimeBasedTrend
+			score := rec.Score / scale * weights.TimeBasedTrend
 
 			itemScores[itemID] = itemScores[itemID] + score
 			itemDetails[itemID] = rec.Item
@@ -288,6 +321,53 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 		}
 	}
 
+	// 4. Get items priced in the user's usual range
+	if weights.PriceSensitivity > 0 {
+		priceRecs, err := s.GetPriceSensitiveItems(ctx, userID)
+		if err != nil {
+			log.Printf("Warning: Failed to get price sensitivity recommendations: %v", err)
+		} else {
+			for _, rec := range priceRecs {
+				itemID := rec.Item.DbID
+				score := rec.Score * weights.PriceSensitivity
+
+				itemScores[itemID] = itemScores[itemID] + score
+				itemDetails[itemID] = rec.Item
+
+				if strategyContributions[itemID] == nil {
+					strategyContributions[itemID] = make(map[string]float64)
+				}
+				strategyContributions[itemID]["PriceSensitivity"] = score
+			}
+		}
+	}
+
+	// 5. Promote items similar users rated highly and demote items the user rated poorly.
+	// Negative scores only adjust items another strategy already found, so dislikes never get recommended.
+	if weights.Ratings > 0 {
+		ratingRecs, err := s.GetRatingBasedItems(ctx, userID)
+		if err != nil {
+			log.Printf("Warning: Failed to get rating recommendations: %v", err)
+		} else {
+			for _, rec := range ratingRecs {
+				itemID := rec.Item.DbID
+				score := rec.Score * weights.Ratings
+
+				if _, found := itemScores[itemID]; !found && score <= 0 {
+					continue
+				}
+
+				itemScores[itemID] = itemScores[itemID] + score
+				itemDetails[itemID] = rec.Item
+
+				if strategyContributions[itemID] == nil {
+					strategyContributions[itemID] = make(map[string]float64)
+				}
+				strategyContributions[itemID]["Ratings"] = score
+			}
+		}
+	}
+
 	// Filter out the item in cart if it exists
 	if itemInCartID != nil {
 		delete(itemScores, *itemInCartID)
@@ -295,6 +375,31 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 		delete(strategyContributions, *itemInCartID)
 	}
 
+	// Apply the user's "not interested" marks; user-specific strategies already did, but
+	// global co-orders and trends do not know the user, so their contributions are scaled here
+	suppressed, err := s.getSuppressionSet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for itemID, item := range itemDetails {
+		penalty := suppressed.penalty(item)
+		if penalty >= suppressionHidePenalty {
+			delete(itemScores, itemID)
+			delete(itemDetails, itemID)
+			delete(strategyContributions, itemID)
+			continue
+		}
+		if penalty == 0 {
+			continue
+		}
+		for _, strategy := range []string{"GlobalCoOrders", "TimeBasedTrend"} {
+			if contribution, ok := strategyContributions[itemID][strategy]; ok {
+				itemScores[itemID] -= contribution * penalty
+				strategyContributions[itemID][strategy] = contribution * (1 - penalty)
+			}
+		}
+	}
+
 	// Convert to slice for sorting
 	var recommendations []models.Recommendation
 	for itemID, totalScore := range itemScores {
@@ -320,6 +425,10 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 			explanation = fmt.Sprintf("Customers who order item %d also order this", *itemInCartID)
 		case "TimeBasedTrend":
 			explanation = "This item is trending right now"
+		case "PriceSensitivity":
+			explanation = "Priced in the range you usually spend"
+		case "Ratings":
+			explanation = "Highly rated by guests with similar taste"
 		default:
 			explanation = "Recommended based on your preferences"
 		}
@@ -340,34 +449,46 @@ func (s *RecommendationService) HybridRecommendation(ctx context.Context, userID
 	return recommendations, nil
 }
 
+// countScale returns the largest score of a count-based strategy, or 1 when it has none
+func countScale(recommendations []models.Recommendation) float64 {
+	scale := 0.0
+	for _, rec := range recommendations {
+		if rec.Score > scale {
+			scale = rec.Score
+		}
+	}
+	if scale == 0 {
+		return 1
+	}
+	return scale
+}
+
 // GetDefaultWeights returns the default weights for hybrid recommendations
 func (s *RecommendationService) GetDefaultWeights() models.HybridWeights {
-	return models.HybridWeights{
-		UserFrequency:  0.4,
-		UserCoOrders:   0.3,
-		GlobalCoOrders: 0.2,
-		TimeBasedTrend: 0.1,
-	}
+	s.weightsMu.RLock()
+	defer s.weightsMu.RUnlock()
+	return s.segmentWeights.Default
 }
 
 // GetWeightsForNewUser returns weights optimized for new users
 func (s *RecommendationService) GetWeightsForNewUser() models.HybridWeights {
-	return models.HybridWeights{
-		UserFrequency:  0.1,
-		UserCoOrders:   0.1,
-		GlobalCoOrders: 0.5,
-		TimeBasedTrend: 0.3,
-	}
+	s.weightsMu.RLock()
+	defer s.weightsMu.RUnlock()
+	return s.segmentWeights.NewUser
 }
 
 // GetWeightsForExperiencedUser returns weights optimized for experienced users
 func (s *RecommendationService) GetWeightsForExperiencedUser() models.HybridWeights {
-	return models.HybridWeights{
-		UserFrequency:  0.5,
-		UserCoOrders:   0.3,
-		GlobalCoOrders: 0.1,
-		TimeBasedTrend: 0.1,
-	}
+	s.weightsMu.RLock()
+	defer s.weightsMu.RUnlock()
+	return s.segmentWeights.ExperiencedUser
+}
+
+// GetWeightsForUser picks hybrid weights for the user's segment, using the profile
+// assigned to that segment when there is one
+func (s *RecommendationService) GetWeightsForUser(ctx context.Context, userID int) models.HybridWeights {
+	weights, _ := s.GetWeightsForSegment(ctx, s.GetUserSegment(ctx, userID))
+	return weights
 }
 
 // IsNewUser determines if a user is new based on order history
@@ -390,20 +511,27 @@ func (s *RecommendationService) IsNewUser(ctx context.Context, userID int) (bool
 		return true, nil
 	}
 
-	orderCount := int(results[0]["order_count"].(int64))
-	return orderCount < 3, nil // Consider users with less than 3 orders as new
+	row, err := database.Decode[struct {
+		OrderCount int `db:"order_count"`
+	}](results[0])
+	if err != nil {
+		return true, fmt.Errorf("failed to decode user status: %w", err)
+	}
+
+	return row.OrderCount < 3, nil // Consider users with less than 3 orders as new
 }
 
-// GetAllItems retrieves all menu items from the database
+// GetAllItems retrieves all menu items that are still available
 func (s *RecommendationService) GetAllItems(ctx context.Context) ([]models.Item, error) {
 	query := `
 		MATCH (i:Item)
-		RETURN i.db_id AS db_id, 
+		WHERE coalesce(i.available, true)
+		RETURN i.db_id AS item_id, 
 			   i.name AS name, 
 			   i.price AS price, 
-			   i.category AS category,
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
 			   i.description AS description
-		ORDER BY i.category, i.name
+		ORDER BY category, name
 	`
 
 	results, err := s.client.ExecuteRead(ctx, query, nil)
@@ -411,36 +539,91 @@ func (s *RecommendationService) GetAllItems(ctx context.Context) ([]models.Item,
 		return nil, fmt.Errorf("failed to get all items: %w", err)
 	}
 
-	var items []models.Item
-	for _, result := range results {
-		item := models.Item{
-			DbID:     int(result["db_id"].(int64)),
-			Name:     result["name"].(string),
-			Price:    result["price"].(float64),
-			Category: result["category"].(string),
-		}
+	rows, err := database.DecodeAll[itemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
 
-		// Handle optional description field
-		if desc, ok := result["description"]; ok && desc != nil {
-			if descStr, ok := desc.(string); ok {
-				item.Description = descStr
-			}
-		}
+	return itemsFromRows(rows), nil
+}
+
+// GetItemByID retrieves a single menu item, returning nil when it does not exist
+func (s *RecommendationService) GetItemByID(ctx context.Context, itemID int) (*models.Item, error) {
+	query := `
+		MATCH (i:Item {db_id: $itemId})
+		RETURN i.db_id AS item_id, 
+			   i.name AS name, 
+			   i.price AS price, 
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   i.description AS description
+	`
+
+	params := map[string]interface{}{
+		"itemId": itemID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	row, err := database.Decode[itemRow](results[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode item: %w", err)
+	}
+
+	item := row.item()
+	return &item, nil
+}
+
+// GetItemsByIDs retrieves several menu items in one query, available or not, keyed by
+// ID; IDs that do not exist are left out
+func (s *RecommendationService) GetItemsByIDs(ctx context.Context, itemIDs []int) (map[int]models.Item, error) {
+	query := `
+		MATCH (i:Item)
+		WHERE i.db_id IN $itemIds
+		RETURN i.db_id AS item_id, 
+			   i.name AS name, 
+			   i.price AS price, 
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   i.description AS description
+	`
 
-		items = append(items, item)
+	params := map[string]interface{}{
+		"itemIds": itemIDs,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items: %w", err)
+	}
+
+	rows, err := database.DecodeAll[itemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
+
+	items := make(map[int]models.Item, len(rows))
+	for _, row := range rows {
+		items[row.ItemID] = row.item()
 	}
 
 	return items, nil
 }
 
-// GetItemsByCategory retrieves menu items by category
+// GetItemsByCategory retrieves the available menu items of a category
 func (s *RecommendationService) GetItemsByCategory(ctx context.Context, category string) ([]models.Item, error) {
 	query := `
-		MATCH (i:Item {category: $category})
-		RETURN i.db_id AS db_id, 
+		MATCH (i:Item)-[:IN_CATEGORY]->(c:Category {name: $category})
+		WHERE coalesce(i.available, true)
+		RETURN i.db_id AS item_id, 
 			   i.name AS name, 
 			   i.price AS price, 
-			   i.category AS category,
+			   c.name AS category,
 			   i.description AS description
 		ORDER BY i.name
 	`
@@ -454,33 +637,56 @@ func (s *RecommendationService) GetItemsByCategory(ctx context.Context, category
 		return nil, fmt.Errorf("failed to get items by category: %w", err)
 	}
 
-	var items []models.Item
-	for _, result := range results {
-		item := models.Item{
-			DbID:     int(result["db_id"].(int64)),
-			Name:     result["name"].(string),
-			Price:    result["price"].(float64),
-			Category: result["category"].(string),
-		}
+	rows, err := database.DecodeAll[itemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
 
-		// Handle optional description field
-		if desc, ok := result["description"]; ok && desc != nil {
-			if descStr, ok := desc.(string); ok {
-				item.Description = descStr
-			}
-		}
+	return itemsFromRows(rows), nil
+}
 
-		items = append(items, item)
+// GetItemsByCategories retrieves the available menu items of several categories in one
+// query, keyed by category
+func (s *RecommendationService) GetItemsByCategories(ctx context.Context, categories []string) (map[string][]models.Item, error) {
+	query := `
+		MATCH (i:Item)-[:IN_CATEGORY]->(c:Category)
+		WHERE c.name IN $categories AND coalesce(i.available, true)
+		RETURN i.db_id AS item_id, 
+			   i.name AS name, 
+			   i.price AS price, 
+			   c.name AS category,
+			   i.description AS description
+		ORDER BY category, i.name
+	`
+
+	params := map[string]interface{}{
+		"categories": categories,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items by category: %w", err)
+	}
+
+	rows, err := database.DecodeAll[itemRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
+
+	items := make(map[string][]models.Item, len(categories))
+	for _, row := range rows {
+		items[row.Category] = append(items[row.Category], row.item())
 	}
 
 	return items, nil
 }
 
-// GetAllUsers retrieves all users from the database
+// GetAllUsers retrieves all users from the database, leaving out erased ones
 func (s *RecommendationService) GetAllUsers(ctx context.Context) ([]models.User, error) {
 	query := `
 		MATCH (u:User)
-		RETURN u.db_id AS db_id, 
+		WHERE u.erased_at IS NULL
+		RETURN u.db_id AS user_id, 
 			   u.name AS name, 
 			   u.email AS email,
 			   u.created_at AS created_at
@@ -492,21 +698,14 @@ func (s *RecommendationService) GetAllUsers(ctx context.Context) ([]models.User,
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
 
-	var users []models.User
-	for _, result := range results {
-		user := models.User{
-			DbID:  int(result["db_id"].(int64)),
-			Name:  result["name"].(string),
-			Email: result["email"].(string),
-		}
-
-		// Handle created_at if present
-		if createdAt, ok := result["created_at"]; ok && createdAt != nil {
-			// Neo4j datetime handling would go here if needed
-			// For now, we'll skip the datetime conversion
-		}
+	rows, err := database.DecodeAll[userRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
 
-		users = append(users, user)
+	users := make([]models.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.user())
 	}
 
 	return users, nil
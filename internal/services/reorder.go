@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// minRecurringOrders is how many identical orders make a basket recurring
+const minRecurringOrders = 2
+
+// pastOrder is an order with its lines as loaded from the graph
+type pastOrder struct {
+	id          int
+	createdAt   time.Time
+	totalAmount float64
+	lines       []models.BasketLine
+}
+
+// pastOrderLineRow is one item line of a past order, with the order's columns repeated
+type pastOrderLineRow struct {
+	itemRow
+	OrderID     int       `db:"order_id"`
+	CreatedAt   time.Time `db:"created_at,optional"`
+	TotalAmount float64   `db:"total_amount"`
+	Available   bool      `db:"available"`
+	Quantity    int       `db:"quantity"`
+}
+
+// signature identifies the contents of an order regardless of line order
+func (o pastOrder) signature() string {
+	parts := make([]string, len(o.lines))
+	for i, line := range o.lines {
+		parts[i] = fmt.Sprintf("%dx%d", line.Item.DbID, line.Quantity)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// GetReorderSuggestions answers: "Which of my past orders can I simply place again?"
+// Recent baskets are the user's latest distinct orders; recurring baskets are
+// orders with identical contents that were placed more than once. As with bundles, the
+// budget caps a basket's current total and min/max price apply to each available item.
+func (s *RecommendationService) GetReorderSuggestions(ctx context.Context, userID int, limit int, price PriceFilter) (models.ReorderSuggestions, error) {
+	suggestions := models.ReorderSuggestions{
+		UserID:           userID,
+		RecentBaskets:    []models.Basket{},
+		RecurringBaskets: []models.Basket{},
+	}
+
+	if limit <= 0 {
+		limit = 5
+	}
+
+	orders, err := s.getUserOrders(ctx, userID)
+	if err != nil {
+		return suggestions, err
+	}
+
+	// Group identical orders, most recent first
+	var signatures []string
+	groups := make(map[string][]pastOrder)
+	for _, order := range orders {
+		sig := order.signature()
+		if _, seen := groups[sig]; !seen {
+			signatures = append(signatures, sig)
+		}
+		groups[sig] = append(groups[sig], order)
+	}
+
+	for _, sig := range signatures {
+		basket := buildBasket(groups[sig])
+		if !basketWithinPrice(basket, price) {
+			continue
+		}
+
+		if len(suggestions.RecentBaskets) < limit {
+			suggestions.RecentBaskets = append(suggestions.RecentBaskets, basket)
+		}
+		if basket.TimesOrdered >= minRecurringOrders {
+			suggestions.RecurringBaskets = append(suggestions.RecurringBaskets, basket)
+		}
+	}
+
+	sort.SliceStable(suggestions.RecurringBaskets, func(i, j int) bool {
+		return suggestions.RecurringBaskets[i].TimesOrdered > suggestions.RecurringBaskets[j].TimesOrdered
+	})
+	if len(suggestions.RecurringBaskets) > limit {
+		suggestions.RecurringBaskets = suggestions.RecurringBaskets[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// getUserOrders loads a user's orders with their lines, newest first
+func (s *RecommendationService) getUserOrders(ctx context.Context, userID int) ([]pastOrder, error) {
+	query := `
+		MATCH (u:User {db_id: $userId})-[:HAS_MADE]->(o:Order)-[hi:HAS_ITEM]->(i:Item)
+		RETURN o.db_id AS order_id,
+			   o.created_at AS created_at,
+			   o.total_amount AS total_amount,
+			   i.db_id AS item_id,
+			   i.name AS name,
+			   i.price AS price,
+			   head([(i)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   coalesce(i.available, true) AS available,
+			   hi.quantity AS quantity
+		ORDER BY o.created_at DESC, o.db_id DESC, i.db_id
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user orders: %w", err)
+	}
+
+	rows, err := database.DecodeAll[pastOrderLineRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user orders: %w", err)
+	}
+
+	var orders []pastOrder
+	for _, row := range rows {
+		if len(orders) == 0 || orders[len(orders)-1].id != row.OrderID {
+			orders = append(orders, pastOrder{
+				id:          row.OrderID,
+				createdAt:   row.CreatedAt,
+				totalAmount: row.TotalAmount,
+			})
+		}
+
+		item := row.item()
+		current := &orders[len(orders)-1]
+		current.lines = append(current.lines, models.BasketLine{
+			Item:      item,
+			Quantity:  row.Quantity,
+			Available: row.Available,
+			LineTotal: item.Price * float64(row.Quantity),
+		})
+	}
+
+	return orders, nil
+}
+
+// buildBasket turns a group of identical orders (newest first) into a reorderable basket
+func buildBasket(orders []pastOrder) models.Basket {
+	latest := orders[0]
+
+	basket := models.Basket{
+		Lines:         latest.lines,
+		LastOrderedAt: latest.createdAt,
+		TimesOrdered:  len(orders),
+		OriginalTotal: latest.totalAmount,
+		AllAvailable:  true,
+	}
+
+	for _, order := range orders {
+		basket.OrderIDs = append(basket.OrderIDs, order.id)
+	}
+
+	for _, line := range latest.lines {
+		if line.Available {
+			basket.CurrentTotal += line.LineTotal
+		} else {
+			basket.AllAvailable = false
+		}
+	}
+	basket.CurrentTotal = math.Round(basket.CurrentTotal*100) / 100
+
+	basket.Weekday = commonWeekday(orders)
+	basket.Explanation = basketExplanation(basket, orders)
+
+	return basket
+}
+
+// basketWithinPrice reports whether every available item of a basket is within the
+// filter's price range and the basket's current total within its budget
+func basketWithinPrice(basket models.Basket, filter PriceFilter) bool {
+	if filter.Budget > 0 && basket.CurrentTotal > filter.Budget {
+		return false
+	}
+	for _, line := range basket.Lines {
+		if line.Available && !itemWithinRange(line.Item, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// commonWeekday returns the weekday shared by every order, if there is one
+func commonWeekday(orders []pastOrder) string {
+	if len(orders) < minRecurringOrders {
+		return ""
+	}
+
+	weekday := orders[0].createdAt.Weekday()
+	for _, order := range orders[1:] {
+		if order.createdAt.Weekday() != weekday {
+			return ""
+		}
+	}
+	return weekday.String()
+}
+
+// basketExplanation describes when a basket was ordered and whether it can be reordered as-is
+func basketExplanation(basket models.Basket, orders []pastOrder) string {
+	var explanation string
+	switch {
+	case basket.Weekday != "":
+		explanation = fmt.Sprintf("You order this every %s (%d times)", basket.Weekday, basket.TimesOrdered)
+	case basket.TimesOrdered >= minRecurringOrders:
+		oldest := orders[len(orders)-1].createdAt
+		days := basket.LastOrderedAt.Sub(oldest).Hours() / 24 / float64(basket.TimesOrdered-1)
+		explanation = fmt.Sprintf("You order this about every %d days (%d times)", int(math.Round(days)), basket.TimesOrdered)
+	case !basket.LastOrderedAt.IsZero():
+		explanation = fmt.Sprintf("Your order from %s", basket.LastOrderedAt.Format("Jan 2, 2006"))
+	default:
+		explanation = "One of your previous orders"
+	}
+
+	if !basket.AllAvailable {
+		explanation += "; some items are no longer available"
+	}
+
+	return explanation
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func TestBasketWithinPrice(t *testing.T) {
+	basket := models.Basket{
+		Lines: []models.BasketLine{
+			{Item: models.Item{DbID: 1, Price: 12}, Quantity: 1, Available: true},
+			{Item: models.Item{DbID: 2, Price: 4}, Quantity: 2, Available: true},
+			{Item: models.Item{DbID: 3, Price: 30}, Quantity: 1, Available: false},
+		},
+		CurrentTotal: 20,
+	}
+
+	tests := []struct {
+		name   string
+		filter PriceFilter
+		want   bool
+	}{
+		{"no filter", PriceFilter{}, true},
+		{"budget covers the current total", PriceFilter{Budget: 20}, true},
+		{"budget below the current total", PriceFilter{Budget: 19.99}, false},
+		{"every available item in range", PriceFilter{MinPrice: 4, MaxPrice: 12}, true},
+		{"an item below the minimum", PriceFilter{MinPrice: 5}, false},
+		{"an item above the maximum", PriceFilter{MaxPrice: 10}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := basketWithinPrice(basket, tt.filter); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func basketLine(itemID, quantity int, price float64) models.BasketLine {
+	return models.BasketLine{
+		Item:      models.Item{DbID: itemID, Price: price},
+		Quantity:  quantity,
+		Available: true,
+		LineTotal: price * float64(quantity),
+	}
+}
+
+func TestPastOrderSignature(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []models.BasketLine
+		same bool
+	}{
+		{"line order is ignored", []models.BasketLine{basketLine(1, 1, 5), basketLine(2, 2, 3)}, []models.BasketLine{basketLine(2, 2, 3), basketLine(1, 1, 5)}, true},
+		{"quantities differ", []models.BasketLine{basketLine(1, 1, 5)}, []models.BasketLine{basketLine(1, 2, 5)}, false},
+		{"items differ", []models.BasketLine{basketLine(1, 1, 5)}, []models.BasketLine{basketLine(2, 1, 5)}, false},
+		{"prices are ignored", []models.BasketLine{basketLine(1, 1, 5)}, []models.BasketLine{basketLine(1, 1, 7)}, true},
+		// 1x12 and 11x2 must not collide once joined
+		{"ids and quantities do not run together", []models.BasketLine{basketLine(1, 12, 5)}, []models.BasketLine{basketLine(11, 2, 5)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := pastOrder{lines: tt.a}.signature()
+			b := pastOrder{lines: tt.b}.signature()
+			if (a == b) != tt.same {
+				t.Errorf("signatures %q and %q: same = %v, want %v", a, b, a == b, tt.same)
+			}
+		})
+	}
+}
+
+func TestCommonWeekday(t *testing.T) {
+	friday := time.Date(2024, 3, 1, 19, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name  string
+		dates []time.Time
+		want  string
+	}{
+		{"single order", []time.Time{friday}, ""},
+		{"every friday", []time.Time{friday.Add(14 * day), friday.Add(7 * day), friday}, "Friday"},
+		{"one saturday", []time.Time{friday.Add(8 * day), friday}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := make([]pastOrder, len(tt.dates))
+			for i, date := range tt.dates {
+				orders[i] = pastOrder{id: i + 1, createdAt: date}
+			}
+			if got := commonWeekday(orders); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildBasket(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	withdrawn := basketLine(3, 1, 9)
+	withdrawn.Available = false
+
+	latest := pastOrder{id: 9, createdAt: monday.Add(14 * 24 * time.Hour), totalAmount: 21, lines: []models.BasketLine{basketLine(1, 1, 5.555), basketLine(2, 2, 3), withdrawn}}
+	older := pastOrder{id: 4, createdAt: monday.Add(7 * 24 * time.Hour), totalAmount: 20, lines: latest.lines}
+	oldest := pastOrder{id: 1, createdAt: monday, totalAmount: 19, lines: latest.lines}
+
+	basket := buildBasket([]pastOrder{latest, older, oldest})
+
+	if !slices.Equal(basket.OrderIDs, []int{9, 4, 1}) {
+		t.Errorf("order IDs = %v", basket.OrderIDs)
+	}
+	if basket.TimesOrdered != 3 || !basket.LastOrderedAt.Equal(latest.createdAt) || basket.OriginalTotal != 21 {
+		t.Errorf("got %+v", basket)
+	}
+	// The withdrawn item is left out of the current total, which is rounded to cents
+	if basket.CurrentTotal != 11.56 || basket.AllAvailable {
+		t.Errorf("current total = %v, all available = %v", basket.CurrentTotal, basket.AllAvailable)
+	}
+	if basket.Weekday != "Monday" {
+		t.Errorf("weekday = %q", basket.Weekday)
+	}
+	want := "You order this every Monday (3 times); some items are no longer available"
+	if basket.Explanation != want {
+		t.Errorf("explanation = %q, want %q", basket.Explanation, want)
+	}
+}
+
+func TestBasketExplanation(t *testing.T) {
+	start := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	order := func(daysLater int) pastOrder {
+		return pastOrder{createdAt: start.Add(time.Duration(daysLater) * 24 * time.Hour)}
+	}
+
+	tests := []struct {
+		name   string
+		orders []pastOrder
+		want   string
+	}{
+		{"recurring on different weekdays", []pastOrder{order(20), order(10), order(0)}, "You order this about every 10 days (3 times)"},
+		{"ordered once", []pastOrder{order(0)}, "Your order from Mar 4, 2024"},
+		{"no date", []pastOrder{{}}, "One of your previous orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			basket := models.Basket{
+				TimesOrdered:  len(tt.orders),
+				LastOrderedAt: tt.orders[0].createdAt,
+				Weekday:       commonWeekday(tt.orders),
+				AllAvailable:  true,
+			}
+			if got := basketExplanation(basket, tt.orders); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
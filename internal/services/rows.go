@@ -0,0 +1,67 @@
+package services
+
+import (
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// itemRow holds the item columns most queries return. Category and description are
+// optional so an item missing either still loads.
+type itemRow struct {
+	ItemID      int     `db:"item_id"`
+	Name        string  `db:"name"`
+	Price       float64 `db:"price"`
+	Category    string  `db:"category,optional"`
+	Description string  `db:"description,optional"`
+}
+
+// item converts the row into a model
+func (r itemRow) item() models.Item {
+	return models.Item{
+		DbID:        r.ItemID,
+		Name:        r.Name,
+		Price:       r.Price,
+		Category:    r.Category,
+		Description: r.Description,
+	}
+}
+
+// countedItemRow is an item with the count a strategy ranks it by
+type countedItemRow struct {
+	itemRow
+	Times int `db:"times"`
+}
+
+// coOrderedItemRow is an item ordered along with the target item, with how often
+type coOrderedItemRow struct {
+	countedItemRow
+	TargetID int `db:"target_id"`
+}
+
+// itemsFromRows converts item rows into models
+func itemsFromRows(rows []itemRow) []models.Item {
+	items := make([]models.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.item())
+	}
+	return items
+}
+
+// userRow holds the user columns; email and created_at are optional for users created by other tools
+type userRow struct {
+	UserID    int       `db:"user_id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email,optional"`
+	CreatedAt time.Time `db:"created_at,optional"`
+}
+
+// user converts the row into a model
+func (r userRow) user() models.User {
+	return models.User{
+		DbID:      r.UserID,
+		Name:      r.Name,
+		Email:     r.Email,
+		CreatedAt: r.CreatedAt,
+	}
+}
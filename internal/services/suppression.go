@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// Kinds of "not interested" suppression
+const (
+	SuppressionItem     = "item"
+	SuppressionCategory = "category"
+)
+
+// DefaultSuppressionPeriod is how long a "not interested" mark lasts. A mark fades over
+// the period: its penalty falls linearly from 1 when made to 0 when it expires.
+const DefaultSuppressionPeriod = 30 * 24 * time.Hour
+
+// suppressionHidePenalty is the penalty above which a mark hides its item outright; below
+// it the item is shown again with its score scaled down by the penalty
+const suppressionHidePenalty = 0.9
+
+var (
+	// ErrUserNotFound is returned when no user has the requested ID
+	ErrUserNotFound = newError(KindNotFound, "user not found")
+	// ErrItemNotFound is returned when no item has the requested ID
+	ErrItemNotFound = newError(KindNotFound, "item not found")
+	// ErrCategoryNotFound is returned when no category has the requested name
+	ErrCategoryNotFound = newError(KindNotFound, "category not found")
+	// ErrSuppressionNotFound is returned when undoing a suppression that does not exist
+	ErrSuppressionNotFound = newError(KindNotFound, "suppression not found")
+)
+
+// suppressionRow is one NOT_INTERESTED mark; the item columns are null for categories
+type suppressionRow struct {
+	Kind     string    `db:"kind"`
+	ItemID   int       `db:"item_id,optional"`
+	Name     string    `db:"name"`
+	Price    float64   `db:"price,optional"`
+	Category string    `db:"category,optional"`
+	At       time.Time `db:"at"`
+}
+
+// suppressionPenalty is how much a mark made age ago still counts: 1 when fresh,
+// falling linearly to 0 once the period has passed
+func suppressionPenalty(age, period time.Duration) float64 {
+	if period <= 0 || age >= period {
+		return 0
+	}
+	if age <= 0 {
+		return 1
+	}
+	return 1 - float64(age)/float64(period)
+}
+
+// suppressionSet holds the penalties of a user's active suppressions for quick lookups
+type suppressionSet struct {
+	items      map[int]float64
+	categories map[string]float64
+}
+
+// penalty is the stronger of an item's own mark and its category's, 0 when neither is marked
+func (set suppressionSet) penalty(item models.Item) float64 {
+	return math.Max(set.items[item.DbID], set.categories[item.Category])
+}
+
+// hides reports whether a mark is still fresh enough to leave the item out entirely
+func (set suppressionSet) hides(item models.Item) bool {
+	return set.penalty(item) >= suppressionHidePenalty
+}
+
+// apply drops the recommendations the set hides and scales the positive scores of the
+// rest down by their penalty, keeping the list ordered by score. Negative scores, such as
+// poorly rated items, are left alone so a mark never makes an item look better.
+func (set suppressionSet) apply(recommendations []models.Recommendation) []models.Recommendation {
+	filtered := make([]models.Recommendation, 0, len(recommendations))
+	for _, rec := range recommendations {
+		penalty := set.penalty(rec.Item)
+		if penalty >= suppressionHidePenalty {
+			continue
+		}
+		if rec.Score > 0 {
+			rec.Score *= 1 - penalty
+		}
+		filtered = append(filtered, rec)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Score > filtered[j].Score
+	})
+	return filtered
+}
+
+// SetSuppressionPeriod changes how long suppressions last; marks older than this have expired
+func (s *RecommendationService) SetSuppressionPeriod(period time.Duration) {
+	s.suppressionPeriod = period
+}
+
+// GetSuppressions lists a user's active "not interested" marks with their current penalty, newest first
+func (s *RecommendationService) GetSuppressions(ctx context.Context, userID int) ([]models.Suppression, error) {
+	query := `
+		MATCH (u:User {db_id: $userId})-[n:NOT_INTERESTED]->(target)
+		WHERE n.at > $since
+		RETURN CASE WHEN target:Item THEN $itemKind ELSE $categoryKind END AS kind,
+			   target.db_id AS item_id,
+			   target.name AS name,
+			   target.price AS price,
+			   head([(target)-[:IN_CATEGORY]->(c:Category) | c.name]) AS category,
+			   n.at AS at
+		ORDER BY at DESC
+	`
+
+	params := map[string]interface{}{
+		"userId":       userID,
+		"since":        s.now().Add(-s.suppressionPeriod),
+		"itemKind":     SuppressionItem,
+		"categoryKind": SuppressionCategory,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppressions: %w", err)
+	}
+
+	rows, err := database.DecodeAll[suppressionRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode suppressions: %w", err)
+	}
+
+	now := s.now()
+	suppressions := make([]models.Suppression, 0, len(rows))
+	for _, row := range rows {
+		suppression := models.Suppression{
+			Kind:      row.Kind,
+			CreatedAt: row.At,
+			ExpiresAt: row.At.Add(s.suppressionPeriod),
+			Penalty:   suppressionPenalty(now.Sub(row.At), s.suppressionPeriod),
+		}
+
+		if suppression.Kind == SuppressionItem {
+			suppression.Item = &models.Item{
+				DbID:     row.ItemID,
+				Name:     row.Name,
+				Price:    row.Price,
+				Category: row.Category,
+			}
+		} else {
+			suppression.Category = row.Name
+		}
+
+		suppressions = append(suppressions, suppression)
+	}
+
+	return suppressions, nil
+}
+
+// SuppressItem marks an item as "not interested" for a user, restarting the period if already marked
+func (s *RecommendationService) SuppressItem(ctx context.Context, userID, itemID int) error {
+	query := `
+		MATCH (u:User {db_id: $userId})
+		OPTIONAL MATCH (i:Item {db_id: $itemId})
+		FOREACH (_ IN CASE WHEN i IS NULL THEN [] ELSE [1] END |
+			MERGE (u)-[n:NOT_INTERESTED]->(i)
+			SET n.at = $at
+		)
+		RETURN i IS NOT NULL AS found
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+		"itemId": itemID,
+		"at":     s.now(),
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to suppress item: %w", err)
+	}
+	if len(results) == 0 {
+		return ErrUserNotFound
+	}
+	found, err := database.Value[bool](results[0], "found")
+	if err != nil {
+		return fmt.Errorf("failed to decode suppression: %w", err)
+	}
+	if !found {
+		return ErrItemNotFound
+	}
+
+	return nil
+}
+
+// SuppressCategory marks a whole category as "not interested" for a user
+func (s *RecommendationService) SuppressCategory(ctx context.Context, userID int, category string) error {
+	query := `
+		MATCH (u:User {db_id: $userId})
+		OPTIONAL MATCH (c:Category {name: $category})
+		FOREACH (_ IN CASE WHEN c IS NULL THEN [] ELSE [1] END |
+			MERGE (u)-[n:NOT_INTERESTED]->(c)
+			SET n.at = $at
+		)
+		RETURN c IS NOT NULL AS found
+	`
+
+	params := map[string]interface{}{
+		"userId":   userID,
+		"category": category,
+		"at":       s.now(),
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to suppress category: %w", err)
+	}
+	if len(results) == 0 {
+		return ErrUserNotFound
+	}
+	found, err := database.Value[bool](results[0], "found")
+	if err != nil {
+		return fmt.Errorf("failed to decode suppression: %w", err)
+	}
+	if !found {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// SuppressDismissed marks the items of dismiss feedback events "not interested", so a
+// guest who dismisses a recommendation stops seeing it just as with SuppressItem. Events
+// about unknown users or items are skipped, the mark runs from when the item was
+// dismissed (never later than now), and an older dismissal never shortens a newer mark.
+func (s *RecommendationService) SuppressDismissed(ctx context.Context, dismissals []models.Event) error {
+	if len(dismissals) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	rows := make([]map[string]interface{}, len(dismissals))
+	for i, event := range dismissals {
+		at := event.At
+		if at.IsZero() || at.After(now) {
+			at = now
+		}
+		rows[i] = map[string]interface{}{
+			"user_id": event.UserID,
+			"item_id": event.ItemID,
+			"at":      at,
+		}
+	}
+
+	query := `
+		UNWIND $rows AS row
+		MATCH (u:User {db_id: row.user_id})
+		MATCH (i:Item {db_id: row.item_id})
+		MERGE (u)-[n:NOT_INTERESTED]->(i)
+		SET n.at = CASE WHEN n.at IS NULL OR n.at < row.at THEN row.at ELSE n.at END
+	`
+
+	params := map[string]interface{}{
+		"rows": rows,
+	}
+
+	if err := s.client.ExecuteWrite(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to suppress dismissed items: %w", err)
+	}
+
+	return nil
+}
+
+// UnsuppressItem undoes a user's "not interested" mark on an item
+func (s *RecommendationService) UnsuppressItem(ctx context.Context, userID, itemID int) error {
+	query := `
+		MATCH (:User {db_id: $userId})-[n:NOT_INTERESTED]->(:Item {db_id: $itemId})
+		DELETE n
+		RETURN count(*) AS removed
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+		"itemId": itemID,
+	}
+
+	return s.removeSuppression(ctx, query, params)
+}
+
+// UnsuppressCategory undoes a user's "not interested" mark on a category
+func (s *RecommendationService) UnsuppressCategory(ctx context.Context, userID int, category string) error {
+	query := `
+		MATCH (:User {db_id: $userId})-[n:NOT_INTERESTED]->(:Category {name: $category})
+		DELETE n
+		RETURN count(*) AS removed
+	`
+
+	params := map[string]interface{}{
+		"userId":   userID,
+		"category": category,
+	}
+
+	return s.removeSuppression(ctx, query, params)
+}
+
+// FilterSuppressed applies the user's "not interested" marks, directly or by category:
+// fresh marks drop their items, fading ones scale their scores down
+func (s *RecommendationService) FilterSuppressed(ctx context.Context, userID int, recommendations []models.Recommendation) ([]models.Recommendation, error) {
+	if len(recommendations) == 0 {
+		return recommendations, nil
+	}
+
+	set, err := s.getSuppressionSet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(set.items) == 0 && len(set.categories) == 0 {
+		return recommendations, nil
+	}
+
+	return set.apply(recommendations), nil
+}
+
+// getSuppressionSet loads a user's active suppressions
+func (s *RecommendationService) getSuppressionSet(ctx context.Context, userID int) (suppressionSet, error) {
+	set := suppressionSet{
+		items:      make(map[int]float64),
+		categories: make(map[string]float64),
+	}
+
+	suppressions, err := s.GetSuppressions(ctx, userID)
+	if err != nil {
+		return set, err
+	}
+
+	for _, suppression := range suppressions {
+		if suppression.Kind == SuppressionItem {
+			set.items[suppression.Item.DbID] = suppression.Penalty
+		} else {
+			set.categories[suppression.Category] = suppression.Penalty
+		}
+	}
+
+	return set, nil
+}
+
+// PruneExpiredSuppressions deletes the "not interested" marks that have expired and
+// returns how many were removed; expired marks are already ignored, this only keeps
+// them from piling up
+func (s *RecommendationService) PruneExpiredSuppressions(ctx context.Context) (int, error) {
+	query := `
+		MATCH (:User)-[n:NOT_INTERESTED]->()
+		WHERE n.at <= $since
+		DELETE n
+		RETURN count(*) AS removed
+	`
+
+	params := map[string]interface{}{
+		"since": s.now().Add(-s.suppressionPeriod),
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune expired suppressions: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+	removed, err := database.Value[int](results[0], "removed")
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode pruned suppressions: %w", err)
+	}
+
+	return removed, nil
+}
+
+// removeSuppression runs a delete query and reports whether anything was removed
+func (s *RecommendationService) removeSuppression(ctx context.Context, query string, params map[string]interface{}) error {
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to remove suppression: %w", err)
+	}
+	if len(results) == 0 {
+		return ErrSuppressionNotFound
+	}
+	removed, err := database.Value[int](results[0], "removed")
+	if err != nil {
+		return fmt.Errorf("failed to decode suppression: %w", err)
+	}
+	if removed == 0 {
+		return ErrSuppressionNotFound
+	}
+
+	return nil
+}
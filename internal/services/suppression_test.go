@@ -0,0 +1,91 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func TestSuppressionPenalty(t *testing.T) {
+	const period = 30 * 24 * time.Hour
+	tests := []struct {
+		name   string
+		age    time.Duration
+		period time.Duration
+		want   float64
+	}{
+		{"fresh", 0, period, 1},
+		{"clock skew", -time.Hour, period, 1},
+		{"a tenth in", period / 10, period, 0.9},
+		{"halfway", period / 2, period, 0.5},
+		{"expired", period, period, 0},
+		{"long expired", 2 * period, period, 0},
+		{"no period", time.Hour, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressionPenalty(tt.age, tt.period); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuppressionSetApply(t *testing.T) {
+	set := suppressionSet{
+		items:      map[int]float64{1: 1, 2: 0.5, 5: 0.25},
+		categories: map[string]float64{"Desserts": 0.95, "Drinks": 0.75},
+	}
+	recommendation := func(id int, category string, score float64) models.Recommendation {
+		return models.Recommendation{Item: models.Item{DbID: id, Category: category}, Score: score}
+	}
+
+	got := set.apply([]models.Recommendation{
+		recommendation(1, "Mains", 10), // freshly marked: dropped
+		recommendation(2, "Mains", 8),  // half faded: 4
+		recommendation(3, "Desserts", 6),
+		recommendation(4, "Mains", 5),
+		recommendation(5, "Drinks", 4),  // the category mark is stronger: 1
+		recommendation(6, "Mains", -2),  // negative scores are not lifted
+		recommendation(7, "Drinks", -1), // even when marked
+	})
+
+	want := []struct {
+		id    int
+		score float64
+	}{{4, 5}, {2, 4}, {5, 1}, {7, -1}, {6, -2}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i, rec := range got {
+		if rec.Item.DbID != want[i].id || math.Abs(rec.Score-want[i].score) > 1e-9 {
+			t.Errorf("position %d: got item %d scoring %v, want item %d scoring %v", i, rec.Item.DbID, rec.Score, want[i].id, want[i].score)
+		}
+	}
+}
+
+func TestSuppressionSetHides(t *testing.T) {
+	set := suppressionSet{
+		items:      map[int]float64{1: suppressionHidePenalty, 2: suppressionHidePenalty - 0.01},
+		categories: map[string]float64{"Desserts": 1},
+	}
+
+	tests := []struct {
+		item models.Item
+		want bool
+	}{
+		{models.Item{DbID: 1, Category: "Mains"}, true},
+		{models.Item{DbID: 2, Category: "Mains"}, false},
+		{models.Item{DbID: 2, Category: "Desserts"}, true},
+		{models.Item{DbID: 3, Category: "Mains"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := set.hides(tt.item); got != tt.want {
+			t.Errorf("hides(%+v) = %v, want %v", tt.item, got, tt.want)
+		}
+	}
+}
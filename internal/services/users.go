@@ -0,0 +1,577 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yishak-cs/Neo4j_DB/internal/database"
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// Erasure policies decide what happens to a forgotten user's orders and their
+// contributions to HAS_ORDERED and ORDERED_ALONG_WITH
+const (
+	// ErasureAnonymise strips the user's name, email and review comments but keeps
+	// the node, its orders and every derived count
+	ErasureAnonymise = "anonymise"
+	// ErasureDelete deletes the user and their HAS_ORDERED edges; their orders stay
+	// as anonymous orders, so ORDERED_ALONG_WITH keeps their contribution
+	ErasureDelete = "delete"
+	// ErasurePurge deletes the user and their orders and subtracts the orders'
+	// contribution from ORDERED_ALONG_WITH
+	ErasurePurge = "purge"
+)
+
+// Order statuses reported in a user's order history
+const (
+	OrderStatusPlaced    = "placed"
+	OrderStatusCancelled = "cancelled"
+)
+
+var (
+	// ErrInvalidUser is returned for users without a name or with a malformed email
+	ErrInvalidUser = newError(KindInvalidArgument, "invalid user")
+	// ErrEmailTaken is returned when another user already has the email
+	ErrEmailTaken = newError(KindConflict, "email is already in use")
+	// ErrInvalidErasurePolicy is returned for an unknown erasure policy
+	ErrInvalidErasurePolicy = newError(KindInvalidArgument, "invalid erasure policy")
+)
+
+// UserUpdate lists the user fields to change; nil fields are left as they are
+type UserUpdate struct {
+	Name  *string
+	Email *string
+}
+
+// SetErasurePolicy sets what right-to-be-forgotten requests do with a user's orders
+func (s *RecommendationService) SetErasurePolicy(policy string) error {
+	switch policy {
+	case ErasureAnonymise, ErasureDelete, ErasurePurge:
+		s.erasurePolicy = policy
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidErasurePolicy, policy)
+	}
+}
+
+// GetUserProfile returns a user with their order count, lifetime spend and favourite category
+func (s *RecommendationService) GetUserProfile(ctx context.Context, userID int) (models.UserProfile, error) {
+	profiles, err := s.GetUserProfiles(ctx, []int{userID})
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+	profile, ok := profiles[userID]
+	if !ok {
+		return models.UserProfile{}, ErrUserNotFound
+	}
+	return profile, nil
+}
+
+// GetUserProfiles returns the profiles of several users in one query, keyed by user;
+// unknown and erased users are left out
+func (s *RecommendationService) GetUserProfiles(ctx context.Context, userIDs []int) (map[int]models.UserProfile, error) {
+	// Favourite category is the one the user bought the most units of
+	query := `
+		MATCH (u:User)
+		WHERE u.db_id IN $userIds AND u.erased_at IS NULL
+		OPTIONAL MATCH (u)-[:HAS_MADE]->(o:Order)
+		WITH u,
+			 count(o) AS order_count,
+			 sum(coalesce(o.total_amount, 0.0)) AS lifetime_spend,
+			 max(o.created_at) AS last_order_at
+		OPTIONAL MATCH (u)-[:HAS_MADE]->(:Order)-[hi:HAS_ITEM]->(:Item)-[:IN_CATEGORY]->(c:Category)
+		WITH u, order_count, lifetime_spend, last_order_at, c.name AS category, sum(hi.quantity) AS units
+		ORDER BY units DESC, category
+		RETURN u.db_id AS user_id,
+			   u.name AS name,
+			   u.email AS email,
+			   u.created_at AS created_at,
+			   order_count,
+			   lifetime_spend,
+			   last_order_at,
+			   head(collect(category)) AS favourite_category
+	`
+
+	params := map[string]interface{}{
+		"userIds": userIDs,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profiles: %w", err)
+	}
+
+	rows, err := database.DecodeAll[userProfileRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user profiles: %w", err)
+	}
+
+	profiles := make(map[int]models.UserProfile, len(rows))
+	for _, row := range rows {
+		profiles[row.UserID] = models.UserProfile{
+			User: row.user(),
+			Stats: models.UserStats{
+				OrderCount:        row.OrderCount,
+				LifetimeSpend:     row.LifetimeSpend,
+				FavouriteCategory: row.FavouriteCategory,
+				LastOrderAt:       row.LastOrderAt,
+			},
+		}
+	}
+
+	return profiles, nil
+}
+
+// RequireUser returns ErrUserNotFound unless the user exists and has not been erased,
+// so user-scoped endpoints can tell an unknown user from one with no history
+func (s *RecommendationService) RequireUser(ctx context.Context, userID int) error {
+	query := `
+		MATCH (u:User {db_id: $userId})
+		WHERE u.erased_at IS NULL
+		RETURN u.db_id AS user_id
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(results) == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GetUserOrders returns one page of a user's orders, cancelled ones included, newest first,
+// together with how many orders the user has in total
+func (s *RecommendationService) GetUserOrders(ctx context.Context, userID, page, pageSize int) ([]models.Order, int, error) {
+	countQuery := `
+		MATCH (u:User {db_id: $userId})
+		WHERE u.erased_at IS NULL
+		OPTIONAL MATCH (u)-[:HAS_MADE]->(o)
+		WHERE o:Order OR o:CancelledOrder
+		// Grouping by the user returns no row, rather than a zero count, for an unknown user
+		RETURN u.db_id AS user_id, count(o) AS total
+	`
+
+	pageQuery := `
+		MATCH (u:User {db_id: $userId})-[:HAS_MADE]->(o)
+		WHERE u.erased_at IS NULL AND (o:Order OR o:CancelledOrder)
+		WITH o
+		ORDER BY o.created_at DESC, o.db_id DESC
+		SKIP $skip
+		LIMIT $limit
+		OPTIONAL MATCH (o)-[hi:HAS_ITEM]->(i:Item)
+		RETURN o.db_id AS order_id,
+			   o.created_at AS created_at,
+			   o.total_amount AS total_amount,
+			   o:CancelledOrder AS cancelled,
+			   i.db_id AS item_id,
+			   hi.quantity AS quantity
+		ORDER BY created_at DESC, order_id DESC, item_id
+	`
+
+	params := map[string]interface{}{
+		"userId": userID,
+		"skip":   (page - 1) * pageSize,
+		"limit":  pageSize,
+	}
+
+	countResults, err := s.client.ExecuteRead(ctx, countQuery, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count user orders: %w", err)
+	}
+	if len(countResults) == 0 {
+		return nil, 0, ErrUserNotFound
+	}
+	total, err := database.Value[int](countResults[0], "total")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode user order count: %w", err)
+	}
+
+	results, err := s.client.ExecuteRead(ctx, pageQuery, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get user orders: %w", err)
+	}
+
+	rows, err := database.DecodeAll[orderHistoryRow](results)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode user orders: %w", err)
+	}
+
+	orders := make([]models.Order, 0, pageSize)
+	for _, row := range rows {
+		orders = appendOrderRow(orders, userID, row)
+	}
+
+	return orders, total, nil
+}
+
+// appendOrderRow adds one line of an order history, sorted by order, to the orders
+// read so far, starting a new order when the row belongs to the next one
+func appendOrderRow(orders []models.Order, userID int, row orderHistoryRow) []models.Order {
+	if len(orders) == 0 || orders[len(orders)-1].DbID != row.OrderID {
+		order := models.Order{
+			DbID:        row.OrderID,
+			UserID:      userID,
+			CreatedAt:   row.CreatedAt,
+			TotalAmount: row.TotalAmount,
+			Status:      OrderStatusPlaced,
+		}
+		if row.Cancelled {
+			order.Status = OrderStatusCancelled
+		}
+		orders = append(orders, order)
+	}
+
+	if row.ItemID != nil {
+		current := &orders[len(orders)-1]
+		current.Items = append(current.Items, models.OrderItem{
+			OrderID:  row.OrderID,
+			ItemID:   *row.ItemID,
+			Quantity: row.Quantity,
+		})
+	}
+	return orders
+}
+
+// GetRecentOrders returns the latest orders of several users in one query, cancelled
+// ones included, newest first and at most limit per user, keyed by user; erased users have none
+func (s *RecommendationService) GetRecentOrders(ctx context.Context, userIDs []int, limit int) (map[int][]models.Order, error) {
+	query := `
+		MATCH (u:User)-[:HAS_MADE]->(o)
+		WHERE u.db_id IN $userIds AND u.erased_at IS NULL AND (o:Order OR o:CancelledOrder)
+		WITH u, o
+		ORDER BY o.created_at DESC, o.db_id DESC
+		WITH u, collect(o)[..$limit] AS orders
+		UNWIND orders AS o
+		OPTIONAL MATCH (o)-[hi:HAS_ITEM]->(i:Item)
+		RETURN u.db_id AS user_id,
+			   o.db_id AS order_id,
+			   o.created_at AS created_at,
+			   o.total_amount AS total_amount,
+			   o:CancelledOrder AS cancelled,
+			   i.db_id AS item_id,
+			   hi.quantity AS quantity
+		ORDER BY user_id, created_at DESC, order_id DESC, item_id
+	`
+
+	params := map[string]interface{}{
+		"userIds": userIDs,
+		"limit":   limit,
+	}
+
+	results, err := s.client.ExecuteRead(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent orders: %w", err)
+	}
+
+	rows, err := database.DecodeAll[userOrderRow](results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode recent orders: %w", err)
+	}
+
+	orders := make(map[int][]models.Order, len(userIDs))
+	for _, row := range rows {
+		orders[row.UserID] = appendOrderRow(orders[row.UserID], row.UserID, row.orderHistoryRow)
+	}
+
+	return orders, nil
+}
+
+// CreateUser registers a new user
+func (s *RecommendationService) CreateUser(ctx context.Context, user models.User) (models.User, error) {
+	if err := normaliseUser(&user.Name, &user.Email); err != nil {
+		return models.User{}, err
+	}
+	user.CreatedAt = s.now().UTC()
+
+	// User IDs come from a sequence node, like order and item IDs. IDs of erased users are
+	// never handed out again: a reload erases whoever holds one once more.
+	query := `
+		OPTIONAL MATCH (existing)
+		WHERE existing:User OR existing:ErasedUser
+		WITH coalesce(max(existing.db_id), 0) AS highest
+		OPTIONAL MATCH (taken:User {email: $email})
+		WITH highest, taken
+		WHERE taken IS NULL
+		MERGE (seq:Sequence {name: "user"})
+		ON CREATE SET seq.value = highest
+		SET seq.value = CASE WHEN seq.value < highest THEN highest ELSE seq.value END + 1
+		CREATE (u:User {
+			db_id: seq.value,
+			name: $name,
+			email: $email,
+			created_at: $createdAt
+		})
+		RETURN u.db_id AS user_id
+	`
+
+	params := map[string]interface{}{
+		"name":      user.Name,
+		"email":     user.Email,
+		"createdAt": user.CreatedAt,
+	}
+
+	results, err := s.client.ExecuteWriteWithResult(ctx, query, params)
+	if err != nil {
+		return models.User{}, fmt.Errorf("failed to create user: %w", err)
+	}
+	if len(results) == 0 {
+		return models.User{}, ErrEmailTaken
+	}
+
+	user.DbID, err = database.Value[int](results[0], "user_id")
+	if err != nil {
+		return models.User{}, fmt.Errorf("failed to decode created user: %w", err)
+	}
+	return user, nil
+}
+
+// UpdateUser changes a user's name or email
+func (s *RecommendationService) UpdateUser(ctx context.Context, userID int, update UserUpdate) (models.User, error) {
+	// Work on copies so the caller's values are not trimmed in place
+	var name, email *string
+	if update.Name != nil {
+		value := *update.Name
+		name = &value
+	}
+	if update.Email != nil {
+		value := *update.Email
+		email = &value
+	}
+	if err := normaliseUser(name, email); err != nil {
+		return models.User{}, err
+	}
+
+	params := map[string]interface{}{
+		"userId": userID,
+		"name":   nil,
+		"email":  nil,
+	}
+	if name != nil {
+		params["name"] = *name
+	}
+	if email != nil {
+		params["email"] = *email
+	}
+
+	err := s.client.ExecuteWriteTransactionSimple(ctx, func(tx neo4j.ManagedTransaction) error {
+		results, err := database.RunInTransaction(ctx, tx, `
+			MATCH (u:User {db_id: $userId})
+			WHERE u.erased_at IS NULL
+			OPTIONAL MATCH (other:User {email: $email})
+			WHERE other <> u
+			RETURN other IS NOT NULL AS taken
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if len(results) == 0 {
+			return ErrUserNotFound
+		}
+		taken, err := database.Value[bool](results[0], "taken")
+		if err != nil {
+			return fmt.Errorf("failed to decode user: %w", err)
+		}
+		if taken {
+			return ErrEmailTaken
+		}
+
+		_, err = database.RunInTransaction(ctx, tx, `
+			MATCH (u:User {db_id: $userId})
+			SET u.name = coalesce($name, u.name),
+				u.email = coalesce($email, u.email)
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	profile, err := s.GetUserProfile(ctx, userID)
+	if err != nil {
+		return models.User{}, err
+	}
+	return profile.User, nil
+}
+
+// EraseUser carries out a right-to-be-forgotten request under the configured policy.
+// Everything happens in one transaction, so derived counts never reflect a half-erased user.
+func (s *RecommendationService) EraseUser(ctx context.Context, userID int) (models.ErasureReport, error) {
+	report := models.ErasureReport{
+		UserID:   userID,
+		Policy:   s.erasurePolicy,
+		ErasedAt: s.now().UTC(),
+	}
+	params := map[string]interface{}{
+		"userId":     userID,
+		"erasedAt":   report.ErasedAt,
+		"policy":     s.erasurePolicy,
+		"erasedName": database.ErasedUserName,
+	}
+
+	err := s.client.ExecuteWriteTransactionSimple(ctx, func(tx neo4j.ManagedTransaction) error {
+		results, err := database.RunInTransaction(ctx, tx, `
+			MATCH (u:User {db_id: $userId})
+			WHERE u.erased_at IS NULL
+			OPTIONAL MATCH (u)-[:HAS_MADE]->(o)
+			WHERE o:Order OR o:CancelledOrder
+			// Grouping by the user returns no row, rather than a zero count, for an unknown user
+			RETURN u.db_id AS user_id, count(o) AS orders
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if len(results) == 0 {
+			return ErrUserNotFound
+		}
+		orders, err := database.Value[int](results[0], "orders")
+		if err != nil {
+			return fmt.Errorf("failed to decode user orders: %w", err)
+		}
+
+		switch report.Policy {
+		case ErasureAnonymise:
+			_, err = database.RunInTransaction(ctx, tx, `
+				MATCH (u:User {db_id: $userId})
+				SET u.name = $erasedName, u.email = "", u.erased_at = $erasedAt
+				WITH u
+				OPTIONAL MATCH (u)-[r:RATED]->(:Item)
+				REMOVE r.comment
+			`, params)
+			if err != nil {
+				return fmt.Errorf("failed to anonymise user: %w", err)
+			}
+
+		case ErasureDelete:
+			// HAS_ORDERED and HAS_MADE go with the node; the orders themselves stay
+			_, err = database.RunInTransaction(ctx, tx, `MATCH (u:User {db_id: $userId}) DETACH DELETE u`, params)
+			if err != nil {
+				return fmt.Errorf("failed to delete user: %w", err)
+			}
+			report.OrdersDetached = orders
+
+		case ErasurePurge:
+			if err := purgeUserOrders(ctx, tx, params); err != nil {
+				return err
+			}
+			_, err = database.RunInTransaction(ctx, tx, `MATCH (u:User {db_id: $userId}) DETACH DELETE u`, params)
+			if err != nil {
+				return fmt.Errorf("failed to delete user: %w", err)
+			}
+			report.OrdersDeleted = orders
+		}
+
+		// The tombstone keeps no personal data, only what a CSV reimport needs to erase the user again
+		_, err = database.RunInTransaction(ctx, tx, `
+			MERGE (t:ErasedUser {db_id: $userId})
+			SET t.policy = $policy, t.erased_at = $erasedAt
+		`, params)
+		if err != nil {
+			return fmt.Errorf("failed to record erasure: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ErasureReport{}, err
+	}
+
+	return report, nil
+}
+
+// purgeUserOrders subtracts a user's counted orders from ORDERED_ALONG_WITH and deletes
+// all their orders with the orders' audit trail. Cancelled orders were already subtracted
+// when they were cancelled, and orders still in the update queue were never added.
+func purgeUserOrders(ctx context.Context, tx neo4j.ManagedTransaction, params map[string]interface{}) error {
+	_, err := database.RunInTransaction(ctx, tx, `
+		MATCH (:User {db_id: $userId})-[:HAS_MADE]->(o:Order)
+		WHERE o.derived_at IS NOT NULL
+		MATCH (o)-[:HAS_ITEM]->(i1:Item)
+		MATCH (o)-[:HAS_ITEM]->(i2:Item)
+		WHERE i1.db_id < i2.db_id
+		WITH i1, i2, count(DISTINCT o) AS together
+		MATCH (i1)-[oaw1:ORDERED_ALONG_WITH]->(i2)
+		MATCH (i2)-[oaw2:ORDERED_ALONG_WITH]->(i1)
+		SET oaw1.times = oaw1.times - together,
+			oaw2.times = oaw2.times - together
+		WITH oaw1, oaw2
+		WHERE oaw1.times <= 0
+		DELETE oaw1, oaw2
+	`, params)
+	if err != nil {
+		return fmt.Errorf("failed to subtract erased orders: %w", err)
+	}
+
+	_, err = database.RunInTransaction(ctx, tx, `
+		MATCH (:User {db_id: $userId})-[:HAS_MADE]->(o)-[:HAS_CHANGE]->(change:OrderChange)
+		DETACH DELETE change
+	`, params)
+	if err != nil {
+		return fmt.Errorf("failed to delete order changes: %w", err)
+	}
+
+	_, err = database.RunInTransaction(ctx, tx, `
+		MATCH (:User {db_id: $userId})-[:HAS_MADE]->(o)
+		WHERE o:Order OR o:CancelledOrder
+		DETACH DELETE o
+	`, params)
+	if err != nil {
+		return fmt.Errorf("failed to delete orders: %w", err)
+	}
+
+	return nil
+}
+
+// userProfileRow is a user with the order summary of GetUserProfile
+type userProfileRow struct {
+	userRow
+	OrderCount        int        `db:"order_count"`
+	LifetimeSpend     float64    `db:"lifetime_spend"`
+	LastOrderAt       *time.Time `db:"last_order_at"`
+	FavouriteCategory string     `db:"favourite_category,optional"`
+}
+
+// orderHistoryRow is one line of an order in a user's history; item_id is null for an order without lines
+type orderHistoryRow struct {
+	OrderID     int       `db:"order_id"`
+	CreatedAt   time.Time `db:"created_at,optional"`
+	TotalAmount float64   `db:"total_amount"`
+	Cancelled   bool      `db:"cancelled"`
+	ItemID      *int      `db:"item_id"`
+	Quantity    int       `db:"quantity,optional"`
+}
+
+// userOrderRow is one line of an order in the history of one of several users
+type userOrderRow struct {
+	orderHistoryRow
+	UserID int `db:"user_id"`
+}
+
+// normaliseUser trims the given fields and checks they are usable; nil fields are skipped
+func normaliseUser(name, email *string) error {
+	if name != nil {
+		*name = strings.TrimSpace(*name)
+		if *name == "" {
+			return fmt.Errorf("%w: name is required", ErrInvalidUser)
+		}
+	}
+	if email != nil {
+		*email = strings.ToLower(strings.TrimSpace(*email))
+		at := strings.Index(*email, "@")
+		if at < 1 || at == len(*email)-1 {
+			return fmt.Errorf("%w: email is not valid", ErrInvalidUser)
+		}
+	}
+	return nil
+}
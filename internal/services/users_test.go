@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+func TestSetErasurePolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		wantErr bool
+	}{
+		{ErasureAnonymise, false},
+		{ErasureDelete, false},
+		{ErasurePurge, false},
+		{"", true},
+		{"anonymize", true},
+		{"PURGE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			s := NewRecommendationService(nil)
+			err := s.SetErasurePolicy(tt.policy)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidErasurePolicy) {
+					t.Errorf("got %v, want ErrInvalidErasurePolicy", err)
+				}
+				if s.erasurePolicy != ErasureAnonymise {
+					t.Errorf("a rejected policy changed the policy to %q", s.erasurePolicy)
+				}
+				return
+			}
+			if err != nil || s.erasurePolicy != tt.policy {
+				t.Errorf("got policy %q, %v", s.erasurePolicy, err)
+			}
+		})
+	}
+}
+
+func TestNormaliseUser(t *testing.T) {
+	tests := []struct {
+		name, email         string
+		wantName, wantEmail string
+		wantErr             bool
+	}{
+		{" Abebe ", " Abebe@Example.COM ", "Abebe", "abebe@example.com", false},
+		{"", "a@b.c", "", "", true},
+		{"Abebe", "no-at-sign", "", "", true},
+		{"Abebe", "@example.com", "", "", true},
+		{"Abebe", "abebe@", "", "", true},
+	}
+
+	for _, tt := range tests {
+		name, email := tt.name, tt.email
+		err := normaliseUser(&name, &email)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidUser) {
+				t.Errorf("normaliseUser(%q, %q) = %v, want ErrInvalidUser", tt.name, tt.email, err)
+			}
+			continue
+		}
+		if err != nil || name != tt.wantName || email != tt.wantEmail {
+			t.Errorf("normaliseUser(%q, %q) = %q, %q, %v", tt.name, tt.email, name, email, err)
+		}
+	}
+
+	// Fields that are not being changed are left alone
+	if err := normaliseUser(nil, nil); err != nil {
+		t.Errorf("normaliseUser(nil, nil) = %v", err)
+	}
+}
+
+func TestAppendOrderRow(t *testing.T) {
+	item := func(id int) *int { return &id }
+	rows := []orderHistoryRow{
+		{OrderID: 9, TotalAmount: 20, ItemID: item(1), Quantity: 2},
+		{OrderID: 9, TotalAmount: 20, ItemID: item(3), Quantity: 1},
+		{OrderID: 7, TotalAmount: 5, Cancelled: true, ItemID: item(2), Quantity: 1},
+		{OrderID: 4, TotalAmount: 0},
+	}
+
+	var orders []models.Order
+	for _, row := range rows {
+		orders = appendOrderRow(orders, 12, row)
+	}
+
+	if len(orders) != 3 {
+		t.Fatalf("got %d orders, want 3", len(orders))
+	}
+	want := []struct {
+		id     int
+		status string
+		items  int
+	}{{9, OrderStatusPlaced, 2}, {7, OrderStatusCancelled, 1}, {4, OrderStatusPlaced, 0}}
+	for i, order := range orders {
+		if order.DbID != want[i].id || order.UserID != 12 || order.Status != want[i].status || len(order.Items) != want[i].items {
+			t.Errorf("order %d: got %+v", i, order)
+		}
+	}
+}
+
+func TestErasureErrorKinds(t *testing.T) {
+	for _, err := range []error{ErrInvalidErasurePolicy, ErrInvalidUser} {
+		if got := KindOf(err); got != KindInvalidArgument {
+			t.Errorf("KindOf(%v) = %s, want %s", err, got, KindInvalidArgument)
+		}
+	}
+	if got := KindOf(ErrEmailTaken); got != KindConflict {
+		t.Errorf("KindOf(ErrEmailTaken) = %s, want %s", got, KindConflict)
+	}
+}
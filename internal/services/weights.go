@@ -0,0 +1,130 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// ErrInvalidWeights is returned for negative or all-zero hybrid weights
+var ErrInvalidWeights = newError(KindInvalidArgument, "invalid hybrid weights")
+
+// WeightsFile is the on-disk profile of tuned hybrid weights loaded at startup
+type WeightsFile struct {
+	Segments    models.SegmentWeights `json:"segments"`
+	Metric      string                `json:"metric,omitempty"`
+	Method      string                `json:"method,omitempty"`
+	K           int                   `json:"k,omitempty"`
+	Scores      map[string]float64    `json:"scores,omitempty"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// DefaultSegmentWeights returns the hand-picked weights used when no tuned profile is loaded
+func DefaultSegmentWeights() models.SegmentWeights {
+	return models.SegmentWeights{
+		Default: models.HybridWeights{
+			UserFrequency:    0.4,
+			UserCoOrders:     0.3,
+			GlobalCoOrders:   0.2,
+			TimeBasedTrend:   0.1,
+			PriceSensitivity: 0.1,
+			Ratings:          0.1,
+		},
+		NewUser: models.HybridWeights{
+			UserFrequency:    0.1,
+			UserCoOrders:     0.1,
+			GlobalCoOrders:   0.5,
+			TimeBasedTrend:   0.3,
+			PriceSensitivity: 0.0,
+			Ratings:          0.1,
+		},
+		ExperiencedUser: models.HybridWeights{
+			UserFrequency:    0.5,
+			UserCoOrders:     0.3,
+			GlobalCoOrders:   0.1,
+			TimeBasedTrend:   0.1,
+			PriceSensitivity: 0.2,
+			Ratings:          0.2,
+		},
+	}
+}
+
+// SetSegmentWeights replaces the weights used for every user segment
+func (s *RecommendationService) SetSegmentWeights(weights models.SegmentWeights) error {
+	if err := ValidateWeights(weights.Default); err != nil {
+		return fmt.Errorf("invalid default weights: %w", err)
+	}
+	if err := ValidateWeights(weights.NewUser); err != nil {
+		return fmt.Errorf("invalid new user weights: %w", err)
+	}
+	if err := ValidateWeights(weights.ExperiencedUser); err != nil {
+		return fmt.Errorf("invalid experienced user weights: %w", err)
+	}
+
+	s.weightsMu.Lock()
+	defer s.weightsMu.Unlock()
+	s.segmentWeights = weights
+	return nil
+}
+
+// GetSegmentWeights returns the weights currently used for every user segment
+func (s *RecommendationService) GetSegmentWeights() models.SegmentWeights {
+	s.weightsMu.RLock()
+	defer s.weightsMu.RUnlock()
+	return s.segmentWeights
+}
+
+// ValidateWeights checks that no weight is negative and at least one is positive
+func ValidateWeights(w models.HybridWeights) error {
+	values := []float64{w.UserFrequency, w.UserCoOrders, w.GlobalCoOrders, w.TimeBasedTrend, w.PriceSensitivity, w.Ratings}
+
+	total := 0.0
+	for _, v := range values {
+		if v < 0 {
+			return fmt.Errorf("%w: weights must not be negative", ErrInvalidWeights)
+		}
+		total += v
+	}
+	if total == 0 {
+		return fmt.Errorf("%w: at least one weight must be positive", ErrInvalidWeights)
+	}
+	return nil
+}
+
+// LoadWeightsFile reads a weights profile written by the tuner
+func LoadWeightsFile(path string) (WeightsFile, error) {
+	var file WeightsFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("failed to read weights file %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("failed to parse weights file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// SaveWeightsFile writes a weights profile, creating parent directories as needed
+func SaveWeightsFile(path string, file WeightsFile) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode weights file: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write weights file %s: %w", path, err)
+	}
+
+	return nil
+}
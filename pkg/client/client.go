@@ -0,0 +1,149 @@
+// Package client is a typed Go client for the restaurant recommendation API. The
+// methods in operations.go are generated from the same operation table as the
+// server's OpenAPI document, so they cannot drift from the routes.
+package client
+
+//go:generate go run ../../cmd/clientgen -out operations.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client calls the API at one base URL
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	adminToken string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests through the given HTTP client instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAdminToken sets the bearer token sent with /api/v1/admin requests
+func WithAdminToken(token string) Option {
+	return func(c *Client) {
+		c.adminToken = token
+	}
+}
+
+// New creates a client for the API served at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// RequestOption configures a single request
+type RequestOption func(*http.Request)
+
+// WithSessionID sends the caller's session, which keeps experiment variants stable for
+// anonymous callers
+func WithSessionID(sessionID string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("X-Session-ID", sessionID)
+	}
+}
+
+// WithRequestID sends a request ID the server echoes back and attaches to recommendations
+func WithRequestID(requestID string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("X-Request-ID", requestID)
+	}
+}
+
+// FieldError is one rejected request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error response from the API
+type Error struct {
+	StatusCode int          `json:"-"`
+	Code       string       `json:"code"`
+	Message    string       `json:"message"`
+	Details    []FieldError `json:"details,omitempty"`
+	RequestID  string       `json:"request_id,omitempty"`
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("api error %d %s: %s", e.StatusCode, e.Code, e.Message)
+	for _, field := range e.Details {
+		msg += fmt.Sprintf("; %s %s", field.Field, field.Message)
+	}
+	return msg
+}
+
+// do sends one request, encoding body as JSON when it is not nil, and decodes a
+// successful response into out when it is not nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts []RequestOption) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.adminToken != "" && strings.HasPrefix(path, "/api/v1/admin/") {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,1352 @@
+// Code generated by cmd/clientgen from handlers.Operations. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/yishak-cs/Neo4j_DB/internal/models"
+)
+
+// Types shared with the server's models
+type (
+	Basket               = models.Basket
+	Bundle               = models.Bundle
+	Category             = models.Category
+	ErasureReport        = models.ErasureReport
+	Experiment           = models.Experiment
+	ExperimentAssignment = models.ExperimentAssignment
+	HybridWeights        = models.HybridWeights
+	Item                 = models.Item
+	MenuItem             = models.MenuItem
+	Order                = models.Order
+	OrderChange          = models.OrderChange
+	Rating               = models.Rating
+	RatingSummary        = models.RatingSummary
+	Recommendation       = models.Recommendation
+	StrategyStats        = models.StrategyStats
+	Suppression          = models.Suppression
+	User                 = models.User
+	UserProfile          = models.UserProfile
+	WeightProfile        = models.WeightProfile
+)
+
+// AmendOrderRequest is the body of AmendOrder
+type AmendOrderRequest struct {
+	Items  []AmendedLine `json:"items"`
+	Reason string        `json:"reason"`
+}
+
+// AmendedLine mirrors handlers.amendedLine
+type AmendedLine struct {
+	ItemID   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+// AssignWeightProfileRequest is the body of AssignWeightProfile
+type AssignWeightProfileRequest struct {
+	Profile string `json:"profile"`
+}
+
+// BundleResponse mirrors handlers.bundleResponse
+type BundleResponse struct {
+	RequestID   string                `json:"request_id"`
+	Strategy    string                `json:"strategy"`
+	Description string                `json:"description"`
+	ItemInCart  *int                  `json:"item_in_cart,omitempty"`
+	Experiment  *ExperimentAssignment `json:"experiment,omitempty"`
+	UserID      int                   `json:"user_id"`
+	PriceFilter PriceFilter           `json:"price_filter"`
+	Size        int                   `json:"size"`
+	Bundles     []Bundle              `json:"bundles"`
+}
+
+// CancelOrderParams holds the optional query parameters of CancelOrder; nil fields are left to the server's defaults
+type CancelOrderParams struct {
+	Reason *string
+}
+
+func (p *CancelOrderParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Reason != nil {
+		q.Set("reason", fmt.Sprint(*p.Reason))
+	}
+	return q
+}
+
+// CategoryListResponse mirrors handlers.categoryListResponse
+type CategoryListResponse struct {
+	Categories []Category `json:"categories"`
+	Count      int        `json:"count"`
+}
+
+// CheckDerivedGraphParams holds the optional query parameters of CheckDerivedGraph; nil fields are left to the server's defaults
+type CheckDerivedGraphParams struct {
+	Limit     *int
+	BatchSize *int
+}
+
+func (p *CheckDerivedGraphParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Limit != nil {
+		q.Set("limit", fmt.Sprint(*p.Limit))
+	}
+	if p.BatchSize != nil {
+		q.Set("batchSize", fmt.Sprint(*p.BatchSize))
+	}
+	return q
+}
+
+// CreateCategoryRequest is the body of CreateCategory
+type CreateCategoryRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CreateExperimentRequest is the body of CreateExperiment
+type CreateExperimentRequest struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Variants    []VariantInput `json:"variants"`
+}
+
+// CreateMenuItemRequest is the body of CreateMenuItem
+type CreateMenuItemRequest struct {
+	Name        string  `json:"name"`
+	Price       float64 `json:"price"`
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+	Available   *bool   `json:"available,omitempty"`
+}
+
+// CreateSuppressionRequest is the body of CreateSuppression
+type CreateSuppressionRequest struct {
+	ItemID   *int   `json:"item_id,omitempty"`
+	Category string `json:"category"`
+}
+
+// CreateUserRequest is the body of CreateUser
+type CreateUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// CreateWeightProfileRequest is the body of CreateWeightProfile
+type CreateWeightProfileRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Weights     WeightsInput `json:"weights"`
+}
+
+// Discrepancy mirrors database.Discrepancy
+type Discrepancy struct {
+	Relationship string `json:"relationship"`
+	Kind         string `json:"kind"`
+	From         int    `json:"from"`
+	To           int    `json:"to"`
+	Expected     int64  `json:"expected"`
+	Stored       int64  `json:"stored"`
+}
+
+// DiversityOptions mirrors services.DiversityOptions
+type DiversityOptions struct {
+	Diversity      float64 `json:"diversity"`
+	MaxPerCategory int     `json:"max_per_category"`
+}
+
+// EventInput mirrors handlers.eventInput
+type EventInput struct {
+	RequestID  string    `json:"request_id"`
+	Type       string    `json:"type"`
+	UserID     int       `json:"user_id"`
+	ItemID     int       `json:"item_id"`
+	Strategy   string    `json:"strategy"`
+	Position   int       `json:"position"`
+	Experiment string    `json:"experiment"`
+	Variant    string    `json:"variant"`
+	At         time.Time `json:"at"`
+}
+
+// EventStatsResponse mirrors handlers.eventStatsResponse
+type EventStatsResponse struct {
+	Days       int             `json:"days"`
+	Since      time.Time       `json:"since"`
+	Strategies []StrategyStats `json:"strategies"`
+}
+
+// EventsRecordedResponse mirrors handlers.eventsRecordedResponse
+type EventsRecordedResponse struct {
+	Recorded int `json:"recorded"`
+	Ignored  int `json:"ignored"`
+}
+
+// ExperimentExposuresResponse mirrors handlers.experimentExposuresResponse
+type ExperimentExposuresResponse struct {
+	Experiment string           `json:"experiment"`
+	Active     bool             `json:"active"`
+	Exposures  map[string]int64 `json:"exposures"`
+	Total      int64            `json:"total"`
+}
+
+// ExperimentListResponse mirrors handlers.experimentListResponse
+type ExperimentListResponse struct {
+	Experiments []Experiment `json:"experiments"`
+	Count       int          `json:"count"`
+}
+
+// ExperimentStateResponse mirrors handlers.experimentStateResponse
+type ExperimentStateResponse struct {
+	Experiment string `json:"experiment"`
+	Active     bool   `json:"active"`
+}
+
+// GetBundleRecommendationsParams holds the optional query parameters of GetBundleRecommendations; nil fields are left to the server's defaults
+type GetBundleRecommendationsParams struct {
+	ItemInCart *int
+	Size       *int
+	Limit      *int
+	MinPrice   *float64
+	MaxPrice   *float64
+	Budget     *float64
+}
+
+func (p *GetBundleRecommendationsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.ItemInCart != nil {
+		q.Set("itemInCart", fmt.Sprint(*p.ItemInCart))
+	}
+	if p.Size != nil {
+		q.Set("size", fmt.Sprint(*p.Size))
+	}
+	if p.Limit != nil {
+		q.Set("limit", fmt.Sprint(*p.Limit))
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetEventStatsParams holds the optional query parameters of GetEventStats; nil fields are left to the server's defaults
+type GetEventStatsParams struct {
+	Days *int
+}
+
+func (p *GetEventStatsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Days != nil {
+		q.Set("days", fmt.Sprint(*p.Days))
+	}
+	return q
+}
+
+// GetGlobalCoOrderedItemsParams holds the optional query parameters of GetGlobalCoOrderedItems; nil fields are left to the server's defaults
+type GetGlobalCoOrderedItemsParams struct {
+	UserID   *int
+	MinPrice *float64
+	MaxPrice *float64
+	Budget   *float64
+}
+
+func (p *GetGlobalCoOrderedItemsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.UserID != nil {
+		q.Set("userId", fmt.Sprint(*p.UserID))
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetHybridRecommendationsParams holds the optional query parameters of GetHybridRecommendations; nil fields are left to the server's defaults
+type GetHybridRecommendationsParams struct {
+	ItemInCart       *int
+	Profile          *string
+	ProfileVersion   *int
+	UserFreq         *float64
+	UserCoOrders     *float64
+	GlobalCoOrders   *float64
+	TimeTrend        *float64
+	PriceSensitivity *float64
+	Ratings          *float64
+	Diversity        *float64
+	MaxPerCategory   *int
+	Mode             *string
+	MixRatio         *float64
+	MinPrice         *float64
+	MaxPrice         *float64
+	Budget           *float64
+}
+
+func (p *GetHybridRecommendationsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.ItemInCart != nil {
+		q.Set("itemInCart", fmt.Sprint(*p.ItemInCart))
+	}
+	if p.Profile != nil {
+		q.Set("profile", fmt.Sprint(*p.Profile))
+	}
+	if p.ProfileVersion != nil {
+		q.Set("profileVersion", fmt.Sprint(*p.ProfileVersion))
+	}
+	if p.UserFreq != nil {
+		q.Set("userFreq", fmt.Sprint(*p.UserFreq))
+	}
+	if p.UserCoOrders != nil {
+		q.Set("userCoOrders", fmt.Sprint(*p.UserCoOrders))
+	}
+	if p.GlobalCoOrders != nil {
+		q.Set("globalCoOrders", fmt.Sprint(*p.GlobalCoOrders))
+	}
+	if p.TimeTrend != nil {
+		q.Set("timeTrend", fmt.Sprint(*p.TimeTrend))
+	}
+	if p.PriceSensitivity != nil {
+		q.Set("priceSensitivity", fmt.Sprint(*p.PriceSensitivity))
+	}
+	if p.Ratings != nil {
+		q.Set("ratings", fmt.Sprint(*p.Ratings))
+	}
+	if p.Diversity != nil {
+		q.Set("diversity", fmt.Sprint(*p.Diversity))
+	}
+	if p.MaxPerCategory != nil {
+		q.Set("maxPerCategory", fmt.Sprint(*p.MaxPerCategory))
+	}
+	if p.Mode != nil {
+		q.Set("mode", fmt.Sprint(*p.Mode))
+	}
+	if p.MixRatio != nil {
+		q.Set("mixRatio", fmt.Sprint(*p.MixRatio))
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetRatingBasedItemsParams holds the optional query parameters of GetRatingBasedItems; nil fields are left to the server's defaults
+type GetRatingBasedItemsParams struct {
+	MinPrice *float64
+	MaxPrice *float64
+	Budget   *float64
+}
+
+func (p *GetRatingBasedItemsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetReorderSuggestionsParams holds the optional query parameters of GetReorderSuggestions; nil fields are left to the server's defaults
+type GetReorderSuggestionsParams struct {
+	Limit    *int
+	MinPrice *float64
+	MaxPrice *float64
+	Budget   *float64
+}
+
+func (p *GetReorderSuggestionsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Limit != nil {
+		q.Set("limit", fmt.Sprint(*p.Limit))
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetTrendingItemsParams holds the optional query parameters of GetTrendingItems; nil fields are left to the server's defaults
+type GetTrendingItemsParams struct {
+	Days     *int
+	UserID   *int
+	MinPrice *float64
+	MaxPrice *float64
+	Budget   *float64
+}
+
+func (p *GetTrendingItemsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Days != nil {
+		q.Set("days", fmt.Sprint(*p.Days))
+	}
+	if p.UserID != nil {
+		q.Set("userId", fmt.Sprint(*p.UserID))
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetUserCoOrderedItemsParams holds the optional query parameters of GetUserCoOrderedItems; nil fields are left to the server's defaults
+type GetUserCoOrderedItemsParams struct {
+	MinPrice *float64
+	MaxPrice *float64
+	Budget   *float64
+}
+
+func (p *GetUserCoOrderedItemsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetUserFrequentItemsParams holds the optional query parameters of GetUserFrequentItems; nil fields are left to the server's defaults
+type GetUserFrequentItemsParams struct {
+	MinPrice *float64
+	MaxPrice *float64
+	Budget   *float64
+}
+
+func (p *GetUserFrequentItemsParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.MinPrice != nil {
+		q.Set("minPrice", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		q.Set("maxPrice", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.Budget != nil {
+		q.Set("budget", fmt.Sprint(*p.Budget))
+	}
+	return q
+}
+
+// GetUserOrdersParams holds the optional query parameters of GetUserOrders; nil fields are left to the server's defaults
+type GetUserOrdersParams struct {
+	Page     *int
+	PageSize *int
+}
+
+func (p *GetUserOrdersParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Page != nil {
+		q.Set("page", fmt.Sprint(*p.Page))
+	}
+	if p.PageSize != nil {
+		q.Set("pageSize", fmt.Sprint(*p.PageSize))
+	}
+	return q
+}
+
+// GetWeightProfileParams holds the optional query parameters of GetWeightProfile; nil fields are left to the server's defaults
+type GetWeightProfileParams struct {
+	Version *int
+}
+
+func (p *GetWeightProfileParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Version != nil {
+		q.Set("version", fmt.Sprint(*p.Version))
+	}
+	return q
+}
+
+// GraphCheckResponse mirrors handlers.graphCheckResponse
+type GraphCheckResponse struct {
+	Report             GraphReport `json:"report"`
+	TotalDiscrepancies int         `json:"total_discrepancies"`
+}
+
+// GraphQLError mirrors handlers.graphQLError
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Locations  []GraphQLLocation      `json:"locations,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLLocation mirrors handlers.graphQLLocation
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLResponse mirrors handlers.graphQLResponse
+type GraphQLResponse struct {
+	Data   interface{}    `json:"data,omitempty"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// GraphReport mirrors database.GraphReport
+type GraphReport struct {
+	CheckedAt          time.Time           `json:"checked_at"`
+	Consistent         bool                `json:"consistent"`
+	PendingOrders      int                 `json:"pending_orders"`
+	Checks             []RelationshipCheck `json:"checks"`
+	Discrepancies      []Discrepancy       `json:"discrepancies"`
+	Repaired           int                 `json:"repaired"`
+	PrunedSuppressions int                 `json:"pruned_suppressions"`
+}
+
+// HealthResponse mirrors handlers.healthResponse
+type HealthResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// HybridResponse mirrors handlers.hybridResponse
+type HybridResponse struct {
+	RequestID       string                `json:"request_id"`
+	Strategy        string                `json:"strategy"`
+	Description     string                `json:"description"`
+	ItemInCart      *int                  `json:"item_in_cart,omitempty"`
+	Experiment      *ExperimentAssignment `json:"experiment,omitempty"`
+	UserID          int                   `json:"user_id"`
+	Segment         string                `json:"segment"`
+	Profile         *WeightProfile        `json:"profile,omitempty"`
+	Weights         HybridWeights         `json:"weights"`
+	Diversity       DiversityOptions      `json:"diversity"`
+	Mode            string                `json:"mode"`
+	MixRatio        float64               `json:"mix_ratio"`
+	PriceFilter     PriceFilter           `json:"price_filter"`
+	Recommendations []Recommendation      `json:"recommendations"`
+}
+
+// ItemAvailabilityResponse mirrors handlers.itemAvailabilityResponse
+type ItemAvailabilityResponse struct {
+	ItemID    int  `json:"item_id"`
+	Available bool `json:"available"`
+}
+
+// ItemListResponse mirrors handlers.itemListResponse
+type ItemListResponse struct {
+	Category string `json:"category,omitempty"`
+	Items    []Item `json:"items"`
+	Count    int    `json:"count"`
+}
+
+// ItemRatingsResponse mirrors handlers.itemRatingsResponse
+type ItemRatingsResponse struct {
+	ItemID  int            `json:"item_id"`
+	Summary *RatingSummary `json:"summary,omitempty"`
+	Ratings []Rating       `json:"ratings"`
+	Count   int            `json:"count"`
+}
+
+// MenuItemListResponse mirrors handlers.menuItemListResponse
+type MenuItemListResponse struct {
+	Items []MenuItem `json:"items"`
+	Count int        `json:"count"`
+}
+
+// OrderChangesResponse mirrors handlers.orderChangesResponse
+type OrderChangesResponse struct {
+	OrderID int           `json:"order_id"`
+	Changes []OrderChange `json:"changes"`
+	Count   int           `json:"count"`
+}
+
+// OrderLine mirrors handlers.orderLine
+type OrderLine struct {
+	ItemID   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+// OrderPageResponse mirrors handlers.orderPageResponse
+type OrderPageResponse struct {
+	UserID   int     `json:"user_id"`
+	Orders   []Order `json:"orders"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"page_size"`
+	Total    int     `json:"total"`
+	HasMore  bool    `json:"has_more"`
+}
+
+// PlaceOrderRequest is the body of PlaceOrder
+type PlaceOrderRequest struct {
+	UserID int         `json:"user_id"`
+	Items  []OrderLine `json:"items"`
+}
+
+// PriceFilter mirrors services.PriceFilter
+type PriceFilter struct {
+	MinPrice float64 `json:"min_price,omitempty"`
+	MaxPrice float64 `json:"max_price,omitempty"`
+	Budget   float64 `json:"budget,omitempty"`
+}
+
+// QueryGraphQLRequest is the body of QueryGraphQL
+type QueryGraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// QueueStats mirrors database.QueueStats
+type QueueStats struct {
+	Depth          int64   `json:"depth"`
+	Enqueued       int64   `json:"enqueued"`
+	Applied        int64   `json:"applied"`
+	Coalesced      int64   `json:"coalesced"`
+	Duplicates     int64   `json:"duplicates"`
+	Failed         int64   `json:"failed"`
+	DeadLettered   int64   `json:"dead_lettered"`
+	Retries        int64   `json:"retries"`
+	Batches        int64   `json:"batches"`
+	LastLagSeconds float64 `json:"last_lag_seconds"`
+	MaxLagSeconds  float64 `json:"max_lag_seconds"`
+}
+
+// RateItemRequest is the body of RateItem
+type RateItemRequest struct {
+	UserID  int    `json:"user_id"`
+	Stars   int    `json:"stars"`
+	Comment string `json:"comment"`
+}
+
+// RecommendationListResponse mirrors handlers.recommendationListResponse
+type RecommendationListResponse struct {
+	RequestID       string                `json:"request_id"`
+	Strategy        string                `json:"strategy"`
+	Description     string                `json:"description"`
+	ItemInCart      *int                  `json:"item_in_cart,omitempty"`
+	Experiment      *ExperimentAssignment `json:"experiment,omitempty"`
+	UserID          *int                  `json:"user_id,omitempty"`
+	ItemID          *int                  `json:"item_id,omitempty"`
+	Days            int                   `json:"days,omitempty"`
+	PriceFilter     PriceFilter           `json:"price_filter"`
+	Recommendations []Recommendation      `json:"recommendations"`
+}
+
+// RecordEventsRequest is the body of RecordEvents
+type RecordEventsRequest struct {
+	Events []EventInput `json:"events"`
+}
+
+// RelationshipCheck mirrors database.RelationshipCheck
+type RelationshipCheck struct {
+	Relationship string `json:"relationship"`
+	Expected     int    `json:"expected"`
+	Stored       int    `json:"stored"`
+	Missing      int    `json:"missing"`
+	Extra        int    `json:"extra"`
+	Mismatched   int    `json:"mismatched"`
+}
+
+// ReorderResponse mirrors handlers.reorderResponse
+type ReorderResponse struct {
+	RequestID        string                `json:"request_id"`
+	Strategy         string                `json:"strategy"`
+	Description      string                `json:"description"`
+	ItemInCart       *int                  `json:"item_in_cart,omitempty"`
+	Experiment       *ExperimentAssignment `json:"experiment,omitempty"`
+	UserID           int                   `json:"user_id"`
+	PriceFilter      PriceFilter           `json:"price_filter"`
+	RecentBaskets    []Basket              `json:"recent_baskets"`
+	RecurringBaskets []Basket              `json:"recurring_baskets"`
+}
+
+// RepairDerivedGraphParams holds the optional query parameters of RepairDerivedGraph; nil fields are left to the server's defaults
+type RepairDerivedGraphParams struct {
+	Limit     *int
+	BatchSize *int
+}
+
+func (p *RepairDerivedGraphParams) query() url.Values {
+	q := url.Values{}
+	if p == nil {
+		return q
+	}
+	if p.Limit != nil {
+		q.Set("limit", fmt.Sprint(*p.Limit))
+	}
+	if p.BatchSize != nil {
+		q.Set("batchSize", fmt.Sprint(*p.BatchSize))
+	}
+	return q
+}
+
+// ReplaceMenuItemRequest is the body of ReplaceMenuItem
+type ReplaceMenuItemRequest struct {
+	Name        string  `json:"name"`
+	Price       float64 `json:"price"`
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+	Available   *bool   `json:"available,omitempty"`
+}
+
+// SegmentAssignmentResponse mirrors handlers.segmentAssignmentResponse
+type SegmentAssignmentResponse struct {
+	Segment string `json:"segment"`
+	Profile string `json:"profile"`
+}
+
+// SegmentAssignmentsResponse mirrors handlers.segmentAssignmentsResponse
+type SegmentAssignmentsResponse struct {
+	Assignments map[string]string `json:"assignments"`
+	Segments    []string          `json:"segments"`
+}
+
+// SuppressionListResponse mirrors handlers.suppressionListResponse
+type SuppressionListResponse struct {
+	UserID       int           `json:"user_id"`
+	Suppressions []Suppression `json:"suppressions"`
+	Count        int           `json:"count"`
+}
+
+// SuppressionResponse mirrors handlers.suppressionResponse
+type SuppressionResponse struct {
+	UserID   int    `json:"user_id"`
+	ItemID   *int   `json:"item_id,omitempty"`
+	Category string `json:"category"`
+}
+
+// UpdateCategoryRequest is the body of UpdateCategory
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// UpdateMenuItemRequest is the body of UpdateMenuItem
+type UpdateMenuItemRequest struct {
+	Name        *string  `json:"name,omitempty"`
+	Price       *float64 `json:"price,omitempty"`
+	Category    *string  `json:"category,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Available   *bool    `json:"available,omitempty"`
+}
+
+// UpdateUserRequest is the body of UpdateUser
+type UpdateUserRequest struct {
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+}
+
+// UpdateWeightProfileRequest is the body of UpdateWeightProfile
+type UpdateWeightProfileRequest struct {
+	Description string       `json:"description"`
+	Weights     WeightsInput `json:"weights"`
+}
+
+// UserListResponse mirrors handlers.userListResponse
+type UserListResponse struct {
+	Users []User `json:"users"`
+	Count int    `json:"count"`
+}
+
+// VariantInput mirrors handlers.variantInput
+type VariantInput struct {
+	Name       string   `json:"name"`
+	Allocation int      `json:"allocation"`
+	Profile    string   `json:"profile"`
+	Strategies []string `json:"strategies"`
+}
+
+// WeightProfileListResponse mirrors handlers.weightProfileListResponse
+type WeightProfileListResponse struct {
+	Profiles []WeightProfile `json:"profiles"`
+	Count    int             `json:"count"`
+}
+
+// WeightProfileVersionsResponse mirrors handlers.weightProfileVersionsResponse
+type WeightProfileVersionsResponse struct {
+	Name     string          `json:"name"`
+	Versions []WeightProfile `json:"versions"`
+	Count    int             `json:"count"`
+}
+
+// WeightsInput mirrors handlers.weightsInput
+type WeightsInput struct {
+	UserFrequency    float64 `json:"user_frequency"`
+	UserCoOrders     float64 `json:"user_co_orders"`
+	GlobalCoOrders   float64 `json:"global_co_orders"`
+	TimeBasedTrend   float64 `json:"time_based_trend"`
+	PriceSensitivity float64 `json:"price_sensitivity"`
+	Ratings          float64 `json:"ratings"`
+}
+
+// GetHealth calls GET /api/v1/health: Check service health
+func (c *Client) GetHealth(ctx context.Context, opts ...RequestOption) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/health", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAllUsers calls GET /api/v1/users: List users
+func (c *Client) GetAllUsers(ctx context.Context, opts ...RequestOption) (*UserListResponse, error) {
+	var out UserListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateUser calls POST /api/v1/users: Register a user; emails are unique
+func (c *Client) CreateUser(ctx context.Context, body CreateUserRequest, opts ...RequestOption) (*User, error) {
+	var out User
+	if err := c.do(ctx, http.MethodPost, "/api/v1/users", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserProfile calls GET /api/v1/users/:userId: Get a user with their order summary
+func (c *Client) GetUserProfile(ctx context.Context, userID int, opts ...RequestOption) (*UserProfile, error) {
+	var out UserProfile
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID)), nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateUser calls PATCH /api/v1/users/:userId: Change a user's name or email
+func (c *Client) UpdateUser(ctx context.Context, userID int, body UpdateUserRequest, opts ...RequestOption) (*User, error) {
+	var out User
+	if err := c.do(ctx, http.MethodPatch, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID)), nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserOrders calls GET /api/v1/users/:userId/orders: Get a page of a user's orders, newest first, cancelled ones included
+func (c *Client) GetUserOrders(ctx context.Context, userID int, params *GetUserOrdersParams, opts ...RequestOption) (*OrderPageResponse, error) {
+	var out OrderPageResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID))+"/orders", params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSuppressions calls GET /api/v1/users/:userId/suppressions: List a user's active "not interested" marks
+func (c *Client) GetSuppressions(ctx context.Context, userID int, opts ...RequestOption) (*SuppressionListResponse, error) {
+	var out SuppressionListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID))+"/suppressions", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateSuppression calls POST /api/v1/users/:userId/suppressions: Mark an item or a whole category "not interested"
+func (c *Client) CreateSuppression(ctx context.Context, userID int, body CreateSuppressionRequest, opts ...RequestOption) (*SuppressionResponse, error) {
+	var out SuppressionResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID))+"/suppressions", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteItemSuppression calls DELETE /api/v1/users/:userId/suppressions/items/:itemId: Undo a "not interested" mark on an item
+func (c *Client) DeleteItemSuppression(ctx context.Context, userID int, itemID int, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID))+"/suppressions/items/"+url.PathEscape(fmt.Sprint(itemID)), nil, nil, nil, opts)
+}
+
+// DeleteCategorySuppression calls DELETE /api/v1/users/:userId/suppressions/categories/:category: Undo a "not interested" mark on a category
+func (c *Client) DeleteCategorySuppression(ctx context.Context, userID int, category string, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/users/"+url.PathEscape(fmt.Sprint(userID))+"/suppressions/categories/"+url.PathEscape(category), nil, nil, nil, opts)
+}
+
+// GetAllItems calls GET /api/v1/items: List menu items with their rating summaries
+func (c *Client) GetAllItems(ctx context.Context, opts ...RequestOption) (*ItemListResponse, error) {
+	var out ItemListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/items", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetItemsByCategory calls GET /api/v1/items/category/:category: List the menu items of one category
+func (c *Client) GetItemsByCategory(ctx context.Context, category string, opts ...RequestOption) (*ItemListResponse, error) {
+	var out ItemListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/items/category/"+url.PathEscape(category), nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetItemRatings calls GET /api/v1/items/:itemId/ratings: Get an item's reviews and rating summary
+func (c *Client) GetItemRatings(ctx context.Context, itemID int, opts ...RequestOption) (*ItemRatingsResponse, error) {
+	var out ItemRatingsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/items/"+url.PathEscape(fmt.Sprint(itemID))+"/ratings", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RateItem calls POST /api/v1/items/:itemId/ratings: Rate an item, replacing the user's earlier rating
+func (c *Client) RateItem(ctx context.Context, itemID int, body RateItemRequest, opts ...RequestOption) (*Rating, error) {
+	var out Rating
+	if err := c.do(ctx, http.MethodPost, "/api/v1/items/"+url.PathEscape(fmt.Sprint(itemID))+"/ratings", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PlaceOrder calls POST /api/v1/orders: Place an order
+func (c *Client) PlaceOrder(ctx context.Context, body PlaceOrderRequest, opts ...RequestOption) (*Order, error) {
+	var out Order
+	if err := c.do(ctx, http.MethodPost, "/api/v1/orders", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AmendOrder calls PATCH /api/v1/orders/:orderId: Change item quantities of an order; quantity 0 removes an item
+func (c *Client) AmendOrder(ctx context.Context, orderID int, body AmendOrderRequest, opts ...RequestOption) (*OrderChange, error) {
+	var out OrderChange
+	if err := c.do(ctx, http.MethodPatch, "/api/v1/orders/"+url.PathEscape(fmt.Sprint(orderID)), nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelOrder calls DELETE /api/v1/orders/:orderId: Cancel an order
+func (c *Client) CancelOrder(ctx context.Context, orderID int, params *CancelOrderParams, opts ...RequestOption) (*OrderChange, error) {
+	var out OrderChange
+	if err := c.do(ctx, http.MethodDelete, "/api/v1/orders/"+url.PathEscape(fmt.Sprint(orderID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrderChanges calls GET /api/v1/orders/:orderId/changes: Get the audit trail of an order
+func (c *Client) GetOrderChanges(ctx context.Context, orderID int, opts ...RequestOption) (*OrderChangesResponse, error) {
+	var out OrderChangesResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/orders/"+url.PathEscape(fmt.Sprint(orderID))+"/changes", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserFrequentItems calls GET /api/v1/recommendations/user-frequent/:userId: Items the user orders most frequently
+func (c *Client) GetUserFrequentItems(ctx context.Context, userID int, params *GetUserFrequentItemsParams, opts ...RequestOption) (*RecommendationListResponse, error) {
+	var out RecommendationListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/user-frequent/"+url.PathEscape(fmt.Sprint(userID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserCoOrderedItems calls GET /api/v1/recommendations/user-co-orders/:userId/:itemId: Items the user frequently orders with an item
+func (c *Client) GetUserCoOrderedItems(ctx context.Context, userID int, itemID int, params *GetUserCoOrderedItemsParams, opts ...RequestOption) (*RecommendationListResponse, error) {
+	var out RecommendationListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/user-co-orders/"+url.PathEscape(fmt.Sprint(userID))+"/"+url.PathEscape(fmt.Sprint(itemID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGlobalCoOrderedItems calls GET /api/v1/recommendations/global-co-orders/:itemId: Items all customers frequently order with an item
+func (c *Client) GetGlobalCoOrderedItems(ctx context.Context, itemID int, params *GetGlobalCoOrderedItemsParams, opts ...RequestOption) (*RecommendationListResponse, error) {
+	var out RecommendationListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/global-co-orders/"+url.PathEscape(fmt.Sprint(itemID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTrendingItems calls GET /api/v1/recommendations/trending: Currently trending items
+func (c *Client) GetTrendingItems(ctx context.Context, params *GetTrendingItemsParams, opts ...RequestOption) (*RecommendationListResponse, error) {
+	var out RecommendationListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/trending", params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRatingBasedItems calls GET /api/v1/recommendations/ratings/:userId: Items rated highly by guests with similar taste
+func (c *Client) GetRatingBasedItems(ctx context.Context, userID int, params *GetRatingBasedItemsParams, opts ...RequestOption) (*RecommendationListResponse, error) {
+	var out RecommendationListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/ratings/"+url.PathEscape(fmt.Sprint(userID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHybridRecommendations calls GET /api/v1/recommendations/hybrid/:userId: Personalised recommendations blending every strategy
+func (c *Client) GetHybridRecommendations(ctx context.Context, userID int, params *GetHybridRecommendationsParams, opts ...RequestOption) (*HybridResponse, error) {
+	var out HybridResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/hybrid/"+url.PathEscape(fmt.Sprint(userID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBundleRecommendations calls GET /api/v1/recommendations/bundles/:userId: Complete-the-meal bundles
+func (c *Client) GetBundleRecommendations(ctx context.Context, userID int, params *GetBundleRecommendationsParams, opts ...RequestOption) (*BundleResponse, error) {
+	var out BundleResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/bundles/"+url.PathEscape(fmt.Sprint(userID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReorderSuggestions calls GET /api/v1/recommendations/reorder/:userId: Recent and recurring orders to place again
+func (c *Client) GetReorderSuggestions(ctx context.Context, userID int, params *GetReorderSuggestionsParams, opts ...RequestOption) (*ReorderResponse, error) {
+	var out ReorderResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/recommendations/reorder/"+url.PathEscape(fmt.Sprint(userID)), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RecordEvents calls POST /api/v1/events: Record what happened to served recommendations
+func (c *Client) RecordEvents(ctx context.Context, body RecordEventsRequest, opts ...RequestOption) (*EventsRecordedResponse, error) {
+	var out EventsRecordedResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/events", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEventStats calls GET /api/v1/events/stats: Engagement and click-through rates per strategy
+func (c *Client) GetEventStats(ctx context.Context, params *GetEventStatsParams, opts ...RequestOption) (*EventStatsResponse, error) {
+	var out EventStatsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/events/stats", params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// QueryGraphQL calls POST /api/v1/graphql: Query the menu, users and recommendations in one request
+func (c *Client) QueryGraphQL(ctx context.Context, body QueryGraphQLRequest, opts ...RequestOption) (*GraphQLResponse, error) {
+	var out GraphQLResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/graphql", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMenuItems calls GET /api/v1/admin/items: List every menu item, withdrawn ones included
+func (c *Client) ListMenuItems(ctx context.Context, opts ...RequestOption) (*MenuItemListResponse, error) {
+	var out MenuItemListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/items", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateMenuItem calls POST /api/v1/admin/items: Add an item to the menu
+func (c *Client) CreateMenuItem(ctx context.Context, body CreateMenuItemRequest, opts ...RequestOption) (*MenuItem, error) {
+	var out MenuItem
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/items", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMenuItem calls GET /api/v1/admin/items/:itemId: Get a menu item, whether or not it is available
+func (c *Client) GetMenuItem(ctx context.Context, itemID int, opts ...RequestOption) (*MenuItem, error) {
+	var out MenuItem
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/items/"+url.PathEscape(fmt.Sprint(itemID)), nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReplaceMenuItem calls PUT /api/v1/admin/items/:itemId: Replace every field of a menu item
+func (c *Client) ReplaceMenuItem(ctx context.Context, itemID int, body ReplaceMenuItemRequest, opts ...RequestOption) (*MenuItem, error) {
+	var out MenuItem
+	if err := c.do(ctx, http.MethodPut, "/api/v1/admin/items/"+url.PathEscape(fmt.Sprint(itemID)), nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateMenuItem calls PATCH /api/v1/admin/items/:itemId: Change some fields of a menu item
+func (c *Client) UpdateMenuItem(ctx context.Context, itemID int, body UpdateMenuItemRequest, opts ...RequestOption) (*MenuItem, error) {
+	var out MenuItem
+	if err := c.do(ctx, http.MethodPatch, "/api/v1/admin/items/"+url.PathEscape(fmt.Sprint(itemID)), nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WithdrawMenuItem calls DELETE /api/v1/admin/items/:itemId: Take an item off the menu, keeping its order history
+func (c *Client) WithdrawMenuItem(ctx context.Context, itemID int, opts ...RequestOption) (*ItemAvailabilityResponse, error) {
+	var out ItemAvailabilityResponse
+	if err := c.do(ctx, http.MethodDelete, "/api/v1/admin/items/"+url.PathEscape(fmt.Sprint(itemID)), nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListCategories calls GET /api/v1/admin/categories: List categories with their item counts
+func (c *Client) ListCategories(ctx context.Context, opts ...RequestOption) (*CategoryListResponse, error) {
+	var out CategoryListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/categories", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCategory calls POST /api/v1/admin/categories: Add a category
+func (c *Client) CreateCategory(ctx context.Context, body CreateCategoryRequest, opts ...RequestOption) (*Category, error) {
+	var out Category
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/categories", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateCategory calls PATCH /api/v1/admin/categories/:name: Rename a category or change its description
+func (c *Client) UpdateCategory(ctx context.Context, name string, body UpdateCategoryRequest, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodPatch, "/api/v1/admin/categories/"+url.PathEscape(name), nil, body, nil, opts)
+}
+
+// DeleteCategory calls DELETE /api/v1/admin/categories/:name: Delete a category that holds no items
+func (c *Client) DeleteCategory(ctx context.Context, name string, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/admin/categories/"+url.PathEscape(name), nil, nil, nil, opts)
+}
+
+// EraseUser calls DELETE /api/v1/admin/users/:userId: Erase a user under the configured erasure policy
+func (c *Client) EraseUser(ctx context.Context, userID int, opts ...RequestOption) (*ErasureReport, error) {
+	var out ErasureReport
+	if err := c.do(ctx, http.MethodDelete, "/api/v1/admin/users/"+url.PathEscape(fmt.Sprint(userID)), nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListWeightProfiles calls GET /api/v1/admin/weight-profiles: List the latest version of every weight profile
+func (c *Client) ListWeightProfiles(ctx context.Context, opts ...RequestOption) (*WeightProfileListResponse, error) {
+	var out WeightProfileListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/weight-profiles", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateWeightProfile calls POST /api/v1/admin/weight-profiles: Create a weight profile
+func (c *Client) CreateWeightProfile(ctx context.Context, body CreateWeightProfileRequest, opts ...RequestOption) (*WeightProfile, error) {
+	var out WeightProfile
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/weight-profiles", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWeightProfile calls GET /api/v1/admin/weight-profiles/:name: Get a weight profile, optionally at an older version
+func (c *Client) GetWeightProfile(ctx context.Context, name string, params *GetWeightProfileParams, opts ...RequestOption) (*WeightProfile, error) {
+	var out WeightProfile
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/weight-profiles/"+url.PathEscape(name), params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWeightProfileVersions calls GET /api/v1/admin/weight-profiles/:name/versions: Get every version of a weight profile
+func (c *Client) GetWeightProfileVersions(ctx context.Context, name string, opts ...RequestOption) (*WeightProfileVersionsResponse, error) {
+	var out WeightProfileVersionsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/weight-profiles/"+url.PathEscape(name)+"/versions", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateWeightProfile calls PUT /api/v1/admin/weight-profiles/:name: Publish a new version of a weight profile
+func (c *Client) UpdateWeightProfile(ctx context.Context, name string, body UpdateWeightProfileRequest, opts ...RequestOption) (*WeightProfile, error) {
+	var out WeightProfile
+	if err := c.do(ctx, http.MethodPut, "/api/v1/admin/weight-profiles/"+url.PathEscape(name), nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWeightProfile calls DELETE /api/v1/admin/weight-profiles/:name: Delete a weight profile that no segment uses
+func (c *Client) DeleteWeightProfile(ctx context.Context, name string, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/admin/weight-profiles/"+url.PathEscape(name), nil, nil, nil, opts)
+}
+
+// GetSegmentAssignments calls GET /api/v1/admin/weight-profile-assignments: Show which profile each user segment uses
+func (c *Client) GetSegmentAssignments(ctx context.Context, opts ...RequestOption) (*SegmentAssignmentsResponse, error) {
+	var out SegmentAssignmentsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/weight-profile-assignments", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssignWeightProfile calls PUT /api/v1/admin/weight-profile-assignments/:segment: Make a user segment use a profile
+func (c *Client) AssignWeightProfile(ctx context.Context, segment string, body AssignWeightProfileRequest, opts ...RequestOption) (*SegmentAssignmentResponse, error) {
+	var out SegmentAssignmentResponse
+	if err := c.do(ctx, http.MethodPut, "/api/v1/admin/weight-profile-assignments/"+url.PathEscape(segment), nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UnassignWeightProfile calls DELETE /api/v1/admin/weight-profile-assignments/:segment: Return a user segment to its built-in weights
+func (c *Client) UnassignWeightProfile(ctx context.Context, segment string, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/admin/weight-profile-assignments/"+url.PathEscape(segment), nil, nil, nil, opts)
+}
+
+// ListExperiments calls GET /api/v1/admin/experiments: List experiments with their variants
+func (c *Client) ListExperiments(ctx context.Context, opts ...RequestOption) (*ExperimentListResponse, error) {
+	var out ExperimentListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/experiments", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateExperiment calls POST /api/v1/admin/experiments: Define an experiment
+func (c *Client) CreateExperiment(ctx context.Context, body CreateExperimentRequest, opts ...RequestOption) (*Experiment, error) {
+	var out Experiment
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/experiments", nil, body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExperiment calls GET /api/v1/admin/experiments/:name: Get an experiment
+func (c *Client) GetExperiment(ctx context.Context, name string, opts ...RequestOption) (*Experiment, error) {
+	var out Experiment
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/experiments/"+url.PathEscape(name), nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExperimentExposures calls GET /api/v1/admin/experiments/:name/exposures: Count how often each variant was served
+func (c *Client) GetExperimentExposures(ctx context.Context, name string, opts ...RequestOption) (*ExperimentExposuresResponse, error) {
+	var out ExperimentExposuresResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/experiments/"+url.PathEscape(name)+"/exposures", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StartExperiment calls POST /api/v1/admin/experiments/:name/start: Run an experiment, stopping any other
+func (c *Client) StartExperiment(ctx context.Context, name string, opts ...RequestOption) (*ExperimentStateResponse, error) {
+	var out ExperimentStateResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/experiments/"+url.PathEscape(name)+"/start", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StopExperiment calls POST /api/v1/admin/experiments/:name/stop: Stop an experiment
+func (c *Client) StopExperiment(ctx context.Context, name string, opts ...RequestOption) (*ExperimentStateResponse, error) {
+	var out ExperimentStateResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/experiments/"+url.PathEscape(name)+"/stop", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteExperiment calls DELETE /api/v1/admin/experiments/:name: Delete an experiment
+func (c *Client) DeleteExperiment(ctx context.Context, name string, opts ...RequestOption) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/admin/experiments/"+url.PathEscape(name), nil, nil, nil, opts)
+}
+
+// GetUpdateQueueStats calls GET /api/v1/admin/update-queue: Depth and lag of the derived-relationship update queue
+func (c *Client) GetUpdateQueueStats(ctx context.Context, opts ...RequestOption) (*QueueStats, error) {
+	var out QueueStats
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/update-queue", nil, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CheckDerivedGraph calls GET /api/v1/admin/graph-check: Diff the derived relationships against the raw orders
+func (c *Client) CheckDerivedGraph(ctx context.Context, params *CheckDerivedGraphParams, opts ...RequestOption) (*GraphCheckResponse, error) {
+	var out GraphCheckResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/graph-check", params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RepairDerivedGraph calls POST /api/v1/admin/graph-check/repair: Diff the derived relationships and rewrite the ones that drifted
+func (c *Client) RepairDerivedGraph(ctx context.Context, params *RepairDerivedGraphParams, opts ...RequestOption) (*GraphCheckResponse, error) {
+	var out GraphCheckResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/admin/graph-check/repair", params.query(), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
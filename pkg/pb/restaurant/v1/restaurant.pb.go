@@ -0,0 +1,2469 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: restaurant/v1/restaurant.proto
+
+package restaurantv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Item struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price       float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Category    string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Unset when the item has no ratings
+	Ratings       *RatingSummary `protobuf:"bytes,6,opt,name=ratings,proto3" json:"ratings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Item) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetRatings() *RatingSummary {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+type RatingSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Mean            float64                `protobuf:"fixed64,1,opt,name=mean,proto3" json:"mean,omitempty"`
+	Count           int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	BayesianAverage float64                `protobuf:"fixed64,3,opt,name=bayesian_average,json=bayesianAverage,proto3" json:"bayesian_average,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RatingSummary) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetBayesianAverage() float64 {
+	if x != nil {
+		return x.BayesianAverage
+	}
+	return 0
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type UserStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderCount        int64                  `protobuf:"varint,1,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	LifetimeSpend     float64                `protobuf:"fixed64,2,opt,name=lifetime_spend,json=lifetimeSpend,proto3" json:"lifetime_spend,omitempty"`
+	FavouriteCategory string                 `protobuf:"bytes,3,opt,name=favourite_category,json=favouriteCategory,proto3" json:"favourite_category,omitempty"`
+	// Unset when the guest has never ordered
+	LastOrderAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_order_at,json=lastOrderAt,proto3" json:"last_order_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStats) Reset() {
+	*x = UserStats{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStats) ProtoMessage() {}
+
+func (x *UserStats) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStats.ProtoReflect.Descriptor instead.
+func (*UserStats) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserStats) GetOrderCount() int64 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+func (x *UserStats) GetLifetimeSpend() float64 {
+	if x != nil {
+		return x.LifetimeSpend
+	}
+	return 0
+}
+
+func (x *UserStats) GetFavouriteCategory() string {
+	if x != nil {
+		return x.FavouriteCategory
+	}
+	return ""
+}
+
+func (x *UserStats) GetLastOrderAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOrderAt
+	}
+	return nil
+}
+
+type UserProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Stats         *UserStats             `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserProfile) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UserProfile) GetStats() *UserStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type Recommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Explanation   string                 `protobuf:"bytes,3,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	Strategy      string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Recommendation) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Recommendation) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *Recommendation) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+// PriceFilter restricts recommendations to a price range; zero fields are unset
+type PriceFilter struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MinPrice float64                `protobuf:"fixed64,1,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice float64                `protobuf:"fixed64,2,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// Caps the cart total: the item price plus the cart items must fit within it
+	Budget        float64 `protobuf:"fixed64,3,opt,name=budget,proto3" json:"budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceFilter) Reset() {
+	*x = PriceFilter{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceFilter) ProtoMessage() {}
+
+func (x *PriceFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceFilter.ProtoReflect.Descriptor instead.
+func (*PriceFilter) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PriceFilter) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *PriceFilter) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *PriceFilter) GetBudget() float64 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+type HybridWeights struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserFrequency    float64                `protobuf:"fixed64,1,opt,name=user_frequency,json=userFrequency,proto3" json:"user_frequency,omitempty"`
+	UserCoOrders     float64                `protobuf:"fixed64,2,opt,name=user_co_orders,json=userCoOrders,proto3" json:"user_co_orders,omitempty"`
+	GlobalCoOrders   float64                `protobuf:"fixed64,3,opt,name=global_co_orders,json=globalCoOrders,proto3" json:"global_co_orders,omitempty"`
+	TimeBasedTrend   float64                `protobuf:"fixed64,4,opt,name=time_based_trend,json=timeBasedTrend,proto3" json:"time_based_trend,omitempty"`
+	PriceSensitivity float64                `protobuf:"fixed64,5,opt,name=price_sensitivity,json=priceSensitivity,proto3" json:"price_sensitivity,omitempty"`
+	Ratings          float64                `protobuf:"fixed64,6,opt,name=ratings,proto3" json:"ratings,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *HybridWeights) Reset() {
+	*x = HybridWeights{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HybridWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HybridWeights) ProtoMessage() {}
+
+func (x *HybridWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HybridWeights.ProtoReflect.Descriptor instead.
+func (*HybridWeights) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HybridWeights) GetUserFrequency() float64 {
+	if x != nil {
+		return x.UserFrequency
+	}
+	return 0
+}
+
+func (x *HybridWeights) GetUserCoOrders() float64 {
+	if x != nil {
+		return x.UserCoOrders
+	}
+	return 0
+}
+
+func (x *HybridWeights) GetGlobalCoOrders() float64 {
+	if x != nil {
+		return x.GlobalCoOrders
+	}
+	return 0
+}
+
+func (x *HybridWeights) GetTimeBasedTrend() float64 {
+	if x != nil {
+		return x.TimeBasedTrend
+	}
+	return 0
+}
+
+func (x *HybridWeights) GetPriceSensitivity() float64 {
+	if x != nil {
+		return x.PriceSensitivity
+	}
+	return 0
+}
+
+func (x *HybridWeights) GetRatings() float64 {
+	if x != nil {
+		return x.Ratings
+	}
+	return 0
+}
+
+type ListItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lists one category when set
+	Category      string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsRequest) Reset() {
+	*x = ListItemsRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsRequest) ProtoMessage() {}
+
+func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListItemsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ListItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsResponse) Reset() {
+	*x = ListItemsResponse{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsResponse) ProtoMessage() {}
+
+func (x *ListItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListItemsResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        int64                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetItemRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{11}
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetUserProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserProfileRequest) Reset() {
+	*x = GetUserProfileRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserProfileRequest) ProtoMessage() {}
+
+func (x *GetUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetUserProfileRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserProfileRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type UserRecommendationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Price         *PriceFilter           `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRecommendationsRequest) Reset() {
+	*x = UserRecommendationsRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRecommendationsRequest) ProtoMessage() {}
+
+func (x *UserRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*UserRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UserRecommendationsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserRecommendationsRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type UserCoOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        int64                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Price         *PriceFilter           `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCoOrdersRequest) Reset() {
+	*x = UserCoOrdersRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCoOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCoOrdersRequest) ProtoMessage() {}
+
+func (x *UserCoOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCoOrdersRequest.ProtoReflect.Descriptor instead.
+func (*UserCoOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UserCoOrdersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserCoOrdersRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *UserCoOrdersRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type GlobalCoOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ItemId int64                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// Hides what this guest marked "not interested" when set
+	UserId        *int64       `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Price         *PriceFilter `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlobalCoOrdersRequest) Reset() {
+	*x = GlobalCoOrdersRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalCoOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalCoOrdersRequest) ProtoMessage() {}
+
+func (x *GlobalCoOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalCoOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GlobalCoOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GlobalCoOrdersRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *GlobalCoOrdersRequest) GetUserId() int64 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *GlobalCoOrdersRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type TrendingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1-365, default 7
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// Hides what this guest marked "not interested" when set
+	UserId        *int64       `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Price         *PriceFilter `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingRequest) Reset() {
+	*x = TrendingRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingRequest) ProtoMessage() {}
+
+func (x *TrendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingRequest.ProtoReflect.Descriptor instead.
+func (*TrendingRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TrendingRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *TrendingRequest) GetUserId() int64 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *TrendingRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+// RecommendationsResponse is the answer of every single-strategy recommendation RPC
+type RecommendationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Quote in feedback events
+	RequestId   string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Strategy    string `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The item the results are based on, if any
+	ItemInCart      *int64            `protobuf:"varint,4,opt,name=item_in_cart,json=itemInCart,proto3,oneof" json:"item_in_cart,omitempty"`
+	Recommendations []*Recommendation `protobuf:"bytes,5,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecommendationsResponse) Reset() {
+	*x = RecommendationsResponse{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendationsResponse) ProtoMessage() {}
+
+func (x *RecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*RecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecommendationsResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RecommendationsResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *RecommendationsResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RecommendationsResponse) GetItemInCart() int64 {
+	if x != nil && x.ItemInCart != nil {
+		return *x.ItemInCart
+	}
+	return 0
+}
+
+func (x *RecommendationsResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type HybridRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemInCart *int64                 `protobuf:"varint,2,opt,name=item_in_cart,json=itemInCart,proto3,oneof" json:"item_in_cart,omitempty"`
+	// A weight profile to use instead of the guest's segment weights
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Version of the profile; 0 is the latest
+	ProfileVersion int32 `protobuf:"varint,4,opt,name=profile_version,json=profileVersion,proto3" json:"profile_version,omitempty"`
+	// Individual weight overrides; setting any keeps the guest out of experiments
+	UserFrequency    *float64 `protobuf:"fixed64,5,opt,name=user_frequency,json=userFrequency,proto3,oneof" json:"user_frequency,omitempty"`
+	UserCoOrders     *float64 `protobuf:"fixed64,6,opt,name=user_co_orders,json=userCoOrders,proto3,oneof" json:"user_co_orders,omitempty"`
+	GlobalCoOrders   *float64 `protobuf:"fixed64,7,opt,name=global_co_orders,json=globalCoOrders,proto3,oneof" json:"global_co_orders,omitempty"`
+	TimeBasedTrend   *float64 `protobuf:"fixed64,8,opt,name=time_based_trend,json=timeBasedTrend,proto3,oneof" json:"time_based_trend,omitempty"`
+	PriceSensitivity *float64 `protobuf:"fixed64,9,opt,name=price_sensitivity,json=priceSensitivity,proto3,oneof" json:"price_sensitivity,omitempty"`
+	Ratings          *float64 `protobuf:"fixed64,10,opt,name=ratings,proto3,oneof" json:"ratings,omitempty"`
+	// 0-1: 0 keeps the ranking, 1 maximises variety
+	Diversity float64 `protobuf:"fixed64,11,opt,name=diversity,proto3" json:"diversity,omitempty"`
+	// Caps items per category; 0 is no cap
+	MaxPerCategory int32 `protobuf:"varint,12,opt,name=max_per_category,json=maxPerCategory,proto3" json:"max_per_category,omitempty"`
+	// "reorder", "explore" or "mixed"; empty keeps every item
+	Mode string `protobuf:"bytes,13,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Share of usuals in mixed mode, 0-1, default 0.5
+	MixRatio *float64     `protobuf:"fixed64,14,opt,name=mix_ratio,json=mixRatio,proto3,oneof" json:"mix_ratio,omitempty"`
+	Price    *PriceFilter `protobuf:"bytes,15,opt,name=price,proto3" json:"price,omitempty"`
+	// Keeps experiment variants stable for the session; the user ID is used when empty
+	SessionId     string `protobuf:"bytes,16,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HybridRequest) Reset() {
+	*x = HybridRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HybridRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HybridRequest) ProtoMessage() {}
+
+func (x *HybridRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HybridRequest.ProtoReflect.Descriptor instead.
+func (*HybridRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HybridRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetItemInCart() int64 {
+	if x != nil && x.ItemInCart != nil {
+		return *x.ItemInCart
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *HybridRequest) GetProfileVersion() int32 {
+	if x != nil {
+		return x.ProfileVersion
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetUserFrequency() float64 {
+	if x != nil && x.UserFrequency != nil {
+		return *x.UserFrequency
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetUserCoOrders() float64 {
+	if x != nil && x.UserCoOrders != nil {
+		return *x.UserCoOrders
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetGlobalCoOrders() float64 {
+	if x != nil && x.GlobalCoOrders != nil {
+		return *x.GlobalCoOrders
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetTimeBasedTrend() float64 {
+	if x != nil && x.TimeBasedTrend != nil {
+		return *x.TimeBasedTrend
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetPriceSensitivity() float64 {
+	if x != nil && x.PriceSensitivity != nil {
+		return *x.PriceSensitivity
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetRatings() float64 {
+	if x != nil && x.Ratings != nil {
+		return *x.Ratings
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetDiversity() float64 {
+	if x != nil {
+		return x.Diversity
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetMaxPerCategory() int32 {
+	if x != nil {
+		return x.MaxPerCategory
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *HybridRequest) GetMixRatio() float64 {
+	if x != nil && x.MixRatio != nil {
+		return *x.MixRatio
+	}
+	return 0
+}
+
+func (x *HybridRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *HybridRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type HybridResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RequestId   string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Strategy    string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ItemInCart  *int64                 `protobuf:"varint,4,opt,name=item_in_cart,json=itemInCart,proto3,oneof" json:"item_in_cart,omitempty"`
+	Segment     string                 `protobuf:"bytes,5,opt,name=segment,proto3" json:"segment,omitempty"`
+	// The weight profile used, if any
+	Profile        string `protobuf:"bytes,6,opt,name=profile,proto3" json:"profile,omitempty"`
+	ProfileVersion int32  `protobuf:"varint,7,opt,name=profile_version,json=profileVersion,proto3" json:"profile_version,omitempty"`
+	// The experiment and variant that produced the results, if any
+	Experiment      string            `protobuf:"bytes,8,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	Variant         string            `protobuf:"bytes,9,opt,name=variant,proto3" json:"variant,omitempty"`
+	Weights         *HybridWeights    `protobuf:"bytes,10,opt,name=weights,proto3" json:"weights,omitempty"`
+	Recommendations []*Recommendation `protobuf:"bytes,11,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HybridResponse) Reset() {
+	*x = HybridResponse{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HybridResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HybridResponse) ProtoMessage() {}
+
+func (x *HybridResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HybridResponse.ProtoReflect.Descriptor instead.
+func (*HybridResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *HybridResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetItemInCart() int64 {
+	if x != nil && x.ItemInCart != nil {
+		return *x.ItemInCart
+	}
+	return 0
+}
+
+func (x *HybridResponse) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetProfileVersion() int32 {
+	if x != nil {
+		return x.ProfileVersion
+	}
+	return 0
+}
+
+func (x *HybridResponse) GetExperiment() string {
+	if x != nil {
+		return x.Experiment
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *HybridResponse) GetWeights() *HybridWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *HybridResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type BundleRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemInCart *int64                 `protobuf:"varint,2,opt,name=item_in_cart,json=itemInCart,proto3,oneof" json:"item_in_cart,omitempty"`
+	// Items per bundle, 2-4, default 3
+	Size int32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Bundles to return, 1-50, default 5
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// budget caps the bundle total; min_price and max_price apply to each item
+	Price         *PriceFilter `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleRequest) Reset() {
+	*x = BundleRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleRequest) ProtoMessage() {}
+
+func (x *BundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleRequest.ProtoReflect.Descriptor instead.
+func (*BundleRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BundleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *BundleRequest) GetItemInCart() int64 {
+	if x != nil && x.ItemInCart != nil {
+		return *x.ItemInCart
+	}
+	return 0
+}
+
+func (x *BundleRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BundleRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *BundleRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type Bundle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	TotalPrice    float64                `protobuf:"fixed64,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Explanation   string                 `protobuf:"bytes,5,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bundle) Reset() {
+	*x = Bundle{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bundle) ProtoMessage() {}
+
+func (x *Bundle) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bundle.ProtoReflect.Descriptor instead.
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Bundle) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Bundle) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Bundle) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *Bundle) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Bundle) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type BundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Strategy      string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ItemInCart    *int64                 `protobuf:"varint,4,opt,name=item_in_cart,json=itemInCart,proto3,oneof" json:"item_in_cart,omitempty"`
+	Bundles       []*Bundle              `protobuf:"bytes,5,rep,name=bundles,proto3" json:"bundles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleResponse) Reset() {
+	*x = BundleResponse{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleResponse) ProtoMessage() {}
+
+func (x *BundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleResponse.ProtoReflect.Descriptor instead.
+func (*BundleResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BundleResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *BundleResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *BundleResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BundleResponse) GetItemInCart() int64 {
+	if x != nil && x.ItemInCart != nil {
+		return *x.ItemInCart
+	}
+	return 0
+}
+
+func (x *BundleResponse) GetBundles() []*Bundle {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+type ReorderRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Baskets of each kind to return, 1-50, default 5
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// budget caps a basket's current total; min_price and max_price apply to each available item
+	Price         *PriceFilter `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderRequest) Reset() {
+	*x = ReorderRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderRequest) ProtoMessage() {}
+
+func (x *ReorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderRequest.ProtoReflect.Descriptor instead.
+func (*ReorderRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReorderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReorderRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ReorderRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type BasketLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Available     bool                   `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	LineTotal     float64                `protobuf:"fixed64,4,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BasketLine) Reset() {
+	*x = BasketLine{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BasketLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BasketLine) ProtoMessage() {}
+
+func (x *BasketLine) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BasketLine.ProtoReflect.Descriptor instead.
+func (*BasketLine) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BasketLine) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *BasketLine) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BasketLine) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *BasketLine) GetLineTotal() float64 {
+	if x != nil {
+		return x.LineTotal
+	}
+	return 0
+}
+
+type Basket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderIds      []int64                `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	Lines         []*BasketLine          `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	LastOrderedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_ordered_at,json=lastOrderedAt,proto3" json:"last_ordered_at,omitempty"`
+	TimesOrdered  int64                  `protobuf:"varint,4,opt,name=times_ordered,json=timesOrdered,proto3" json:"times_ordered,omitempty"`
+	OriginalTotal float64                `protobuf:"fixed64,5,opt,name=original_total,json=originalTotal,proto3" json:"original_total,omitempty"`
+	CurrentTotal  float64                `protobuf:"fixed64,6,opt,name=current_total,json=currentTotal,proto3" json:"current_total,omitempty"`
+	AllAvailable  bool                   `protobuf:"varint,7,opt,name=all_available,json=allAvailable,proto3" json:"all_available,omitempty"`
+	Weekday       string                 `protobuf:"bytes,8,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	Explanation   string                 `protobuf:"bytes,9,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Basket) Reset() {
+	*x = Basket{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Basket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Basket) ProtoMessage() {}
+
+func (x *Basket) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Basket.ProtoReflect.Descriptor instead.
+func (*Basket) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Basket) GetOrderIds() []int64 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *Basket) GetLines() []*BasketLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Basket) GetLastOrderedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOrderedAt
+	}
+	return nil
+}
+
+func (x *Basket) GetTimesOrdered() int64 {
+	if x != nil {
+		return x.TimesOrdered
+	}
+	return 0
+}
+
+func (x *Basket) GetOriginalTotal() float64 {
+	if x != nil {
+		return x.OriginalTotal
+	}
+	return 0
+}
+
+func (x *Basket) GetCurrentTotal() float64 {
+	if x != nil {
+		return x.CurrentTotal
+	}
+	return 0
+}
+
+func (x *Basket) GetAllAvailable() bool {
+	if x != nil {
+		return x.AllAvailable
+	}
+	return false
+}
+
+func (x *Basket) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *Basket) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type ReorderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RequestId        string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Strategy         string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	RecentBaskets    []*Basket              `protobuf:"bytes,4,rep,name=recent_baskets,json=recentBaskets,proto3" json:"recent_baskets,omitempty"`
+	RecurringBaskets []*Basket              `protobuf:"bytes,5,rep,name=recurring_baskets,json=recurringBaskets,proto3" json:"recurring_baskets,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReorderResponse) Reset() {
+	*x = ReorderResponse{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderResponse) ProtoMessage() {}
+
+func (x *ReorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderResponse.ProtoReflect.Descriptor instead.
+func (*ReorderResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReorderResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ReorderResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ReorderResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReorderResponse) GetRecentBaskets() []*Basket {
+	if x != nil {
+		return x.RecentBaskets
+	}
+	return nil
+}
+
+func (x *ReorderResponse) GetRecurringBaskets() []*Basket {
+	if x != nil {
+		return x.RecurringBaskets
+	}
+	return nil
+}
+
+type CartRecommendationsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Items in the cart, oldest first; the last one drives co-order suggestions and
+	// none of them is recommended
+	CartItemIds []int64      `protobuf:"varint,2,rep,packed,name=cart_item_ids,json=cartItemIds,proto3" json:"cart_item_ids,omitempty"`
+	Price       *PriceFilter `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	SessionId   string       `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// How often to look for changed recommendations, 5-600, default 30
+	RefreshSeconds int32 `protobuf:"varint,5,opt,name=refresh_seconds,json=refreshSeconds,proto3" json:"refresh_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CartRecommendationsRequest) Reset() {
+	*x = CartRecommendationsRequest{}
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartRecommendationsRequest) ProtoMessage() {}
+
+func (x *CartRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_v1_restaurant_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*CartRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_v1_restaurant_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CartRecommendationsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CartRecommendationsRequest) GetCartItemIds() []int64 {
+	if x != nil {
+		return x.CartItemIds
+	}
+	return nil
+}
+
+func (x *CartRecommendationsRequest) GetPrice() *PriceFilter {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CartRecommendationsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CartRecommendationsRequest) GetRefreshSeconds() int32 {
+	if x != nil {
+		return x.RefreshSeconds
+	}
+	return 0
+}
+
+var File_restaurant_v1_restaurant_proto protoreflect.FileDescriptor
+
+const file_restaurant_v1_restaurant_proto_rawDesc = "" +
+	"\n" +
+	"\x1erestaurant/v1/restaurant.proto\x12\rrestaurant.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x01\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x126\n" +
+	"\aratings\x18\x06 \x01(\v2\x1c.restaurant.v1.RatingSummaryR\aratings\"d\n" +
+	"\rRatingSummary\x12\x12\n" +
+	"\x04mean\x18\x01 \x01(\x01R\x04mean\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12)\n" +
+	"\x10bayesian_average\x18\x03 \x01(\x01R\x0fbayesianAverage\"{\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc2\x01\n" +
+	"\tUserStats\x12\x1f\n" +
+	"\vorder_count\x18\x01 \x01(\x03R\n" +
+	"orderCount\x12%\n" +
+	"\x0elifetime_spend\x18\x02 \x01(\x01R\rlifetimeSpend\x12-\n" +
+	"\x12favourite_category\x18\x03 \x01(\tR\x11favouriteCategory\x12>\n" +
+	"\rlast_order_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastOrderAt\"f\n" +
+	"\vUserProfile\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.restaurant.v1.UserR\x04user\x12.\n" +
+	"\x05stats\x18\x02 \x01(\v2\x18.restaurant.v1.UserStatsR\x05stats\"\x8d\x01\n" +
+	"\x0eRecommendation\x12'\n" +
+	"\x04item\x18\x01 \x01(\v2\x13.restaurant.v1.ItemR\x04item\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12 \n" +
+	"\vexplanation\x18\x03 \x01(\tR\vexplanation\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\"_\n" +
+	"\vPriceFilter\x12\x1b\n" +
+	"\tmin_price\x18\x01 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x02 \x01(\x01R\bmaxPrice\x12\x16\n" +
+	"\x06budget\x18\x03 \x01(\x01R\x06budget\"\xf7\x01\n" +
+	"\rHybridWeights\x12%\n" +
+	"\x0euser_frequency\x18\x01 \x01(\x01R\ruserFrequency\x12$\n" +
+	"\x0euser_co_orders\x18\x02 \x01(\x01R\fuserCoOrders\x12(\n" +
+	"\x10global_co_orders\x18\x03 \x01(\x01R\x0eglobalCoOrders\x12(\n" +
+	"\x10time_based_trend\x18\x04 \x01(\x01R\x0etimeBasedTrend\x12+\n" +
+	"\x11price_sensitivity\x18\x05 \x01(\x01R\x10priceSensitivity\x12\x18\n" +
+	"\aratings\x18\x06 \x01(\x01R\aratings\".\n" +
+	"\x10ListItemsRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\">\n" +
+	"\x11ListItemsResponse\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.restaurant.v1.ItemR\x05items\")\n" +
+	"\x0eGetItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x03R\x06itemId\"\x12\n" +
+	"\x10ListUsersRequest\">\n" +
+	"\x11ListUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.restaurant.v1.UserR\x05users\"0\n" +
+	"\x15GetUserProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"=\n" +
+	"\x11CreateUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"g\n" +
+	"\x1aUserRecommendationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x120\n" +
+	"\x05price\x18\x02 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05price\"y\n" +
+	"\x13UserCoOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\x03R\x06itemId\x120\n" +
+	"\x05price\x18\x03 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05price\"\x8c\x01\n" +
+	"\x15GlobalCoOrdersRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x03R\x06itemId\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\x03H\x00R\x06userId\x88\x01\x01\x120\n" +
+	"\x05price\x18\x03 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05priceB\n" +
+	"\n" +
+	"\b_user_id\"\x81\x01\n" +
+	"\x0fTrendingRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\x03H\x00R\x06userId\x88\x01\x01\x120\n" +
+	"\x05price\x18\x03 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05priceB\n" +
+	"\n" +
+	"\b_user_id\"\xf7\x01\n" +
+	"\x17RecommendationsResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\fitem_in_cart\x18\x04 \x01(\x03H\x00R\n" +
+	"itemInCart\x88\x01\x01\x12G\n" +
+	"\x0frecommendations\x18\x05 \x03(\v2\x1d.restaurant.v1.RecommendationR\x0frecommendationsB\x0f\n" +
+	"\r_item_in_cart\"\xf8\x05\n" +
+	"\rHybridRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12%\n" +
+	"\fitem_in_cart\x18\x02 \x01(\x03H\x00R\n" +
+	"itemInCart\x88\x01\x01\x12\x18\n" +
+	"\aprofile\x18\x03 \x01(\tR\aprofile\x12'\n" +
+	"\x0fprofile_version\x18\x04 \x01(\x05R\x0eprofileVersion\x12*\n" +
+	"\x0euser_frequency\x18\x05 \x01(\x01H\x01R\ruserFrequency\x88\x01\x01\x12)\n" +
+	"\x0euser_co_orders\x18\x06 \x01(\x01H\x02R\fuserCoOrders\x88\x01\x01\x12-\n" +
+	"\x10global_co_orders\x18\a \x01(\x01H\x03R\x0eglobalCoOrders\x88\x01\x01\x12-\n" +
+	"\x10time_based_trend\x18\b \x01(\x01H\x04R\x0etimeBasedTrend\x88\x01\x01\x120\n" +
+	"\x11price_sensitivity\x18\t \x01(\x01H\x05R\x10priceSensitivity\x88\x01\x01\x12\x1d\n" +
+	"\aratings\x18\n" +
+	" \x01(\x01H\x06R\aratings\x88\x01\x01\x12\x1c\n" +
+	"\tdiversity\x18\v \x01(\x01R\tdiversity\x12(\n" +
+	"\x10max_per_category\x18\f \x01(\x05R\x0emaxPerCategory\x12\x12\n" +
+	"\x04mode\x18\r \x01(\tR\x04mode\x12 \n" +
+	"\tmix_ratio\x18\x0e \x01(\x01H\aR\bmixRatio\x88\x01\x01\x120\n" +
+	"\x05price\x18\x0f \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05price\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x10 \x01(\tR\tsessionIdB\x0f\n" +
+	"\r_item_in_cartB\x11\n" +
+	"\x0f_user_frequencyB\x11\n" +
+	"\x0f_user_co_ordersB\x13\n" +
+	"\x11_global_co_ordersB\x13\n" +
+	"\x11_time_based_trendB\x14\n" +
+	"\x12_price_sensitivityB\n" +
+	"\n" +
+	"\b_ratingsB\f\n" +
+	"\n" +
+	"_mix_ratio\"\xbd\x03\n" +
+	"\x0eHybridResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\fitem_in_cart\x18\x04 \x01(\x03H\x00R\n" +
+	"itemInCart\x88\x01\x01\x12\x18\n" +
+	"\asegment\x18\x05 \x01(\tR\asegment\x12\x18\n" +
+	"\aprofile\x18\x06 \x01(\tR\aprofile\x12'\n" +
+	"\x0fprofile_version\x18\a \x01(\x05R\x0eprofileVersion\x12\x1e\n" +
+	"\n" +
+	"experiment\x18\b \x01(\tR\n" +
+	"experiment\x12\x18\n" +
+	"\avariant\x18\t \x01(\tR\avariant\x126\n" +
+	"\aweights\x18\n" +
+	" \x01(\v2\x1c.restaurant.v1.HybridWeightsR\aweights\x12G\n" +
+	"\x0frecommendations\x18\v \x03(\v2\x1d.restaurant.v1.RecommendationR\x0frecommendationsB\x0f\n" +
+	"\r_item_in_cart\"\xbc\x01\n" +
+	"\rBundleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12%\n" +
+	"\fitem_in_cart\x18\x02 \x01(\x03H\x00R\n" +
+	"itemInCart\x88\x01\x01\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x120\n" +
+	"\x05price\x18\x05 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05priceB\x0f\n" +
+	"\r_item_in_cart\"\xa2\x01\n" +
+	"\x06Bundle\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.restaurant.v1.ItemR\x05items\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x12\x1f\n" +
+	"\vtotal_price\x18\x03 \x01(\x01R\n" +
+	"totalPrice\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12 \n" +
+	"\vexplanation\x18\x05 \x01(\tR\vexplanation\"\xd6\x01\n" +
+	"\x0eBundleResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\fitem_in_cart\x18\x04 \x01(\x03H\x00R\n" +
+	"itemInCart\x88\x01\x01\x12/\n" +
+	"\abundles\x18\x05 \x03(\v2\x15.restaurant.v1.BundleR\abundlesB\x0f\n" +
+	"\r_item_in_cart\"q\n" +
+	"\x0eReorderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x120\n" +
+	"\x05price\x18\x03 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05price\"\x8e\x01\n" +
+	"\n" +
+	"BasketLine\x12'\n" +
+	"\x04item\x18\x01 \x01(\v2\x13.restaurant.v1.ItemR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\bR\tavailable\x12\x1d\n" +
+	"\n" +
+	"line_total\x18\x04 \x01(\x01R\tlineTotal\"\xec\x02\n" +
+	"\x06Basket\x12\x1b\n" +
+	"\torder_ids\x18\x01 \x03(\x03R\borderIds\x12/\n" +
+	"\x05lines\x18\x02 \x03(\v2\x19.restaurant.v1.BasketLineR\x05lines\x12B\n" +
+	"\x0flast_ordered_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rlastOrderedAt\x12#\n" +
+	"\rtimes_ordered\x18\x04 \x01(\x03R\ftimesOrdered\x12%\n" +
+	"\x0eoriginal_total\x18\x05 \x01(\x01R\roriginalTotal\x12#\n" +
+	"\rcurrent_total\x18\x06 \x01(\x01R\fcurrentTotal\x12#\n" +
+	"\rall_available\x18\a \x01(\bR\fallAvailable\x12\x18\n" +
+	"\aweekday\x18\b \x01(\tR\aweekday\x12 \n" +
+	"\vexplanation\x18\t \x01(\tR\vexplanation\"\xf0\x01\n" +
+	"\x0fReorderResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12<\n" +
+	"\x0erecent_baskets\x18\x04 \x03(\v2\x15.restaurant.v1.BasketR\rrecentBaskets\x12B\n" +
+	"\x11recurring_baskets\x18\x05 \x03(\v2\x15.restaurant.v1.BasketR\x10recurringBaskets\"\xd3\x01\n" +
+	"\x1aCartRecommendationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\"\n" +
+	"\rcart_item_ids\x18\x02 \x03(\x03R\vcartItemIds\x120\n" +
+	"\x05price\x18\x03 \x01(\v2\x1a.restaurant.v1.PriceFilterR\x05price\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\x12'\n" +
+	"\x0frefresh_seconds\x18\x05 \x01(\x05R\x0erefreshSeconds2\x81\n" +
+	"\n" +
+	"\x15RecommendationService\x12N\n" +
+	"\tListItems\x12\x1f.restaurant.v1.ListItemsRequest\x1a .restaurant.v1.ListItemsResponse\x12=\n" +
+	"\aGetItem\x12\x1d.restaurant.v1.GetItemRequest\x1a\x13.restaurant.v1.Item\x12N\n" +
+	"\tListUsers\x12\x1f.restaurant.v1.ListUsersRequest\x1a .restaurant.v1.ListUsersResponse\x12R\n" +
+	"\x0eGetUserProfile\x12$.restaurant.v1.GetUserProfileRequest\x1a\x1a.restaurant.v1.UserProfile\x12C\n" +
+	"\n" +
+	"CreateUser\x12 .restaurant.v1.CreateUserRequest\x1a\x13.restaurant.v1.User\x12i\n" +
+	"\x14GetUserFrequentItems\x12).restaurant.v1.UserRecommendationsRequest\x1a&.restaurant.v1.RecommendationsResponse\x12c\n" +
+	"\x15GetUserCoOrderedItems\x12\".restaurant.v1.UserCoOrdersRequest\x1a&.restaurant.v1.RecommendationsResponse\x12g\n" +
+	"\x17GetGlobalCoOrderedItems\x12$.restaurant.v1.GlobalCoOrdersRequest\x1a&.restaurant.v1.RecommendationsResponse\x12Z\n" +
+	"\x10GetTrendingItems\x12\x1e.restaurant.v1.TrendingRequest\x1a&.restaurant.v1.RecommendationsResponse\x12h\n" +
+	"\x13GetRatingBasedItems\x12).restaurant.v1.UserRecommendationsRequest\x1a&.restaurant.v1.RecommendationsResponse\x12W\n" +
+	"\x18GetHybridRecommendations\x12\x1c.restaurant.v1.HybridRequest\x1a\x1d.restaurant.v1.HybridResponse\x12W\n" +
+	"\x18GetBundleRecommendations\x12\x1c.restaurant.v1.BundleRequest\x1a\x1d.restaurant.v1.BundleResponse\x12V\n" +
+	"\x15GetReorderSuggestions\x12\x1d.restaurant.v1.ReorderRequest\x1a\x1e.restaurant.v1.ReorderResponse\x12g\n" +
+	"\x19StreamCartRecommendations\x12).restaurant.v1.CartRecommendationsRequest\x1a\x1d.restaurant.v1.HybridResponse0\x01BAZ?github.com/yishak-cs/Neo4j_DB/pkg/pb/restaurant/v1;restaurantv1b\x06proto3"
+
+var (
+	file_restaurant_v1_restaurant_proto_rawDescOnce sync.Once
+	file_restaurant_v1_restaurant_proto_rawDescData []byte
+)
+
+func file_restaurant_v1_restaurant_proto_rawDescGZIP() []byte {
+	file_restaurant_v1_restaurant_proto_rawDescOnce.Do(func() {
+		file_restaurant_v1_restaurant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_restaurant_v1_restaurant_proto_rawDesc), len(file_restaurant_v1_restaurant_proto_rawDesc)))
+	})
+	return file_restaurant_v1_restaurant_proto_rawDescData
+}
+
+var file_restaurant_v1_restaurant_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_restaurant_v1_restaurant_proto_goTypes = []any{
+	(*Item)(nil),                       // 0: restaurant.v1.Item
+	(*RatingSummary)(nil),              // 1: restaurant.v1.RatingSummary
+	(*User)(nil),                       // 2: restaurant.v1.User
+	(*UserStats)(nil),                  // 3: restaurant.v1.UserStats
+	(*UserProfile)(nil),                // 4: restaurant.v1.UserProfile
+	(*Recommendation)(nil),             // 5: restaurant.v1.Recommendation
+	(*PriceFilter)(nil),                // 6: restaurant.v1.PriceFilter
+	(*HybridWeights)(nil),              // 7: restaurant.v1.HybridWeights
+	(*ListItemsRequest)(nil),           // 8: restaurant.v1.ListItemsRequest
+	(*ListItemsResponse)(nil),          // 9: restaurant.v1.ListItemsResponse
+	(*GetItemRequest)(nil),             // 10: restaurant.v1.GetItemRequest
+	(*ListUsersRequest)(nil),           // 11: restaurant.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),          // 12: restaurant.v1.ListUsersResponse
+	(*GetUserProfileRequest)(nil),      // 13: restaurant.v1.GetUserProfileRequest
+	(*CreateUserRequest)(nil),          // 14: restaurant.v1.CreateUserRequest
+	(*UserRecommendationsRequest)(nil), // 15: restaurant.v1.UserRecommendationsRequest
+	(*UserCoOrdersRequest)(nil),        // 16: restaurant.v1.UserCoOrdersRequest
+	(*GlobalCoOrdersRequest)(nil),      // 17: restaurant.v1.GlobalCoOrdersRequest
+	(*TrendingRequest)(nil),            // 18: restaurant.v1.TrendingRequest
+	(*RecommendationsResponse)(nil),    // 19: restaurant.v1.RecommendationsResponse
+	(*HybridRequest)(nil),              // 20: restaurant.v1.HybridRequest
+	(*HybridResponse)(nil),             // 21: restaurant.v1.HybridResponse
+	(*BundleRequest)(nil),              // 22: restaurant.v1.BundleRequest
+	(*Bundle)(nil),                     // 23: restaurant.v1.Bundle
+	(*BundleResponse)(nil),             // 24: restaurant.v1.BundleResponse
+	(*ReorderRequest)(nil),             // 25: restaurant.v1.ReorderRequest
+	(*BasketLine)(nil),                 // 26: restaurant.v1.BasketLine
+	(*Basket)(nil),                     // 27: restaurant.v1.Basket
+	(*ReorderResponse)(nil),            // 28: restaurant.v1.ReorderResponse
+	(*CartRecommendationsRequest)(nil), // 29: restaurant.v1.CartRecommendationsRequest
+	(*timestamppb.Timestamp)(nil),      // 30: google.protobuf.Timestamp
+}
+var file_restaurant_v1_restaurant_proto_depIdxs = []int32{
+	1,  // 0: restaurant.v1.Item.ratings:type_name -> restaurant.v1.RatingSummary
+	30, // 1: restaurant.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	30, // 2: restaurant.v1.UserStats.last_order_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: restaurant.v1.UserProfile.user:type_name -> restaurant.v1.User
+	3,  // 4: restaurant.v1.UserProfile.stats:type_name -> restaurant.v1.UserStats
+	0,  // 5: restaurant.v1.Recommendation.item:type_name -> restaurant.v1.Item
+	0,  // 6: restaurant.v1.ListItemsResponse.items:type_name -> restaurant.v1.Item
+	2,  // 7: restaurant.v1.ListUsersResponse.users:type_name -> restaurant.v1.User
+	6,  // 8: restaurant.v1.UserRecommendationsRequest.price:type_name -> restaurant.v1.PriceFilter
+	6,  // 9: restaurant.v1.UserCoOrdersRequest.price:type_name -> restaurant.v1.PriceFilter
+	6,  // 10: restaurant.v1.GlobalCoOrdersRequest.price:type_name -> restaurant.v1.PriceFilter
+	6,  // 11: restaurant.v1.TrendingRequest.price:type_name -> restaurant.v1.PriceFilter
+	5,  // 12: restaurant.v1.RecommendationsResponse.recommendations:type_name -> restaurant.v1.Recommendation
+	6,  // 13: restaurant.v1.HybridRequest.price:type_name -> restaurant.v1.PriceFilter
+	7,  // 14: restaurant.v1.HybridResponse.weights:type_name -> restaurant.v1.HybridWeights
+	5,  // 15: restaurant.v1.HybridResponse.recommendations:type_name -> restaurant.v1.Recommendation
+	6,  // 16: restaurant.v1.BundleRequest.price:type_name -> restaurant.v1.PriceFilter
+	0,  // 17: restaurant.v1.Bundle.items:type_name -> restaurant.v1.Item
+	23, // 18: restaurant.v1.BundleResponse.bundles:type_name -> restaurant.v1.Bundle
+	6,  // 19: restaurant.v1.ReorderRequest.price:type_name -> restaurant.v1.PriceFilter
+	0,  // 20: restaurant.v1.BasketLine.item:type_name -> restaurant.v1.Item
+	26, // 21: restaurant.v1.Basket.lines:type_name -> restaurant.v1.BasketLine
+	30, // 22: restaurant.v1.Basket.last_ordered_at:type_name -> google.protobuf.Timestamp
+	27, // 23: restaurant.v1.ReorderResponse.recent_baskets:type_name -> restaurant.v1.Basket
+	27, // 24: restaurant.v1.ReorderResponse.recurring_baskets:type_name -> restaurant.v1.Basket
+	6,  // 25: restaurant.v1.CartRecommendationsRequest.price:type_name -> restaurant.v1.PriceFilter
+	8,  // 26: restaurant.v1.RecommendationService.ListItems:input_type -> restaurant.v1.ListItemsRequest
+	10, // 27: restaurant.v1.RecommendationService.GetItem:input_type -> restaurant.v1.GetItemRequest
+	11, // 28: restaurant.v1.RecommendationService.ListUsers:input_type -> restaurant.v1.ListUsersRequest
+	13, // 29: restaurant.v1.RecommendationService.GetUserProfile:input_type -> restaurant.v1.GetUserProfileRequest
+	14, // 30: restaurant.v1.RecommendationService.CreateUser:input_type -> restaurant.v1.CreateUserRequest
+	15, // 31: restaurant.v1.RecommendationService.GetUserFrequentItems:input_type -> restaurant.v1.UserRecommendationsRequest
+	16, // 32: restaurant.v1.RecommendationService.GetUserCoOrderedItems:input_type -> restaurant.v1.UserCoOrdersRequest
+	17, // 33: restaurant.v1.RecommendationService.GetGlobalCoOrderedItems:input_type -> restaurant.v1.GlobalCoOrdersRequest
+	18, // 34: restaurant.v1.RecommendationService.GetTrendingItems:input_type -> restaurant.v1.TrendingRequest
+	15, // 35: restaurant.v1.RecommendationService.GetRatingBasedItems:input_type -> restaurant.v1.UserRecommendationsRequest
+	20, // 36: restaurant.v1.RecommendationService.GetHybridRecommendations:input_type -> restaurant.v1.HybridRequest
+	22, // 37: restaurant.v1.RecommendationService.GetBundleRecommendations:input_type -> restaurant.v1.BundleRequest
+	25, // 38: restaurant.v1.RecommendationService.GetReorderSuggestions:input_type -> restaurant.v1.ReorderRequest
+	29, // 39: restaurant.v1.RecommendationService.StreamCartRecommendations:input_type -> restaurant.v1.CartRecommendationsRequest
+	9,  // 40: restaurant.v1.RecommendationService.ListItems:output_type -> restaurant.v1.ListItemsResponse
+	0,  // 41: restaurant.v1.RecommendationService.GetItem:output_type -> restaurant.v1.Item
+	12, // 42: restaurant.v1.RecommendationService.ListUsers:output_type -> restaurant.v1.ListUsersResponse
+	4,  // 43: restaurant.v1.RecommendationService.GetUserProfile:output_type -> restaurant.v1.UserProfile
+	2,  // 44: restaurant.v1.RecommendationService.CreateUser:output_type -> restaurant.v1.User
+	19, // 45: restaurant.v1.RecommendationService.GetUserFrequentItems:output_type -> restaurant.v1.RecommendationsResponse
+	19, // 46: restaurant.v1.RecommendationService.GetUserCoOrderedItems:output_type -> restaurant.v1.RecommendationsResponse
+	19, // 47: restaurant.v1.RecommendationService.GetGlobalCoOrderedItems:output_type -> restaurant.v1.RecommendationsResponse
+	19, // 48: restaurant.v1.RecommendationService.GetTrendingItems:output_type -> restaurant.v1.RecommendationsResponse
+	19, // 49: restaurant.v1.RecommendationService.GetRatingBasedItems:output_type -> restaurant.v1.RecommendationsResponse
+	21, // 50: restaurant.v1.RecommendationService.GetHybridRecommendations:output_type -> restaurant.v1.HybridResponse
+	24, // 51: restaurant.v1.RecommendationService.GetBundleRecommendations:output_type -> restaurant.v1.BundleResponse
+	28, // 52: restaurant.v1.RecommendationService.GetReorderSuggestions:output_type -> restaurant.v1.ReorderResponse
+	21, // 53: restaurant.v1.RecommendationService.StreamCartRecommendations:output_type -> restaurant.v1.HybridResponse
+	40, // [40:54] is the sub-list for method output_type
+	26, // [26:40] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_restaurant_v1_restaurant_proto_init() }
+func file_restaurant_v1_restaurant_proto_init() {
+	if File_restaurant_v1_restaurant_proto != nil {
+		return
+	}
+	file_restaurant_v1_restaurant_proto_msgTypes[17].OneofWrappers = []any{}
+	file_restaurant_v1_restaurant_proto_msgTypes[18].OneofWrappers = []any{}
+	file_restaurant_v1_restaurant_proto_msgTypes[19].OneofWrappers = []any{}
+	file_restaurant_v1_restaurant_proto_msgTypes[20].OneofWrappers = []any{}
+	file_restaurant_v1_restaurant_proto_msgTypes[21].OneofWrappers = []any{}
+	file_restaurant_v1_restaurant_proto_msgTypes[22].OneofWrappers = []any{}
+	file_restaurant_v1_restaurant_proto_msgTypes[24].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_restaurant_v1_restaurant_proto_rawDesc), len(file_restaurant_v1_restaurant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   30,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_restaurant_v1_restaurant_proto_goTypes,
+		DependencyIndexes: file_restaurant_v1_restaurant_proto_depIdxs,
+		MessageInfos:      file_restaurant_v1_restaurant_proto_msgTypes,
+	}.Build()
+	File_restaurant_v1_restaurant_proto = out.File
+	file_restaurant_v1_restaurant_proto_goTypes = nil
+	file_restaurant_v1_restaurant_proto_depIdxs = nil
+}
@@ -0,0 +1,671 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: restaurant/v1/restaurant.proto
+
+package restaurantv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RecommendationService_ListItems_FullMethodName                 = "/restaurant.v1.RecommendationService/ListItems"
+	RecommendationService_GetItem_FullMethodName                   = "/restaurant.v1.RecommendationService/GetItem"
+	RecommendationService_ListUsers_FullMethodName                 = "/restaurant.v1.RecommendationService/ListUsers"
+	RecommendationService_GetUserProfile_FullMethodName            = "/restaurant.v1.RecommendationService/GetUserProfile"
+	RecommendationService_CreateUser_FullMethodName                = "/restaurant.v1.RecommendationService/CreateUser"
+	RecommendationService_GetUserFrequentItems_FullMethodName      = "/restaurant.v1.RecommendationService/GetUserFrequentItems"
+	RecommendationService_GetUserCoOrderedItems_FullMethodName     = "/restaurant.v1.RecommendationService/GetUserCoOrderedItems"
+	RecommendationService_GetGlobalCoOrderedItems_FullMethodName   = "/restaurant.v1.RecommendationService/GetGlobalCoOrderedItems"
+	RecommendationService_GetTrendingItems_FullMethodName          = "/restaurant.v1.RecommendationService/GetTrendingItems"
+	RecommendationService_GetRatingBasedItems_FullMethodName       = "/restaurant.v1.RecommendationService/GetRatingBasedItems"
+	RecommendationService_GetHybridRecommendations_FullMethodName  = "/restaurant.v1.RecommendationService/GetHybridRecommendations"
+	RecommendationService_GetBundleRecommendations_FullMethodName  = "/restaurant.v1.RecommendationService/GetBundleRecommendations"
+	RecommendationService_GetReorderSuggestions_FullMethodName     = "/restaurant.v1.RecommendationService/GetReorderSuggestions"
+	RecommendationService_StreamCartRecommendations_FullMethodName = "/restaurant.v1.RecommendationService/StreamCartRecommendations"
+)
+
+// RecommendationServiceClient is the client API for RecommendationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RecommendationService serves the menu, guests and recommendations to backends that
+// would rather not go through the REST API. It runs in the same process as the REST
+// API and shares its services, so both always answer alike.
+//
+// Errors use the standard status codes: INVALID_ARGUMENT (with a BadRequest detail
+// listing the rejected fields), NOT_FOUND, ALREADY_EXISTS for conflicts such as a
+// taken email, UNAVAILABLE, DEADLINE_EXCEEDED and INTERNAL. A request ID sent as
+// x-request-id metadata is echoed back in the response header, or one is generated.
+type RecommendationServiceClient interface {
+	// ListItems lists the available menu items with their rating summaries
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error)
+	// GetItem gets one menu item with its rating summary
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error)
+	// ListUsers lists the registered guests
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// GetUserProfile gets a guest with their order summary
+	GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	// CreateUser registers a guest; emails are unique
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	// GetUserFrequentItems recommends the items a guest orders most frequently
+	GetUserFrequentItems(ctx context.Context, in *UserRecommendationsRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+	// GetUserCoOrderedItems recommends items a guest frequently orders with an item
+	GetUserCoOrderedItems(ctx context.Context, in *UserCoOrdersRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+	// GetGlobalCoOrderedItems recommends items all guests frequently order with an item
+	GetGlobalCoOrderedItems(ctx context.Context, in *GlobalCoOrdersRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+	// GetTrendingItems recommends the currently trending items
+	GetTrendingItems(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+	// GetRatingBasedItems recommends items rated highly by guests with similar taste
+	GetRatingBasedItems(ctx context.Context, in *UserRecommendationsRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+	// GetHybridRecommendations blends every strategy into personalised recommendations
+	GetHybridRecommendations(ctx context.Context, in *HybridRequest, opts ...grpc.CallOption) (*HybridResponse, error)
+	// GetBundleRecommendations suggests combinations that complete a meal
+	GetBundleRecommendations(ctx context.Context, in *BundleRequest, opts ...grpc.CallOption) (*BundleResponse, error)
+	// GetReorderSuggestions suggests recent and recurring orders to place again
+	GetReorderSuggestions(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error)
+	// StreamCartRecommendations sends hybrid recommendations for a cart straight away,
+	// then again whenever they change as new orders reshape the graph, until the caller
+	// cancels. Open a new stream when the cart changes. The stream keeps the weights
+	// and experiment variant it started with.
+	StreamCartRecommendations(ctx context.Context, in *CartRecommendationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HybridResponse], error)
+}
+
+type recommendationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecommendationServiceClient(cc grpc.ClientConnInterface) RecommendationServiceClient {
+	return &recommendationServiceClient{cc}
+}
+
+func (c *recommendationServiceClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListItemsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_ListItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Item)
+	err := c.cc.Invoke(ctx, RecommendationService_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, RecommendationService_GetUserProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, RecommendationService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetUserFrequentItems(ctx context.Context, in *UserRecommendationsRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetUserFrequentItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetUserCoOrderedItems(ctx context.Context, in *UserCoOrdersRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetUserCoOrderedItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetGlobalCoOrderedItems(ctx context.Context, in *GlobalCoOrdersRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetGlobalCoOrderedItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetTrendingItems(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetTrendingItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetRatingBasedItems(ctx context.Context, in *UserRecommendationsRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetRatingBasedItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetHybridRecommendations(ctx context.Context, in *HybridRequest, opts ...grpc.CallOption) (*HybridResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HybridResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetHybridRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetBundleRecommendations(ctx context.Context, in *BundleRequest, opts ...grpc.CallOption) (*BundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BundleResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetBundleRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetReorderSuggestions(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetReorderSuggestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) StreamCartRecommendations(ctx context.Context, in *CartRecommendationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HybridResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RecommendationService_ServiceDesc.Streams[0], RecommendationService_StreamCartRecommendations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CartRecommendationsRequest, HybridResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RecommendationService_StreamCartRecommendationsClient = grpc.ServerStreamingClient[HybridResponse]
+
+// RecommendationServiceServer is the server API for RecommendationService service.
+// All implementations must embed UnimplementedRecommendationServiceServer
+// for forward compatibility.
+//
+// RecommendationService serves the menu, guests and recommendations to backends that
+// would rather not go through the REST API. It runs in the same process as the REST
+// API and shares its services, so both always answer alike.
+//
+// Errors use the standard status codes: INVALID_ARGUMENT (with a BadRequest detail
+// listing the rejected fields), NOT_FOUND, ALREADY_EXISTS for conflicts such as a
+// taken email, UNAVAILABLE, DEADLINE_EXCEEDED and INTERNAL. A request ID sent as
+// x-request-id metadata is echoed back in the response header, or one is generated.
+type RecommendationServiceServer interface {
+	// ListItems lists the available menu items with their rating summaries
+	ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error)
+	// GetItem gets one menu item with its rating summary
+	GetItem(context.Context, *GetItemRequest) (*Item, error)
+	// ListUsers lists the registered guests
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// GetUserProfile gets a guest with their order summary
+	GetUserProfile(context.Context, *GetUserProfileRequest) (*UserProfile, error)
+	// CreateUser registers a guest; emails are unique
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	// GetUserFrequentItems recommends the items a guest orders most frequently
+	GetUserFrequentItems(context.Context, *UserRecommendationsRequest) (*RecommendationsResponse, error)
+	// GetUserCoOrderedItems recommends items a guest frequently orders with an item
+	GetUserCoOrderedItems(context.Context, *UserCoOrdersRequest) (*RecommendationsResponse, error)
+	// GetGlobalCoOrderedItems recommends items all guests frequently order with an item
+	GetGlobalCoOrderedItems(context.Context, *GlobalCoOrdersRequest) (*RecommendationsResponse, error)
+	// GetTrendingItems recommends the currently trending items
+	GetTrendingItems(context.Context, *TrendingRequest) (*RecommendationsResponse, error)
+	// GetRatingBasedItems recommends items rated highly by guests with similar taste
+	GetRatingBasedItems(context.Context, *UserRecommendationsRequest) (*RecommendationsResponse, error)
+	// GetHybridRecommendations blends every strategy into personalised recommendations
+	GetHybridRecommendations(context.Context, *HybridRequest) (*HybridResponse, error)
+	// GetBundleRecommendations suggests combinations that complete a meal
+	GetBundleRecommendations(context.Context, *BundleRequest) (*BundleResponse, error)
+	// GetReorderSuggestions suggests recent and recurring orders to place again
+	GetReorderSuggestions(context.Context, *ReorderRequest) (*ReorderResponse, error)
+	// StreamCartRecommendations sends hybrid recommendations for a cart straight away,
+	// then again whenever they change as new orders reshape the graph, until the caller
+	// cancels. Open a new stream when the cart changes. The stream keeps the weights
+	// and experiment variant it started with.
+	StreamCartRecommendations(*CartRecommendationsRequest, grpc.ServerStreamingServer[HybridResponse]) error
+	mustEmbedUnimplementedRecommendationServiceServer()
+}
+
+// UnimplementedRecommendationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecommendationServiceServer struct{}
+
+func (UnimplementedRecommendationServiceServer) ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetItem(context.Context, *GetItemRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedRecommendationServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetUserProfile(context.Context, *GetUserProfileRequest) (*UserProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserProfile not implemented")
+}
+func (UnimplementedRecommendationServiceServer) CreateUser(context.Context, *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetUserFrequentItems(context.Context, *UserRecommendationsRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserFrequentItems not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetUserCoOrderedItems(context.Context, *UserCoOrdersRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserCoOrderedItems not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetGlobalCoOrderedItems(context.Context, *GlobalCoOrdersRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGlobalCoOrderedItems not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetTrendingItems(context.Context, *TrendingRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingItems not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetRatingBasedItems(context.Context, *UserRecommendationsRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRatingBasedItems not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetHybridRecommendations(context.Context, *HybridRequest) (*HybridResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHybridRecommendations not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetBundleRecommendations(context.Context, *BundleRequest) (*BundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBundleRecommendations not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetReorderSuggestions(context.Context, *ReorderRequest) (*ReorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReorderSuggestions not implemented")
+}
+func (UnimplementedRecommendationServiceServer) StreamCartRecommendations(*CartRecommendationsRequest, grpc.ServerStreamingServer[HybridResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCartRecommendations not implemented")
+}
+func (UnimplementedRecommendationServiceServer) mustEmbedUnimplementedRecommendationServiceServer() {}
+func (UnimplementedRecommendationServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeRecommendationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecommendationServiceServer will
+// result in compilation errors.
+type UnsafeRecommendationServiceServer interface {
+	mustEmbedUnimplementedRecommendationServiceServer()
+}
+
+func RegisterRecommendationServiceServer(s grpc.ServiceRegistrar, srv RecommendationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecommendationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecommendationService_ServiceDesc, srv)
+}
+
+func _RecommendationService_ListItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).ListItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_ListItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).ListItems(ctx, req.(*ListItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetUserProfile(ctx, req.(*GetUserProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetUserFrequentItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetUserFrequentItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetUserFrequentItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetUserFrequentItems(ctx, req.(*UserRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetUserCoOrderedItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserCoOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetUserCoOrderedItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetUserCoOrderedItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetUserCoOrderedItems(ctx, req.(*UserCoOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetGlobalCoOrderedItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GlobalCoOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetGlobalCoOrderedItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetGlobalCoOrderedItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetGlobalCoOrderedItems(ctx, req.(*GlobalCoOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetTrendingItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetTrendingItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetTrendingItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetTrendingItems(ctx, req.(*TrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetRatingBasedItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetRatingBasedItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetRatingBasedItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetRatingBasedItems(ctx, req.(*UserRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetHybridRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HybridRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetHybridRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetHybridRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetHybridRecommendations(ctx, req.(*HybridRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetBundleRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetBundleRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetBundleRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetBundleRecommendations(ctx, req.(*BundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetReorderSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetReorderSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetReorderSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetReorderSuggestions(ctx, req.(*ReorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_StreamCartRecommendations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CartRecommendationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecommendationServiceServer).StreamCartRecommendations(m, &grpc.GenericServerStream[CartRecommendationsRequest, HybridResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RecommendationService_StreamCartRecommendationsServer = grpc.ServerStreamingServer[HybridResponse]
+
+// RecommendationService_ServiceDesc is the grpc.ServiceDesc for RecommendationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecommendationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restaurant.v1.RecommendationService",
+	HandlerType: (*RecommendationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListItems",
+			Handler:    _RecommendationService_ListItems_Handler,
+		},
+		{
+			MethodName: "GetItem",
+			Handler:    _RecommendationService_GetItem_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _RecommendationService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUserProfile",
+			Handler:    _RecommendationService_GetUserProfile_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _RecommendationService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUserFrequentItems",
+			Handler:    _RecommendationService_GetUserFrequentItems_Handler,
+		},
+		{
+			MethodName: "GetUserCoOrderedItems",
+			Handler:    _RecommendationService_GetUserCoOrderedItems_Handler,
+		},
+		{
+			MethodName: "GetGlobalCoOrderedItems",
+			Handler:    _RecommendationService_GetGlobalCoOrderedItems_Handler,
+		},
+		{
+			MethodName: "GetTrendingItems",
+			Handler:    _RecommendationService_GetTrendingItems_Handler,
+		},
+		{
+			MethodName: "GetRatingBasedItems",
+			Handler:    _RecommendationService_GetRatingBasedItems_Handler,
+		},
+		{
+			MethodName: "GetHybridRecommendations",
+			Handler:    _RecommendationService_GetHybridRecommendations_Handler,
+		},
+		{
+			MethodName: "GetBundleRecommendations",
+			Handler:    _RecommendationService_GetBundleRecommendations_Handler,
+		},
+		{
+			MethodName: "GetReorderSuggestions",
+			Handler:    _RecommendationService_GetReorderSuggestions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCartRecommendations",
+			Handler:       _RecommendationService_StreamCartRecommendations_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "restaurant/v1/restaurant.proto",
+}
@@ -42,7 +42,7 @@ export interface RecommendationResponse {
 
 // Create axios instance with default config
 const api = axios.create({
-  baseURL: '/api',
+  baseURL: '/api/v1',
   timeout: 10000,
   headers: {
     'Content-Type': 'application/json',